@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+)
+
+func runConfig(a *app, args []string) error {
+	if len(args) == 0 || args[0] != "validate" {
+		return fmt.Errorf("usage: config validate [--rpc] [flags]: %w", errUsage)
+	}
+	return runConfigValidate(a, args[1:])
+}
+
+// runConfigValidate checks that the config file matches the schema and is consistent, without connecting to the database.
+// With --rpc, chain ids of the RPC urls and bridge contracts of the active bridges are checked as well.
+func runConfigValidate(a *app, args []string) error {
+	fs := a.newFlagSet("config validate")
+	checkRPC := fs.Bool("rpc", false, "check chain ids of the RPC urls and bridge contracts of the active bridges")
+	timeout := fs.Duration("timeout", time.Minute, "timeout for RPC checks")
+	if err := a.parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := a.readConfig()
+	if err != nil {
+		var schemaErr *config.SchemaError
+		if errors.As(err, &schemaErr) {
+			for _, v := range schemaErr.Violations {
+				a.logger.WithField("path", v.Path).Error(v.Message)
+			}
+		}
+		return err
+	}
+	a.logger.WithField("bridges", len(cfg.Bridges)).Info("config is valid")
+	if !*checkRPC {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if failed := checkBridgeContracts(ctx, a.logger, cfg); failed > 0 {
+		return fmt.Errorf("%d rpc checks failed: %w", failed, config.ErrInvalidConfig)
+	}
+	a.logger.Info("rpc checks passed")
+	return nil
+}
+
+// checkBridgeContracts dials RPC urls of all chains used by the active bridges, and checks that
+// configured bridge contracts respond to the calls of their ABI. It returns the number of failed checks.
+func checkBridgeContracts(ctx context.Context, logger logrus.FieldLogger, cfg *config.Config) int {
+	failed := 0
+	clients := make(map[string]ethclient.Client)
+	defer func() {
+		for _, client := range clients {
+			if client != nil {
+				client.Close()
+			}
+		}
+	}()
+	getClient := func(chainCfg *config.ChainConfig) (ethclient.Client, bool) {
+		if client, ok := clients[chainCfg.ChainID]; ok {
+			return client, client != nil
+		}
+		client, err := newClient(chainCfg)
+		if err != nil {
+			logger.WithField("chain_id", chainCfg.ChainID).WithError(err).Error("rpc check failed")
+			failed++
+			clients[chainCfg.ChainID] = nil
+			return nil, false
+		}
+		clients[chainCfg.ChainID] = client
+		return client, true
+	}
+
+	bridges := cfg.ActiveBridges()
+	ids := make([]string, 0, len(bridges))
+	for id := range bridges {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		bridgeCfg := bridges[id]
+		for i, sideCfg := range []*config.BridgeSideConfig{bridgeCfg.Home, bridgeCfg.Foreign} {
+			client, ok := getClient(sideCfg.Chain)
+			if !ok {
+				continue
+			}
+			fields := logrus.Fields{
+				"bridge_id": id,
+				"home":      i == 0,
+				"address":   sideCfg.Address,
+			}
+			if err := checkBridgeContract(ctx, client, bridgeCfg.BridgeMode, sideCfg); err != nil {
+				logger.WithFields(fields).WithError(err).Error("bridge contract check failed")
+				failed++
+				continue
+			}
+			logger.WithFields(fields).Debug("bridge contract check passed")
+		}
+	}
+	return failed
+}
+
+func checkBridgeContract(ctx context.Context, client ethclient.Client, mode config.BridgeMode, cfg *config.BridgeSideConfig) error {
+	bridgeContract := contract.NewBridgeContract(client, cfg.Address, mode)
+	validatorContract, err := bridgeContract.ValidatorContractAddress(ctx)
+	if err != nil {
+		return err
+	}
+	if validatorContract == (common.Address{}) {
+		return fmt.Errorf("bridge contract returned zero validator contract address: %w", config.ErrInvalidConfig)
+	}
+	if cfg.ValidatorContractAddress != (common.Address{}) && cfg.ValidatorContractAddress != validatorContract {
+		return fmt.Errorf("configured validator contract %s differs from %s returned by the bridge contract: %w",
+			cfg.ValidatorContractAddress, validatorContract, config.ErrInvalidConfig)
+	}
+	requiredSignatures, err := bridgeContract.RequiredSignatures(ctx)
+	if err != nil {
+		return err
+	}
+	if requiredSignatures == 0 {
+		return fmt.Errorf("bridge contract returned zero required signatures: %w", config.ErrInvalidConfig)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/omni/tokenbridge-monitor/presenter"
+	"github.com/omni/tokenbridge-monitor/relay"
+	"github.com/omni/tokenbridge-monitor/repository"
+)
+
+func runExecuteSignatures(a *app, args []string) error {
+	var bridgeID, msgHash, from string
+	var simulate bool
+	fs := a.newFlagSet("execute-signatures")
+	fs.StringVar(&bridgeID, "bridgeId", "", "bridgeId of the collected message")
+	fs.StringVar(&msgHash, "msgHash", "", "hash of the collected home-to-foreign message")
+	fs.BoolVar(&simulate, "simulate", false, "execute the transaction with eth_call on the foreign chain")
+	fs.StringVar(&from, "from", "", "sender address used in the simulation, zero address by default")
+	if err := a.parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := a.readConfig()
+	if err != nil {
+		return err
+	}
+
+	if bridgeID == "" {
+		return fmt.Errorf("bridgeId is not specified: %w", errUsage)
+	}
+	bridgeCfg, ok := cfg.Bridges[bridgeID]
+	if !ok || bridgeCfg == nil {
+		return fmt.Errorf("bridge config for bridgeId %q is not found: %w", bridgeID, errUsage)
+	}
+	hash, err := hexutil.Decode(msgHash)
+	if err != nil || len(hash) != common.HashLength {
+		return fmt.Errorf("msgHash %q is not a valid hash: %w", msgHash, errUsage)
+	}
+	if from != "" && !common.IsHexAddress(from) {
+		return fmt.Errorf("from %q is not a valid address: %w", from, errUsage)
+	}
+
+	dbConn, err := a.connectDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+
+	homeClient, err := newClient(bridgeCfg.Home.Chain)
+	if err != nil {
+		return err
+	}
+	foreignClient, err := newClient(bridgeCfg.Foreign.Chain)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	builder := relay.NewExecuteSignaturesBuilder(repository.NewRepo(dbConn), bridgeCfg, homeClient, foreignClient)
+	tx, err := builder.Build(ctx, common.BytesToHash(hash))
+	if err != nil {
+		return fmt.Errorf("can't build executeSignatures transaction: %w", err)
+	}
+	res := presenter.NewExecuteSignaturesTxInfo(tx)
+	if simulate {
+		sender := common.HexToAddress(from)
+		sim, err2 := builder.Simulate(ctx, tx, sender)
+		if err2 != nil {
+			return err2
+		}
+		res.Simulation = &presenter.SimulationInfo{From: sender, Success: sim.Success, Error: sim.Error}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
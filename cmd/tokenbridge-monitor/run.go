@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/health"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/monitor"
+	"github.com/omni/tokenbridge-monitor/presenter"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/retention"
+)
+
+const (
+	shutdownTimeout        = 20 * time.Second
+	metricsShutdownTimeout = 5 * time.Second
+)
+
+func runMonitor(a *app, args []string) error {
+	fs := a.newFlagSet("run")
+	if err := a.parseFlags(fs, args); err != nil {
+		return err
+	}
+	logger := a.logger
+
+	cfg, err := a.readConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Bridges = cfg.ActiveBridges()
+	lastChecksum, err := fileChecksum(a.configPath)
+	if err != nil {
+		return fmt.Errorf("can't read config: %w", err)
+	}
+
+	dbConn, err := a.connectDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+
+	healthHandler := health.NewHandler(logger.WithField("service", "health"), dbConn)
+	healthHandler.Register(http.DefaultServeMux)
+	http.Handle("/metrics", promhttp.Handler())
+	metricsServer := &http.Server{
+		Addr:              ":2112",
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		err := metricsServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.WithError(err).Fatal("can't start listener for prometheus metrics")
+		}
+	}()
+
+	repo := repository.NewRepo(dbConn)
+	var pr *presenter.Presenter
+	if cfg.Presenter != nil {
+		pr, err = presenter.NewPresenter(logger.WithField("service", "presenter"), repo, cfg)
+		if err != nil {
+			return fmt.Errorf("can't create presenter: %w", err)
+		}
+		go func() {
+			err := pr.Serve(cfg.Presenter.Host)
+			if err != nil {
+				logger.WithError(err).Fatal("can't serve presenter")
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	supervisor := monitor.NewSupervisor(logger, dbConn, repo)
+	if err = supervisor.Apply(ctx, cfg); err != nil {
+		return fmt.Errorf("can't start bridge monitors: %w", err)
+	}
+	healthHandler.SetMonitors(statusProviders(supervisor.Monitors()))
+	if pr != nil {
+		pr.SetMonitors(bridgeMonitors(supervisor.Monitors()))
+	}
+	if cfg.Retention != nil {
+		go retention.NewPruner(logger.WithField("service", "retention"), repo, cfg).Start(ctx)
+	}
+
+	// presenter, database, metrics and retention settings are not reloaded, as they require restart
+	reload := func() {
+		if checksum, err2 := fileChecksum(a.configPath); err2 == nil {
+			lastChecksum = checksum
+		}
+		newCfg, err2 := config.ReadConfigFromFile(a.configPath)
+		if err2 != nil {
+			logger.WithError(err2).Error("can't read new config, keeping the previous one")
+			return
+		}
+		a.applyLogLevel(newCfg)
+		if err2 = supervisor.Apply(ctx, newCfg); err2 != nil {
+			logger.WithError(err2).Error("failed to apply new config")
+		}
+		healthHandler.SetMonitors(statusProviders(supervisor.Monitors()))
+		if pr != nil {
+			pr.SetMonitors(bridgeMonitors(supervisor.Monitors()))
+		}
+		logger.Info("config was reloaded")
+	}
+
+	var watchTicker <-chan time.Time
+	if cfg.ConfigReloadInterval > 0 {
+		ticker := time.NewTicker(cfg.ConfigReloadInterval)
+		defer ticker.Stop()
+		watchTicker = ticker.C
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for {
+		select {
+		case sig := <-c:
+			if sig == syscall.SIGHUP {
+				logger.Info("caught SIGHUP, reloading config")
+				reload()
+				continue
+			}
+			logger.WithField("signal", sig).Warn("caught termination signal, gracefully terminating")
+			cancel()
+			shutdown(logger, supervisor, pr, metricsServer)
+			return nil
+		case <-watchTicker:
+			checksum, err2 := fileChecksum(a.configPath)
+			if err2 != nil {
+				logger.WithError(err2).Error("can't check config file for changes")
+				continue
+			}
+			if checksum != lastChecksum {
+				logger.Info("config file was modified, reloading config")
+				reload()
+			}
+		}
+	}
+}
+
+// shutdown waits for bridge monitors to persist their current logs batches and drains the presenter server.
+// Metrics server is stopped last, so that the final state of the monitors can still be scraped.
+func shutdown(logger logging.Logger, supervisor *monitor.Supervisor, pr *presenter.Presenter, metricsServer *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	wg := new(sync.WaitGroup)
+	if pr != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := pr.Shutdown(ctx); err != nil {
+				logger.WithError(err).Error("failed to gracefully shutdown presenter")
+			}
+		}()
+	}
+	if err := supervisor.Shutdown(ctx); err != nil {
+		logger.WithError(err).Error("failed to gracefully shutdown bridge monitors")
+	}
+	wg.Wait()
+
+	metricsCtx, metricsCancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+	defer metricsCancel()
+	if err := metricsServer.Shutdown(metricsCtx); err != nil {
+		logger.WithError(err).Error("failed to gracefully shutdown metrics server")
+	}
+	logger.Info("monitor was stopped")
+}
+
+func statusProviders(monitors []*monitor.Monitor) []health.StatusProvider {
+	res := make([]health.StatusProvider, len(monitors))
+	for i, m := range monitors {
+		res[i] = m
+	}
+	return res
+}
+
+func bridgeMonitors(monitors []*monitor.Monitor) map[string]presenter.BridgeMonitor {
+	res := make(map[string]presenter.BridgeMonitor, len(monitors))
+	for _, m := range monitors {
+		res[m.BridgeID()] = m
+	}
+	return res
+}
+
+func fileChecksum(path string) ([sha256.Size]byte, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("can't read from file: %w", err)
+	}
+	return sha256.Sum256(blob), nil
+}
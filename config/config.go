@@ -18,9 +18,12 @@ var (
 )
 
 type RPCConfig struct {
-	Host    string        `yaml:"host" json:"-"` // hidden from public presenter endpoint
-	Timeout time.Duration `yaml:"timeout"`
-	RPS     float64       `yaml:"rps"`
+	// Host is hidden from public presenter endpoint, since it might contain an API key.
+	Host     Secret        `yaml:"host" json:"-"`
+	HostEnv  string        `yaml:"host_env" json:"-"`
+	HostFile string        `yaml:"host_file" json:"-"`
+	Timeout  time.Duration `yaml:"timeout"`
+	RPS      float64       `yaml:"rps"`
 }
 
 type ChainConfig struct {
@@ -30,6 +33,9 @@ type ChainConfig struct {
 	BlockIndexInterval   time.Duration `yaml:"block_index_interval"`
 	SafeLogsRequest      bool          `yaml:"safe_logs_request"`
 	ExplorerTxLinkFormat string        `yaml:"explorer_tx_link_format"`
+	// ValidatorBalanceThreshold is a minimal native coin balance of validators and relayers on the chain,
+	// below which low balance alert is triggered. Zero value disables the alert.
+	ValidatorBalanceThreshold float64 `yaml:"validator_balance_threshold"`
 }
 
 type TokenConfig struct {
@@ -37,6 +43,9 @@ type TokenConfig struct {
 	StartBlock         uint             `yaml:"start_block"`
 	EndBlock           uint             `yaml:"end_block"`
 	BlacklistedSenders []common.Address `yaml:"blacklisted_senders"`
+	// InvestmentContracts are the contracts holding the invested bridge tokens, e.g. cDAI or sDAI.
+	// Transfers to and from them are accounted as investments and withdrawals, instead of user transfers.
+	InvestmentContracts []common.Address `yaml:"investment_contracts"`
 }
 
 type BridgeSideConfig struct {
@@ -49,6 +58,7 @@ type BridgeSideConfig struct {
 	MaxBlockRangeSize        uint             `yaml:"max_block_range_size"`
 	WhitelistedSenders       []common.Address `yaml:"whitelisted_senders"`
 	ErcToNativeTokens        []TokenConfig    `yaml:"erc_to_native_tokens"`
+	RelayerAddresses         []common.Address `yaml:"relayer_addresses"`
 }
 
 type BridgeAlertConfig struct {
@@ -63,24 +73,115 @@ const (
 	BridgeModeErcToNative      BridgeMode = "ERC_TO_NATIVE"
 )
 
+type BridgeLimitsConfig struct {
+	Interval time.Duration `yaml:"interval"`
+	// AlertThreshold is a percentage of the remaining daily limit, below which limits alert is triggered.
+	AlertThreshold float64          `yaml:"alert_threshold"`
+	HomeTokens     []common.Address `yaml:"home_tokens"`
+	ForeignTokens  []common.Address `yaml:"foreign_tokens"`
+}
+
+// SignatureVerificationConfig enables verification of the signatures collected for home-to-foreign messages.
+type SignatureVerificationConfig struct {
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize uint          `yaml:"batch_size"`
+}
+
+// ExecutionSimulationConfig enables periodic eth_call simulation of the pending AMB messages.
+type ExecutionSimulationConfig struct {
+	Interval time.Duration `yaml:"interval"`
+	// MaxAge excludes old stuck messages, which are already reported by the stuck message alerts.
+	MaxAge time.Duration `yaml:"max_age"`
+}
+
 type BridgeConfig struct {
-	ID         string                        `yaml:"-"`
-	BridgeMode BridgeMode                    `yaml:"bridge_mode"`
-	Home       *BridgeSideConfig             `yaml:"home"`
-	Foreign    *BridgeSideConfig             `yaml:"foreign"`
-	Alerts     map[string]*BridgeAlertConfig `yaml:"alerts"`
+	ID                    string                        `yaml:"-"`
+	BridgeMode            BridgeMode                    `yaml:"bridge_mode"`
+	Home                  *BridgeSideConfig             `yaml:"home"`
+	Foreign               *BridgeSideConfig             `yaml:"foreign"`
+	Alerts                map[string]*BridgeAlertConfig `yaml:"alerts"`
+	Limits                *BridgeLimitsConfig           `yaml:"limits"`
+	SignatureVerification *SignatureVerificationConfig  `yaml:"signature_verification"`
+	ExecutionSimulation   *ExecutionSimulationConfig    `yaml:"execution_simulation"`
 }
 
 type DBConfig struct {
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	Host     string `yaml:"host"`
-	Port     int    `yaml:"port"`
-	DB       string `yaml:"database"`
+	User         string `yaml:"user"`
+	Password     Secret `yaml:"password" json:"-"`
+	PasswordEnv  string `yaml:"password_env" json:"-"`
+	PasswordFile string `yaml:"password_file" json:"-"`
+	Host         string `yaml:"host"`
+	Port         int    `yaml:"port"`
+	DB           string `yaml:"database"`
+}
+
+type GraphQLConfig struct {
+	MaxDepth uint `yaml:"max_depth"`
+	MaxCost  uint `yaml:"max_cost"`
+}
+
+type APIKeyScope string
+
+const (
+	APIKeyScopeRead  APIKeyScope = "read"
+	APIKeyScopeAdmin APIKeyScope = "admin"
+)
+
+type APIKeyConfig struct {
+	Name    string        `yaml:"name"`
+	Key     Secret        `yaml:"key" json:"-"`
+	KeyEnv  string        `yaml:"key_env" json:"-"`
+	KeyFile string        `yaml:"key_file" json:"-"`
+	Scopes  []APIKeyScope `yaml:"scopes"`
+	RPS     float64       `yaml:"rps"`
+	Burst   int           `yaml:"burst"`
+}
+
+type AuthConfig struct {
+	AnonymousScopes []APIKeyScope   `yaml:"anonymous_scopes"`
+	APIKeys         []*APIKeyConfig `yaml:"api_keys"`
+}
+
+type RateLimitConfig struct {
+	PerIPRPS          float64 `yaml:"per_ip_rps"`
+	PerIPBurst        int     `yaml:"per_ip_burst"`
+	PerKeyRPS         float64 `yaml:"per_key_rps"`
+	PerKeyBurst       int     `yaml:"per_key_burst"`
+	TrustForwardedFor bool    `yaml:"trust_forwarded_for"`
+}
+
+// ABIFileConfig is a JSON contract ABI file, used for decoding of the AMB calls to the given executors.
+type ABIFileConfig struct {
+	File      string           `yaml:"file"`
+	Addresses []common.Address `yaml:"addresses"`
+}
+
+type ABIRegistryConfig struct {
+	ABIs []*ABIFileConfig `yaml:"abis"`
+	// SignaturesFile is a local 4-byte signature database, with one function signature per line.
+	SignaturesFile string `yaml:"signatures_file"`
 }
 
 type PresenterConfig struct {
-	Host string `yaml:"host"`
+	Host        string             `yaml:"host"`
+	GraphQL     *GraphQLConfig     `yaml:"graphql"`
+	Auth        *AuthConfig        `yaml:"auth"`
+	RateLimit   *RateLimitConfig   `yaml:"rate_limit"`
+	ABIRegistry *ABIRegistryConfig `yaml:"abi_registry"`
+}
+
+// RetentionPolicyConfig is a retention policy of the particular chain.
+type RetentionPolicyConfig struct {
+	// Horizon is the minimal age of the pruned logs and block timestamps, zero disables pruning for the chain.
+	Horizon time.Duration `yaml:"horizon"`
+}
+
+type RetentionConfig struct {
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize uint          `yaml:"batch_size"`
+	// Horizon is the default horizon for chains without explicit policy.
+	Horizon time.Duration                     `yaml:"horizon"`
+	Chains  map[string]*RetentionPolicyConfig `yaml:"chains"`
 }
 
 type Config struct {
@@ -91,6 +192,9 @@ type Config struct {
 	DisabledBridges []string                 `yaml:"disabled_bridges"`
 	EnabledBridges  []string                 `yaml:"enabled_bridges"`
 	Presenter       *PresenterConfig         `yaml:"presenter"`
+	Retention       *RetentionConfig         `yaml:"retention"`
+	// ConfigReloadInterval enables periodic checks of the config file for changes, when non-zero.
+	ConfigReloadInterval time.Duration `yaml:"config_reload_interval"`
 }
 
 func (cfg *ChainConfig) FormatTxLink(txHash fmt.Stringer) string {
@@ -112,7 +216,60 @@ func (cfg *Config) GetChainConfig(chainID string) *ChainConfig {
 	return nil
 }
 
+// ActiveBridges returns configs of the bridges that should be monitored,
+// taking into account enabled_bridges and disabled_bridges lists.
+func (cfg *Config) ActiveBridges() map[string]*BridgeConfig {
+	bridges := make(map[string]*BridgeConfig, len(cfg.Bridges))
+	if cfg.EnabledBridges != nil {
+		for _, bridge := range cfg.EnabledBridges {
+			if bridgeCfg, ok := cfg.Bridges[bridge]; ok {
+				bridges[bridge] = bridgeCfg
+			}
+		}
+	} else {
+		for bridge, bridgeCfg := range cfg.Bridges {
+			bridges[bridge] = bridgeCfg
+		}
+	}
+	for _, bridge := range cfg.DisabledBridges {
+		delete(bridges, bridge)
+	}
+	return bridges
+}
+
 func (cfg *Config) init() error {
+	for chainName, chain := range cfg.Chains {
+		if err := chain.init(); err != nil {
+			return fmt.Errorf("can't init chain config for %s: %w", chainName, err)
+		}
+	}
+	if cfg.DBConfig != nil {
+		if err := resolveOptionalSecret("password", &cfg.DBConfig.Password, cfg.DBConfig.PasswordEnv, cfg.DBConfig.PasswordFile); err != nil {
+			return fmt.Errorf("can't init postgres config: %w", err)
+		}
+	}
+	for _, bridge := range cfg.EnabledBridges {
+		if _, ok := cfg.Bridges[bridge]; !ok {
+			return fmt.Errorf("unknown bridge %q in enabled_bridges: %w", bridge, ErrInvalidConfig)
+		}
+	}
+	for _, bridge := range cfg.DisabledBridges {
+		if _, ok := cfg.Bridges[bridge]; !ok {
+			return fmt.Errorf("unknown bridge %q in disabled_bridges: %w", bridge, ErrInvalidConfig)
+		}
+	}
+	if cfg.Presenter != nil {
+		err := cfg.Presenter.init()
+		if err != nil {
+			return fmt.Errorf("can't init presenter config: %w", err)
+		}
+	}
+	if cfg.Retention != nil {
+		err := cfg.Retention.init(cfg)
+		if err != nil {
+			return fmt.Errorf("can't init retention config: %w", err)
+		}
+	}
 	for bridgeID, bridge := range cfg.Bridges {
 		bridge.ID = bridgeID
 		err := bridge.init(cfg)
@@ -123,6 +280,129 @@ func (cfg *Config) init() error {
 	return nil
 }
 
+func (cfg *ChainConfig) init() error {
+	if cfg.RPC == nil {
+		return fmt.Errorf("rpc config is missing: %w", ErrInvalidConfig)
+	}
+	if err := resolveSecret("host", &cfg.RPC.Host, cfg.RPC.HostEnv, cfg.RPC.HostFile); err != nil {
+		return fmt.Errorf("can't init rpc config: %w", err)
+	}
+	return nil
+}
+
+func (cfg *PresenterConfig) init() error {
+	if cfg.GraphQL != nil {
+		cfg.GraphQL.init()
+	}
+	if cfg.Auth != nil {
+		err := cfg.Auth.init()
+		if err != nil {
+			return fmt.Errorf("can't init auth config: %w", err)
+		}
+	}
+	if cfg.RateLimit != nil {
+		cfg.RateLimit.init()
+	}
+	if cfg.ABIRegistry != nil {
+		for _, abiFile := range cfg.ABIRegistry.ABIs {
+			if abiFile.File == "" || len(abiFile.Addresses) == 0 {
+				return fmt.Errorf("abi registry entries must have a file and executor addresses: %w", ErrInvalidConfig)
+			}
+		}
+	}
+	return nil
+}
+
+func (cfg *AuthConfig) init() error {
+	if err := validateScopes(cfg.AnonymousScopes); err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(cfg.APIKeys))
+	for _, key := range cfg.APIKeys {
+		if key.Name == "" {
+			return fmt.Errorf("api key name must be non-empty: %w", ErrInvalidConfig)
+		}
+		if err := resolveSecret("key", &key.Key, key.KeyEnv, key.KeyFile); err != nil {
+			return fmt.Errorf("invalid api key %q: %w", key.Name, err)
+		}
+		if names[key.Name] {
+			return fmt.Errorf("duplicate api key name %q: %w", key.Name, ErrInvalidConfig)
+		}
+		names[key.Name] = true
+		if err := validateScopes(key.Scopes); err != nil {
+			return fmt.Errorf("invalid api key %q: %w", key.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateScopes(scopes []APIKeyScope) error {
+	for _, scope := range scopes {
+		if scope != APIKeyScopeRead && scope != APIKeyScopeAdmin {
+			return fmt.Errorf("unknown api key scope %q: %w", scope, ErrInvalidConfig)
+		}
+	}
+	return nil
+}
+
+func (cfg *RateLimitConfig) init() {
+	if cfg.PerIPBurst <= 0 {
+		cfg.PerIPBurst = int(math.Ceil(cfg.PerIPRPS))
+	}
+	if cfg.PerKeyBurst <= 0 {
+		cfg.PerKeyBurst = int(math.Ceil(cfg.PerKeyRPS))
+	}
+}
+
+func (cfg *GraphQLConfig) init() {
+	if cfg.MaxDepth == 0 {
+		cfg.MaxDepth = 10
+	}
+	if cfg.MaxCost == 0 {
+		cfg.MaxCost = 5000
+	}
+}
+
+func (cfg *RetentionConfig) init(parent *Config) error {
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Hour
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 10000
+	}
+	for chainName := range cfg.Chains {
+		if _, ok := parent.Chains[chainName]; !ok {
+			return fmt.Errorf("unknown chain %q in retention policies: %w", chainName, ErrInvalidConfig)
+		}
+	}
+	return nil
+}
+
+// ChainHorizon returns retention horizon of the given chain, zero horizon means that the chain is not pruned.
+func (cfg *RetentionConfig) ChainHorizon(chainName string) time.Duration {
+	if policy, ok := cfg.Chains[chainName]; ok && policy != nil {
+		return policy.Horizon
+	}
+	return cfg.Horizon
+}
+
+// LimitTokens returns tokens, which limits are monitored on the given side of the bridge.
+// Single nil token is returned for the single-token bridges.
+func (cfg *BridgeConfig) LimitTokens(side *BridgeSideConfig) []*common.Address {
+	tokens := cfg.Limits.ForeignTokens
+	if side == cfg.Home {
+		tokens = cfg.Limits.HomeTokens
+	}
+	if len(tokens) == 0 {
+		return []*common.Address{nil}
+	}
+	res := make([]*common.Address, len(tokens))
+	for i := range tokens {
+		res[i] = &tokens[i]
+	}
+	return res
+}
+
 func (cfg *BridgeConfig) init(parent *Config) error {
 	err := cfg.Home.init(parent)
 	if err != nil {
@@ -145,6 +425,33 @@ func (cfg *BridgeConfig) init(parent *Config) error {
 		}
 		cfg.BridgeMode = BridgeModeArbitraryMessage
 	}
+	if cfg.Limits != nil {
+		if cfg.BridgeMode != BridgeModeErcToNative {
+			return fmt.Errorf("limits are supported only by %s bridges: %w", BridgeModeErcToNative, ErrInvalidConfig)
+		}
+		if cfg.Limits.Interval == 0 {
+			cfg.Limits.Interval = 5 * time.Minute
+		}
+	}
+	if cfg.SignatureVerification != nil {
+		if cfg.SignatureVerification.Interval == 0 {
+			cfg.SignatureVerification.Interval = time.Minute
+		}
+		if cfg.SignatureVerification.BatchSize == 0 {
+			cfg.SignatureVerification.BatchSize = 100
+		}
+	}
+	if cfg.ExecutionSimulation != nil {
+		if cfg.BridgeMode != BridgeModeArbitraryMessage {
+			return fmt.Errorf("execution simulation is supported only by %s bridges: %w", BridgeModeArbitraryMessage, ErrInvalidConfig)
+		}
+		if cfg.ExecutionSimulation.Interval == 0 {
+			cfg.ExecutionSimulation.Interval = 5 * time.Minute
+		}
+		if cfg.ExecutionSimulation.MaxAge == 0 {
+			cfg.ExecutionSimulation.MaxAge = 24 * time.Hour
+		}
+	}
 	for alertName, alertCfg := range cfg.Alerts {
 		if alertCfg == nil {
 			alertCfg = &BridgeAlertConfig{}
@@ -200,7 +507,12 @@ func (cfg *BridgeSideConfig) ErcToNativeTokenAddresses(fromBlock, toBlock uint)
 	return addresses
 }
 
+// ReadConfig parses and validates the yaml config.
+// Violations of the config JSON schema are reported as *SchemaError, listing paths of all invalid values.
 func ReadConfig(blob []byte) (*Config, error) {
+	if err := validateSchema(blob); err != nil {
+		return nil, err
+	}
 	cfg := new(Config)
 	err := parseYaml(cfg, blob)
 	if err != nil {
@@ -213,14 +525,10 @@ func ReadConfig(blob []byte) (*Config, error) {
 	return cfg, nil
 }
 
-func ReadConfigWithEnv(blob []byte) (*Config, error) {
-	return ReadConfig([]byte(os.ExpandEnv(string(blob))))
-}
-
 func ReadConfigFromFile(path string) (*Config, error) {
 	blob, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("can't read from file: %w", err)
 	}
-	return ReadConfigWithEnv(blob)
+	return ReadConfig(blob)
 }
@@ -1,7 +1,12 @@
 package config_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,7 +21,7 @@ const testCfg = `
 chains:
   mainnet:
     rpc:
-      host: https://mainnet.infura.io/v3/${INFURA_PROJECT_KEY}
+      host: https://mainnet.infura.io/v3/12345678
       timeout: 30s
       rps: 10
     chain_id: 1
@@ -95,10 +100,9 @@ presenter:
   host: 0.0.0.0:3333
 `
 
-//nolint:paralleltest
-func TestReadConfigWithEnv(t *testing.T) {
-	t.Setenv("INFURA_PROJECT_KEY", "12345678")
-	cfg, err := config.ReadConfigWithEnv([]byte(testCfg))
+func TestReadConfig(t *testing.T) {
+	t.Parallel()
+	cfg, err := config.ReadConfig([]byte(testCfg))
 	require.NoError(t, err)
 	mainnetChainCfg := &config.ChainConfig{
 		RPC: &config.RPCConfig{
@@ -274,3 +278,211 @@ func TestChainConfig_FormatTxLink(t *testing.T) {
 	require.Equal(t, link, cfg.GetChainConfig("1").FormatTxLink(common.Hash{}))
 	require.Equal(t, txHash, cfg.GetChainConfig("123").FormatTxLink(common.Hash{}))
 }
+
+func TestReadConfig_PresenterAuth(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.ReadConfig([]byte(testCfg + `  auth:
+    anonymous_scopes: [ read ]
+    api_keys:
+      - name: ops
+        key: secret
+        scopes: [ admin ]
+  rate_limit:
+    per_ip_rps: 2.5
+`))
+	require.NoError(t, err)
+	require.Equal(t, []config.APIKeyScope{config.APIKeyScopeRead}, cfg.Presenter.Auth.AnonymousScopes)
+	require.Equal(t, 3, cfg.Presenter.RateLimit.PerIPBurst)
+
+	_, err = config.ReadConfig([]byte(testCfg + `  auth:
+    api_keys:
+      - name: ops
+        key: secret
+        scopes: [ write ]
+`))
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+}
+
+func TestReadConfig_Retention(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.ReadConfig([]byte(testCfg + `retention:
+  horizon: 720h
+  chains:
+    xdai:
+      horizon: 0s
+`))
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, cfg.Retention.Interval)
+	require.Equal(t, uint(10000), cfg.Retention.BatchSize)
+	require.Equal(t, 720*time.Hour, cfg.Retention.ChainHorizon("mainnet"))
+	require.Zero(t, cfg.Retention.ChainHorizon("xdai"))
+
+	_, err = config.ReadConfig([]byte(testCfg + `retention:
+  chains:
+    unknown:
+      horizon: 24h
+`))
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+}
+
+func TestConfig_ActiveBridges(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		Bridges: map[string]*config.BridgeConfig{
+			"a": {ID: "a"},
+			"b": {ID: "b"},
+			"c": {ID: "c"},
+		},
+	}
+	require.Len(t, cfg.ActiveBridges(), 3)
+
+	cfg.DisabledBridges = []string{"b"}
+	require.Equal(t, map[string]*config.BridgeConfig{"a": cfg.Bridges["a"], "c": cfg.Bridges["c"]}, cfg.ActiveBridges())
+
+	cfg.EnabledBridges = []string{"a", "b"}
+	require.Equal(t, map[string]*config.BridgeConfig{"a": cfg.Bridges["a"]}, cfg.ActiveBridges())
+}
+
+func TestReadConfig_Schema(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		old, new string
+		path     string
+	}{
+		"unknown alert": {
+			old:  "      stuck_message_confirmation:\n",
+			new:  "      stuck_mesage_confirmation:\n",
+			path: "bridges.xdai-amb.alerts",
+		},
+		"invalid address": {
+			old:  "        - 0x73cA9C4e72fF109259cf7374F038faf950949C51\n",
+			new:  "        - 0x73cA9C4e72fF109259cf7374F038faf950949C5\n",
+			path: "bridges.xdai-amb.home.whitelisted_senders[0]",
+		},
+		"missing field": {
+			old:  "      start_block: 9130277\n",
+			new:  "",
+			path: "bridges.xdai-amb.foreign",
+		},
+		"unknown field": {
+			old:  "      rps: 10\n",
+			new:  "      rps: 10\n      retries: 3\n",
+			path: "chains.mainnet.rpc",
+		},
+	} {
+		require.Contains(t, testCfg, tc.old, name)
+		_, err := config.ReadConfig([]byte(strings.Replace(testCfg, tc.old, tc.new, 1)))
+		require.ErrorIs(t, err, config.ErrInvalidConfig, name)
+		var schemaErr *config.SchemaError
+		require.ErrorAs(t, err, &schemaErr, name)
+		require.Len(t, schemaErr.Violations, 1, name)
+		require.Equal(t, tc.path, schemaErr.Violations[0].Path, name)
+	}
+
+	_, err := config.ReadConfig([]byte(testCfg + "enabled_bridges: [ xdai, xdai-amb2 ]\n"))
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+	require.ErrorContains(t, err, "xdai-amb2")
+	_, err = config.ReadConfig([]byte(testCfg + "disabled_bridges: [ xdai ]\n"))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest
+func TestReadConfig_Secrets(t *testing.T) {
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("pa$$word\n"), 0o600))
+	t.Setenv("TEST_MAINNET_RPC_URL", "https://mainnet.infura.io/v3/secret-key")
+	t.Setenv("TEST_API_KEY", "secret-api-key")
+	withSecrets := func(cfg string) string {
+		cfg = strings.Replace(cfg, "host: https://mainnet.infura.io/v3/12345678", "host_env: TEST_MAINNET_RPC_URL", 1)
+		return strings.Replace(cfg, "password: test_password", "password_file: "+passwordFile, 1)
+	}
+
+	cfg, err := config.ReadConfig([]byte(withSecrets(testCfg) + `  auth:
+    api_keys:
+      - name: ops
+        key_env: TEST_API_KEY
+        scopes: [ admin ]
+`))
+	require.NoError(t, err)
+	require.Equal(t, "https://mainnet.infura.io/v3/secret-key", cfg.Chains["mainnet"].RPC.Host.Value())
+	require.Equal(t, "pa$$word", cfg.DBConfig.Password.Value())
+	require.Equal(t, "secret-api-key", cfg.Presenter.Auth.APIKeys[0].Key.Value())
+
+	blob, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	for _, secret := range []string{"secret-key", "pa$$word", "secret-api-key", "TEST_MAINNET_RPC_URL", passwordFile} {
+		require.NotContains(t, string(blob), secret)
+	}
+	for _, secret := range []string{"secret-key", "pa$$word", "secret-api-key"} {
+		require.NotContains(t, fmt.Sprintf("%v %+v %#v", cfg.DBConfig, cfg.Chains["mainnet"].RPC, cfg.Presenter.Auth.APIKeys[0]), secret)
+	}
+
+	for name, tc := range map[string]struct {
+		old, new string
+		msg      string
+	}{
+		"missing env": {
+			old: "host_env: TEST_MAINNET_RPC_URL",
+			new: "host_env: TEST_UNKNOWN_RPC_URL",
+			msg: "environment variable TEST_UNKNOWN_RPC_URL referenced by host_env is not set",
+		},
+		"missing file": {
+			old: "password_file: " + passwordFile,
+			new: "password_file: " + passwordFile + ".missing",
+			msg: "can't read file referenced by password_file",
+		},
+		"multiple references": {
+			old: "host_env: TEST_MAINNET_RPC_URL",
+			new: "host_env: TEST_MAINNET_RPC_URL\n      host: https://mainnet.infura.io/v3/12345678",
+			msg: "chains.mainnet.rpc",
+		},
+		"multiple password references": {
+			old: "password_file: " + passwordFile,
+			new: "password_file: " + passwordFile + "\n  password: test_password",
+			msg: "postgres",
+		},
+	} {
+		_, err = config.ReadConfig([]byte(strings.Replace(withSecrets(testCfg), tc.old, tc.new, 1)))
+		require.ErrorIs(t, err, config.ErrInvalidConfig, name)
+		require.ErrorContains(t, err, tc.msg, name)
+	}
+
+	// empty database password is allowed, same as before secret references were introduced
+	for _, password := range []string{"password: \"\"", ""} {
+		cfg, err = config.ReadConfig([]byte(strings.Replace(testCfg, "password: test_password", password, 1)))
+		require.NoError(t, err)
+		require.Empty(t, cfg.DBConfig.Password.Value())
+	}
+}
+
+func TestReadConfig_Limits(t *testing.T) {
+	t.Parallel()
+
+	limits := "    limits:\n      alert_threshold: 10\n      foreign_tokens:\n        - 0x6B175474E89094C44Da98b954EedeAC495271d0F\n"
+	cfg, err := config.ReadConfig([]byte(strings.Replace(testCfg, "  xdai-amb:\n", limits+"  xdai-amb:\n", 1)))
+	require.NoError(t, err)
+	bridge := cfg.Bridges["xdai"]
+	require.Equal(t, 5*time.Minute, bridge.Limits.Interval)
+	require.Equal(t, []*common.Address{nil}, bridge.LimitTokens(bridge.Home))
+	require.Equal(t, []*common.Address{&bridge.Limits.ForeignTokens[0]}, bridge.LimitTokens(bridge.Foreign))
+
+	_, err = config.ReadConfig([]byte(strings.Replace(testCfg, "postgres:\n", limits+"postgres:\n", 1)))
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+}
+
+func TestReadConfig_ExecutionSimulation(t *testing.T) {
+	t.Parallel()
+
+	simulation := "    execution_simulation: {}\n"
+	cfg, err := config.ReadConfig([]byte(strings.Replace(testCfg, "postgres:\n", simulation+"postgres:\n", 1)))
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Minute, cfg.Bridges["xdai-amb"].ExecutionSimulation.Interval)
+	require.Equal(t, 24*time.Hour, cfg.Bridges["xdai-amb"].ExecutionSimulation.MaxAge)
+
+	_, err = config.ReadConfig([]byte(strings.Replace(testCfg, "  xdai-amb:\n", simulation+"  xdai-amb:\n", 1)))
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed config.schema.json
+var schemaJSON string
+
+var configSchema = jsonschema.MustCompileString("config.schema.json", schemaJSON)
+
+// SchemaViolation is a single mismatch between the config and its JSON schema.
+type SchemaViolation struct {
+	// Path is a dot-separated location of the invalid value in the config, e.g. bridges.xdai.home.address.
+	Path    string
+	Message string
+}
+
+// SchemaError lists all violations of the config JSON schema.
+type SchemaError struct {
+	Violations []SchemaViolation
+}
+
+func (e *SchemaError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Path + ": " + v.Message
+	}
+	return "config does not match schema: " + strings.Join(msgs, "; ")
+}
+
+func (e *SchemaError) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+// validateSchema checks the yaml config against config.schema.json.
+func validateSchema(blob []byte) error {
+	var doc yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(blob))
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("can't parse yaml: %w", err)
+	}
+	value, err := yamlToJSON(&doc)
+	if err != nil {
+		return fmt.Errorf("can't parse yaml: %w", err)
+	}
+
+	err = configSchema.Validate(value)
+	var validationErr *jsonschema.ValidationError
+	if errors.As(err, &validationErr) {
+		return newSchemaError(validationErr)
+	}
+	if err != nil {
+		return fmt.Errorf("can't validate config schema: %w", err)
+	}
+	return nil
+}
+
+func newSchemaError(err *jsonschema.ValidationError) *SchemaError {
+	res := new(SchemaError)
+	seen := make(map[SchemaViolation]bool)
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				walk(cause)
+			}
+			return
+		}
+		v := SchemaViolation{Path: pointerToPath(e.InstanceLocation), Message: e.Message}
+		if !seen[v] {
+			seen[v] = true
+			res.Violations = append(res.Violations, v)
+		}
+	}
+	walk(err)
+	sort.SliceStable(res.Violations, func(i, j int) bool {
+		return res.Violations[i].Path < res.Violations[j].Path
+	})
+	return res
+}
+
+// pointerToPath converts JSON pointer (e.g. /bridges/xdai/home/whitelisted_senders/0)
+// into a more readable form (e.g. bridges.xdai.home.whitelisted_senders[0]).
+func pointerToPath(pointer string) string {
+	if pointer == "" {
+		return "(root)"
+	}
+	var sb strings.Builder
+	for _, part := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		if _, err := strconv.Atoi(part); err == nil && sb.Len() > 0 {
+			sb.WriteString("[" + part + "]")
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+// yamlToJSON converts yaml document into a value accepted by the schema validator.
+// Unlike yaml decoding into interface{}, hex scalars (e.g. 0x0000000000000000000000000000000000000000)
+// are kept as strings, since they are addresses, not integers.
+func yamlToJSON(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return map[string]interface{}{}, nil
+		}
+		return yamlToJSON(node.Content[0])
+	case yaml.AliasNode:
+		return yamlToJSON(node.Alias)
+	case yaml.MappingNode:
+		res := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := yamlToJSON(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			if node.Content[i].Tag == "!!merge" {
+				if merged, ok := value.(map[string]interface{}); ok {
+					for k, v := range merged {
+						if _, exists := res[k]; !exists {
+							res[k] = v
+						}
+					}
+				}
+				continue
+			}
+			res[node.Content[i].Value] = value
+		}
+		return res, nil
+	case yaml.SequenceNode:
+		res := make([]interface{}, len(node.Content))
+		for i, item := range node.Content {
+			value, err := yamlToJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = value
+		}
+		return res, nil
+	case yaml.ScalarNode:
+		return yamlScalarToJSON(node)
+	}
+	return nil, fmt.Errorf("unexpected yaml node kind %d at line %d: %w", node.Kind, node.Line, ErrInvalidConfig)
+}
+
+func yamlScalarToJSON(node *yaml.Node) (interface{}, error) {
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return nil, fmt.Errorf("invalid boolean at line %d: %w", node.Line, err)
+		}
+		return b, nil
+	case "!!int", "!!float":
+		if _, err := strconv.ParseFloat(node.Value, 64); err == nil && !strings.HasPrefix(strings.ToLower(node.Value), "0x") {
+			return json.Number(node.Value), nil
+		}
+	}
+	return node.Value, nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const redactedSecret = "[redacted]"
+
+// Secret is a sensitive config value, such as a database password or an RPC url with an API key.
+// It is never revealed by JSON encoding or string formatting, Value should be used to get the actual value.
+type Secret string
+
+func (s Secret) Value() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redactedSecret
+}
+
+func (s Secret) GoString() string {
+	return fmt.Sprintf("config.Secret(%q)", s.String())
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", s.String())), nil
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// resolveOptionalSecret is the same as resolveSecret, but the secret can also be left empty,
+// e.g. database password for trust or peer authentication.
+func resolveOptionalSecret(name string, secret *Secret, envName, filePath string) error {
+	if secret.Value() == "" && envName == "" && filePath == "" {
+		return nil
+	}
+	return resolveSecret(name, secret, envName, filePath)
+}
+
+// resolveSecret sets the secret value from one of the config references: inline value, environment variable name or file path.
+// Exactly one of the references must be specified, name is used for error messages.
+func resolveSecret(name string, secret *Secret, envName, filePath string) error {
+	set := 0
+	for _, ref := range []string{secret.Value(), envName, filePath} {
+		if ref != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("exactly one of %s, %s_env or %s_file must be specified: %w", name, name, name, ErrInvalidConfig)
+	}
+	switch {
+	case envName != "":
+		value, ok := os.LookupEnv(envName)
+		if !ok || value == "" {
+			return fmt.Errorf("environment variable %s referenced by %s_env is not set: %w", envName, name, ErrInvalidConfig)
+		}
+		*secret = Secret(value)
+	case filePath != "":
+		blob, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("can't read file referenced by %s_file: %s: %w", name, err, ErrInvalidConfig)
+		}
+		value := strings.TrimRight(string(blob), "\r\n")
+		if value == "" {
+			return fmt.Errorf("file %s referenced by %s_file is empty: %w", filePath, name, ErrInvalidConfig)
+		}
+		*secret = Secret(value)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package abi
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/omni/tokenbridge-monitor/config"
+)
+
+var ErrInvalidSignature = errors.New("invalid function signature")
+
+// DecodedCall is a contract call, decoded by the Registry.
+type DecodedCall struct {
+	// Method is the canonical function signature, e.g. transfer(address,uint256).
+	Method string
+	Args   []*DecodedArg
+}
+
+// DecodedArg is a single decoded call argument. Value is converted to a JSON friendly representation:
+// integers are decimal strings, byte arrays are hex strings, and tuples are objects.
+type DecodedArg struct {
+	Name  string
+	Type  string
+	Value interface{}
+}
+
+// Registry decodes calldata of the contract calls, using contract ABIs of the known addresses,
+// and a 4-byte signature database for all other calls.
+type Registry struct {
+	abis       map[common.Address]*gethabi.ABI
+	signatures map[[4]byte][]*gethabi.Method
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		abis:       make(map[common.Address]*gethabi.ABI),
+		signatures: make(map[[4]byte][]*gethabi.Method),
+	}
+}
+
+// LoadRegistry creates a registry from the ABI and signature files listed in the config.
+func LoadRegistry(cfg *config.ABIRegistryConfig) (*Registry, error) {
+	r := NewRegistry()
+	if cfg == nil {
+		return r, nil
+	}
+	for _, abiFile := range cfg.ABIs {
+		if err := r.loadABIFile(abiFile.File, abiFile.Addresses); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.SignaturesFile != "" {
+		f, err := os.Open(cfg.SignaturesFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't open signatures file: %w", err)
+		}
+		defer f.Close()
+		if err = r.AddSignatures(f); err != nil {
+			return nil, fmt.Errorf("can't read signatures file %s: %w", cfg.SignaturesFile, err)
+		}
+	}
+	return r, nil
+}
+
+func (r *Registry) loadABIFile(path string, addresses []common.Address) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("can't open abi file: %w", err)
+	}
+	defer f.Close()
+	contractABI, err := gethabi.JSON(f)
+	if err != nil {
+		return fmt.Errorf("can't parse abi file %s: %w", path, err)
+	}
+	for _, address := range addresses {
+		r.AddABI(address, contractABI)
+	}
+	return nil
+}
+
+// AddABI registers the contract ABI, used for decoding of the calls to the given address.
+func (r *Registry) AddABI(address common.Address, contractABI gethabi.ABI) {
+	r.abis[address] = &contractABI
+}
+
+// AddSignature registers the function signature, e.g. transfer(address,uint256), in the 4-byte database.
+func (r *Registry) AddSignature(signature string) error {
+	method, err := parseSignature(signature)
+	if err != nil {
+		return err
+	}
+	var selector [4]byte
+	copy(selector[:], method.ID)
+	for _, m := range r.signatures[selector] {
+		if m.Sig == method.Sig {
+			return nil
+		}
+	}
+	r.signatures[selector] = append(r.signatures[selector], method)
+	return nil
+}
+
+// AddSignatures reads function signatures line by line, empty lines and lines starting with # are skipped.
+func (r *Registry) AddSignatures(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		signature := strings.TrimSpace(scanner.Text())
+		if signature == "" || strings.HasPrefix(signature, "#") {
+			continue
+		}
+		if err := r.AddSignature(signature); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// DecodeCall decodes the calldata of the call to the given address. Contract ABI of the address takes
+// precedence over the signature database, in which the first signature that decodes the calldata is used.
+// Nil is returned if the calldata can't be decoded.
+func (r *Registry) DecodeCall(address common.Address, data []byte) *DecodedCall {
+	if len(data) < 4 {
+		return nil
+	}
+	if contractABI, ok := r.abis[address]; ok {
+		if method, err := contractABI.MethodById(data[:4]); err == nil {
+			if call, err2 := decodeArguments(method.Sig, method.Inputs, data[4:]); err2 == nil {
+				return call
+			}
+		}
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	for _, method := range r.signatures[selector] {
+		if call, err := decodeArguments(method.Sig, method.Inputs, data[4:]); err == nil {
+			return call
+		}
+	}
+	return nil
+}
+
+// DecodeArguments decodes ABI encoded arguments of the function with the given signature,
+// e.g. arguments of the AMB information request.
+func DecodeArguments(signature string, data []byte) (*DecodedCall, error) {
+	method, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	return decodeArguments(method.Sig, method.Inputs, data)
+}
+
+func parseSignature(signature string) (*gethabi.Method, error) {
+	selector, err := gethabi.ParseSelector(signature)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidSignature)
+	}
+	// ParseSelector generates dummy argument names, which are meaningless for the decoded values.
+	// Names of the tuple components are kept, since anonymous tuple components are not supported.
+	for i := range selector.Inputs {
+		selector.Inputs[i].Name = ""
+	}
+	rawJSON, err := json.Marshal([]gethabi.SelectorMarshaling{selector})
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal selector %s: %w", signature, err)
+	}
+	parsed, err := gethabi.JSON(strings.NewReader(string(rawJSON)))
+	if err != nil {
+		return nil, fmt.Errorf("can't parse selector %s: %w", signature, ErrInvalidSignature)
+	}
+	method := parsed.Methods[selector.Name]
+	return &method, nil
+}
+
+func decodeArguments(sig string, args gethabi.Arguments, data []byte) (*DecodedCall, error) {
+	values, err := args.UnpackValues(data)
+	if err != nil {
+		return nil, fmt.Errorf("can't unpack %s arguments: %w", sig, err)
+	}
+	call := &DecodedCall{Method: sig, Args: make([]*DecodedArg, len(args))}
+	for i, arg := range args {
+		call.Args[i] = &DecodedArg{
+			Name:  arg.Name,
+			Type:  arg.Type.String(),
+			Value: formatValue(&arg.Type, reflect.ValueOf(values[i])),
+		}
+	}
+	return call, nil
+}
+
+func formatValue(t *gethabi.Type, v reflect.Value) interface{} {
+	switch t.T {
+	case gethabi.IntTy, gethabi.UintTy:
+		return fmt.Sprint(v.Interface())
+	case gethabi.AddressTy:
+		return v.Interface().(common.Address).String()
+	case gethabi.BytesTy:
+		return hexutil.Encode(v.Bytes())
+	case gethabi.FixedBytesTy, gethabi.FunctionTy:
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return hexutil.Encode(b)
+	case gethabi.SliceTy, gethabi.ArrayTy:
+		res := make([]interface{}, v.Len())
+		for i := range res {
+			res[i] = formatValue(t.Elem, v.Index(i))
+		}
+		return res
+	case gethabi.TupleTy:
+		res := make(map[string]interface{}, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			res[t.TupleRawNames[i]] = formatValue(elem, v.Field(i))
+		}
+		return res
+	default:
+		return v.Interface()
+	}
+}
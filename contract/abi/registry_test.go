@@ -0,0 +1,118 @@
+package abi_test
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract/abi"
+)
+
+func TestRegistry_DecodeCall(t *testing.T) {
+	t.Parallel()
+
+	r := abi.NewRegistry()
+	r.AddABI(aliceAddr, abi.MustReadABI(testJSONABI).ABI)
+	require.NoError(t, r.AddSignatures(strings.NewReader(`
+# ERC20
+transfer(address,uint256)
+batch((address,uint256)[],bytes)
+`)))
+
+	testMethod := abi.MustReadABI(testJSONABI).Methods["testMethod"]
+	data, err := testMethod.Inputs.Pack(big.NewInt(42))
+	require.NoError(t, err)
+	data = append(testMethod.ID, data...)
+	require.Equal(t, &abi.DecodedCall{
+		Method: "testMethod(uint256)",
+		Args:   []*abi.DecodedArg{{Type: "uint256", Value: "42"}},
+	}, r.DecodeCall(aliceAddr, data))
+	require.Nil(t, r.DecodeCall(bobAddr, data))
+
+	transfer := mustParseMethod(t, "transfer", "address", "uint256")
+	data, err = transfer.Inputs.Pack(bobAddr, big.NewInt(100))
+	require.NoError(t, err)
+	require.Equal(t, &abi.DecodedCall{
+		Method: "transfer(address,uint256)",
+		Args: []*abi.DecodedArg{
+			{Type: "address", Value: bobAddr.String()},
+			{Type: "uint256", Value: "100"},
+		},
+	}, r.DecodeCall(bobAddr, append(transfer.ID, data...)))
+
+	batch, err := abi.DecodeArguments("batch((address,uint256)[],bytes)", mustPackBatch(t))
+	require.NoError(t, err)
+	require.Equal(t, []*abi.DecodedArg{
+		{Type: "(address,uint256)[]", Value: []interface{}{map[string]interface{}{"name0": bobAddr.String(), "name1": "7"}}},
+		{Type: "bytes", Value: "0xdeadbeef"},
+	}, batch.Args)
+
+	require.Nil(t, r.DecodeCall(bobAddr, transfer.ID[:3]))
+	require.Nil(t, r.DecodeCall(bobAddr, append(transfer.ID, 1)))
+	require.Nil(t, r.DecodeCall(bobAddr, []byte{1, 2, 3, 4}))
+
+	err = r.AddSignatures(strings.NewReader("transfer(address,uint256)\ntransfer(address"))
+	require.ErrorIs(t, err, abi.ErrInvalidSignature)
+	require.ErrorContains(t, err, "line 2")
+}
+
+func TestLoadRegistry(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	abiFile := filepath.Join(dir, "test_abi.json")
+	signaturesFile := filepath.Join(dir, "signatures.txt")
+	require.NoError(t, os.WriteFile(abiFile, []byte(testJSONABI), 0o600))
+	require.NoError(t, os.WriteFile(signaturesFile, []byte("transfer(address,uint256)\n"), 0o600))
+
+	r, err := abi.LoadRegistry(&config.ABIRegistryConfig{
+		ABIs:           []*config.ABIFileConfig{{File: abiFile, Addresses: []common.Address{aliceAddr}}},
+		SignaturesFile: signaturesFile,
+	})
+	require.NoError(t, err)
+	testMethod := abi.MustReadABI(testJSONABI).Methods["testMethod"]
+	require.NotNil(t, r.DecodeCall(aliceAddr, append(testMethod.ID, make([]byte, 32)...)))
+	transfer := mustParseMethod(t, "transfer", "address", "uint256")
+	require.NotNil(t, r.DecodeCall(bobAddr, append(transfer.ID, make([]byte, 64)...)))
+
+	_, err = abi.LoadRegistry(&config.ABIRegistryConfig{SignaturesFile: filepath.Join(dir, "missing.txt")})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func mustParseMethod(t *testing.T, name string, types ...string) gethabi.Method {
+	t.Helper()
+
+	args := make(gethabi.Arguments, len(types))
+	for i, typ := range types {
+		argType, err := gethabi.NewType(typ, "", nil)
+		require.NoError(t, err)
+		args[i] = gethabi.Argument{Type: argType}
+	}
+	return gethabi.NewMethod(name, name, gethabi.Function, "", false, false, args, nil)
+}
+
+func mustPackBatch(t *testing.T) []byte {
+	t.Helper()
+
+	tupleType, err := gethabi.NewType("tuple[]", "", []gethabi.ArgumentMarshaling{
+		{Name: "to", Type: "address"},
+		{Name: "value", Type: "uint256"},
+	})
+	require.NoError(t, err)
+	bytesType, err := gethabi.NewType("bytes", "", nil)
+	require.NoError(t, err)
+	items := []struct {
+		To    common.Address
+		Value *big.Int
+	}{{bobAddr, big.NewInt(7)}}
+	data, err := gethabi.Arguments{{Type: tupleType}, {Type: bytesType}}.Pack(items, []byte{0xde, 0xad, 0xbe, 0xef})
+	require.NoError(t, err)
+	return data
+}
@@ -2,6 +2,7 @@ package contract
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -13,15 +14,18 @@ import (
 	"github.com/omni/tokenbridge-monitor/ethclient"
 )
 
+var ErrInvalidResult = errors.New("invalid call result")
+
 type BridgeContract struct {
 	*Contract
 }
 
 func NewBridgeContract(client ethclient.Client, addr common.Address, mode config.BridgeMode) *BridgeContract {
-	return &BridgeContract{NewContract(client, addr, getBridgeABI(mode))}
+	return &BridgeContract{NewContract(client, addr, BridgeABI(mode))}
 }
 
-func getBridgeABI(mode config.BridgeMode) abi.ABI {
+// BridgeABI returns ABI of the bridge contract operating in the given mode.
+func BridgeABI(mode config.BridgeMode) abi.ABI {
 	if mode == config.BridgeModeErcToNative {
 		return bridgeabi.ErcToNativeABI
 	}
@@ -43,3 +47,73 @@ func (c *BridgeContract) RequiredSignatures(ctx context.Context) (uint, error) {
 	}
 	return uint(new(big.Int).SetBytes(res).Uint64()), nil
 }
+
+// BridgeLimits holds daily limits of the bridge contract, together with their usage in the current day.
+type BridgeLimits struct {
+	DailyLimit          *big.Int
+	TotalSpentPerDay    *big.Int
+	ExecutionDailyLimit *big.Int
+	TotalExecutedPerDay *big.Int
+}
+
+// Limits returns current daily limits of the bridge contract. For multi-token bridges, limits of the particular
+// token are returned, nil token is used for the single-token bridges.
+func (c *BridgeContract) Limits(ctx context.Context, token *common.Address) (*BridgeLimits, error) {
+	day, err := c.callUint(ctx, "getCurrentDay")
+	if err != nil {
+		return nil, err
+	}
+	// overloaded methods with the token argument are suffixed with 0 in the parsed ABI
+	suffix, args := "", []interface{}{}
+	if token != nil {
+		suffix, args = "0", []interface{}{*token}
+	}
+	res := new(BridgeLimits)
+	if res.DailyLimit, err = c.callUint(ctx, "dailyLimit"+suffix, args...); err != nil {
+		return nil, err
+	}
+	if res.TotalSpentPerDay, err = c.callUint(ctx, "totalSpentPerDay"+suffix, append(args, day)...); err != nil {
+		return nil, err
+	}
+	if res.ExecutionDailyLimit, err = c.callUint(ctx, "executionDailyLimit"+suffix, args...); err != nil {
+		return nil, err
+	}
+	if res.TotalExecutedPerDay, err = c.callUint(ctx, "totalExecutedPerDay"+suffix, append(args, day)...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *BridgeContract) callUint(ctx context.Context, method string, args ...interface{}) (*big.Int, error) {
+	res, err := c.Call(ctx, method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot obtain %s: %w", method, err)
+	}
+	return new(big.Int).SetBytes(res), nil
+}
+
+// Message returns the home-to-foreign message stored in the home bridge contract by its hash.
+func (c *BridgeContract) Message(ctx context.Context, msgHash common.Hash) ([]byte, error) {
+	return c.callBytes(ctx, "message", msgHash)
+}
+
+// Signature returns the index-th collected validator signature of the home-to-foreign message.
+func (c *BridgeContract) Signature(ctx context.Context, msgHash common.Hash, index uint) ([]byte, error) {
+	return c.callBytes(ctx, "signature", msgHash, new(big.Int).SetUint64(uint64(index)))
+}
+
+func (c *BridgeContract) callBytes(ctx context.Context, method string, args ...interface{}) ([]byte, error) {
+	res, err := c.Call(ctx, method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot obtain %s: %w", method, err)
+	}
+	values, err := c.ABI.Unpack(method, res)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %s result: %w", method, err)
+	}
+	data, ok := values[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s result type %T: %w", method, values[0], ErrInvalidResult)
+	}
+	return data, nil
+}
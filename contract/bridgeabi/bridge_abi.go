@@ -38,6 +38,8 @@ const (
 	ErcToNativeUserRequestForAffirmation = "event UserRequestForAffirmation(address recipient, uint256 value)"
 	ErcToNativeAffirmationCompleted      = "event AffirmationCompleted(address recipient, uint256 value, bytes32 transactionHash)"
 	ErcToNativeSignedForAffirmation      = "event SignedForAffirmation(address indexed signer, bytes32 transactionHash)"
+	ErcToNativeTokensSwapped             = "event TokensSwapped(address indexed from, address indexed to, uint256 value)"
+	ErcToNativePaidInterest              = "event PaidInterest(address indexed token, address to, uint256 value)"
 
 	ValidatorAdded   = "event ValidatorAdded(address indexed validator)"
 	ValidatorRemoved = "event ValidatorRemoved(address indexed validator)"
@@ -49,6 +51,8 @@ var (
 
 	ErcToNativeTransferEventSignature                  = ErcToNativeABI.Events["Transfer"].ID
 	ErcToNativeUserRequestForAffirmationEventSignature = ErcToNativeABI.Events["UserRequestForAffirmation"].ID
+	ErcToNativeTokensSwappedEventSignature             = ErcToNativeABI.Events["TokensSwapped"].ID
+	ErcToNativePaidInterestEventSignature              = ErcToNativeABI.Events["PaidInterest"].ID
 
 	ArbitraryMessageMethods = []string{
 		"eth_call(address,bytes)",
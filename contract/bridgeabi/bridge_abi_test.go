@@ -13,4 +13,23 @@ func TestEventSignatures(t *testing.T) {
 
 	require.NotZero(t, bridgeabi.ErcToNativeTransferEventSignature)
 	require.NotZero(t, bridgeabi.ErcToNativeUserRequestForAffirmationEventSignature)
+	require.NotZero(t, bridgeabi.ErcToNativeTokensSwappedEventSignature)
+	require.NotZero(t, bridgeabi.ErcToNativePaidInterestEventSignature)
+}
+
+func TestErcToNativeLimitMethods(t *testing.T) {
+	t.Parallel()
+
+	for name, sig := range map[string]string{
+		"dailyLimit":           "dailyLimit()",
+		"dailyLimit0":          "dailyLimit(address)",
+		"totalSpentPerDay":     "totalSpentPerDay(uint256)",
+		"totalSpentPerDay0":    "totalSpentPerDay(address,uint256)",
+		"executionDailyLimit0": "executionDailyLimit(address)",
+		"totalExecutedPerDay0": "totalExecutedPerDay(address,uint256)",
+	} {
+		method, ok := bridgeabi.ErcToNativeABI.Methods[name]
+		require.True(t, ok, name)
+		require.Equal(t, sig, method.Sig)
+	}
 }
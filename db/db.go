@@ -19,13 +19,23 @@ import (
 	"github.com/omni/tokenbridge-monitor/config"
 )
 
+// defaultMigrationsSource is the location of the database migrations, relative to the working directory.
+const defaultMigrationsSource = "file://db/migrations"
+
 type DB struct {
 	cfg *config.DBConfig
 	db  *sqlx.DB
 }
 
+type txCtxKey struct{}
+
 func (db *DB) Migrate() error {
-	m, err := migrate.New("file://db/migrations", db.dbURL("pgx"))
+	return db.MigrateFrom(defaultMigrationsSource)
+}
+
+// MigrateFrom applies migrations from the given source URL, e.g. when running from a different working directory.
+func (db *DB) MigrateFrom(source string) error {
+	m, err := migrate.New(source, db.dbURL("pgx"))
 	if err != nil {
 		return fmt.Errorf("can't connect to postgres database: %w", err)
 	}
@@ -37,7 +47,7 @@ func (db *DB) Migrate() error {
 }
 
 func (db *DB) dbURL(prefix string) string {
-	return fmt.Sprintf("%s://%s:%s@%s:%d/%s", prefix, db.cfg.User, db.cfg.Password, db.cfg.Host, db.cfg.Port, db.cfg.DB)
+	return fmt.Sprintf("%s://%s:%s@%s:%d/%s", prefix, db.cfg.User, db.cfg.Password.Value(), db.cfg.Host, db.cfg.Port, db.cfg.DB)
 }
 
 func NewDB(cfg *config.DBConfig) (*DB, error) {
@@ -70,14 +80,58 @@ func (db *DB) Close() error {
 	return db.db.Close()
 }
 
+// PingContext verifies that the database is still reachable.
+func (db *DB) PingContext(ctx context.Context) error {
+	return db.db.PingContext(ctx)
+}
+
+// InTransaction runs fn within a single database transaction, which is committed if fn returns no error.
+// All queries executed by the DB methods with the context passed to fn are executed within that transaction.
+// Nested calls reuse the outer transaction.
+func (db *DB) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txCtxKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txCtxKey{}, tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("can't rollback transaction: %v, after error: %w", rollbackErr, err)
+		}
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+	return nil
+}
+
+// conn returns the transaction associated with the context, or the database connection pool otherwise.
+func (db *DB) conn(ctx context.Context) sqlx.ExtContext {
+	if tx, ok := ctx.Value(txCtxKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db.db
+}
+
 func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	defer ObserveDuration(getCurrentFuncName(2))()
-	return db.db.ExecContext(ctx, query, args...)
+	return db.conn(ctx).ExecContext(ctx, query, args...)
 }
 
 func (db *DB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
 	defer ObserveDuration(getCurrentFuncName(2))()
-	err := db.db.GetContext(ctx, dest, query, args...)
+	err := sqlx.GetContext(ctx, db.conn(ctx), dest, query, args...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return ErrNotFound
 	}
@@ -86,7 +140,14 @@ func (db *DB) GetContext(ctx context.Context, dest interface{}, query string, ar
 
 func (db *DB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
 	defer ObserveDuration(getCurrentFuncName(2))()
-	return db.db.SelectContext(ctx, dest, query, args...)
+	return sqlx.SelectContext(ctx, db.conn(ctx), dest, query, args...)
+}
+
+// QueryxContext executes a query returning rows, that can be scanned one by one.
+// Caller is responsible for closing returned rows.
+func (db *DB) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	defer ObserveDuration(getCurrentFuncName(2))()
+	return db.conn(ctx).QueryxContext(ctx, query, args...)
 }
 
 func getCurrentFuncName(skip int) string {
@@ -0,0 +1,12 @@
+CREATE TABLE api_keys
+(
+    id         SERIAL PRIMARY KEY,
+    name       TEXT_ID,
+    key_hash   TEXT NOT NULL UNIQUE,
+    scopes     TEXT[] NOT NULL DEFAULT '{}',
+    rps        DOUBLE PRECISION NULL,
+    burst      INT NULL,
+    revoked    FLAG DEFAULT FALSE,
+    updated_at TS_NOW,
+    created_at TS_NOW
+);
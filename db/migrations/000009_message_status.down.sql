@@ -0,0 +1,3 @@
+REVOKE SELECT ON message_status FROM readonly;
+DROP INDEX collected_messages_bridge_id_msg_hash_idx;
+DROP TABLE message_status;
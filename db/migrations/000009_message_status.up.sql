@@ -0,0 +1,82 @@
+CREATE TABLE message_status
+(
+    bridge_id        TEXT_ID,
+    msg_hash         WORD,
+    message_id       WORD,
+    direction        DIRECTION,
+    state            TEXT NOT NULL CHECK (state IN ('pending', 'signed', 'collected', 'executed', 'failed')),
+    signatures       INT  NOT NULL DEFAULT 0,
+    collected        FLAG DEFAULT FALSE,
+    execution_status BOOLEAN NULL,
+    first_event_at   TIMESTAMP WITHOUT TIME ZONE NULL,
+    last_event_at    TIMESTAMP WITHOUT TIME ZONE NULL,
+    updated_at       TS_NOW,
+    created_at       TS_NOW,
+    PRIMARY KEY (bridge_id, msg_hash)
+);
+
+CREATE INDEX message_status_bridge_id_state_idx ON message_status (bridge_id, state);
+CREATE INDEX message_status_bridge_id_message_id_idx ON message_status (bridge_id, message_id);
+CREATE INDEX collected_messages_bridge_id_msg_hash_idx ON collected_messages (bridge_id, msg_hash);
+
+INSERT INTO message_status (bridge_id, msg_hash, message_id, direction, state, signatures, collected,
+                            execution_status, first_event_at, last_event_at)
+SELECT m.bridge_id,
+       m.msg_hash,
+       m.message_id,
+       m.direction,
+       CASE
+           WHEN e.status THEN 'executed'
+           WHEN NOT e.status THEN 'failed'
+           WHEN c.collected THEN 'collected'
+           WHEN s.signatures > 0 THEN 'signed'
+           ELSE 'pending'
+           END,
+       s.signatures,
+       c.collected,
+       e.status,
+       t.first_event_at,
+       t.last_event_at
+FROM (SELECT bridge_id, msg_hash, message_id, direction
+      FROM messages
+      UNION ALL
+      SELECT bridge_id, msg_hash, msg_hash, direction
+      FROM erc_to_native_messages) m
+         CROSS JOIN LATERAL (SELECT count(*) AS signatures
+                             FROM signed_messages
+                             WHERE bridge_id = m.bridge_id
+                               AND msg_hash = m.msg_hash) s
+         CROSS JOIN LATERAL (SELECT EXISTS(SELECT 1
+                                           FROM collected_messages
+                                           WHERE bridge_id = m.bridge_id
+                                             AND msg_hash = m.msg_hash) AS collected) c
+         CROSS JOIN LATERAL (SELECT bool_or(status) AS status
+                             FROM executed_messages
+                             WHERE bridge_id = m.bridge_id
+                               AND message_id = m.message_id) e
+         CROSS JOIN LATERAL (SELECT min(bt.timestamp) AS first_event_at, max(bt.timestamp) AS last_event_at
+                             FROM (SELECT log_id
+                                   FROM sent_messages
+                                   WHERE bridge_id = m.bridge_id
+                                     AND msg_hash = m.msg_hash
+                                   UNION ALL
+                                   SELECT log_id
+                                   FROM signed_messages
+                                   WHERE bridge_id = m.bridge_id
+                                     AND msg_hash = m.msg_hash
+                                   UNION ALL
+                                   SELECT log_id
+                                   FROM collected_messages
+                                   WHERE bridge_id = m.bridge_id
+                                     AND msg_hash = m.msg_hash
+                                   UNION ALL
+                                   SELECT log_id
+                                   FROM executed_messages
+                                   WHERE bridge_id = m.bridge_id
+                                     AND message_id = m.message_id) ev
+                                      JOIN logs l ON l.id = ev.log_id
+                                      JOIN block_timestamps bt
+                                           ON bt.chain_id = l.chain_id AND bt.block_number = l.block_number) t
+ON CONFLICT DO NOTHING;
+
+GRANT SELECT ON message_status TO readonly;
@@ -0,0 +1,6 @@
+REVOKE SELECT (message_valid) ON collected_messages FROM readonly;
+REVOKE SELECT ON collected_signatures FROM readonly;
+DROP INDEX collected_messages_bridge_id_message_valid_idx;
+DROP TABLE collected_signatures;
+ALTER TABLE collected_messages
+    DROP COLUMN message_valid;
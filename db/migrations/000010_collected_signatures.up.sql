@@ -0,0 +1,21 @@
+ALTER TABLE collected_messages
+    ADD COLUMN message_valid BOOLEAN NULL;
+
+CREATE TABLE collected_signatures
+(
+    log_id          INT REFERENCES collected_messages,
+    bridge_id       TEXT_ID,
+    msg_hash        WORD,
+    signature_index INT NOT NULL,
+    signer          ADDRESS,
+    signature       BLOB,
+    updated_at      TS_NOW,
+    created_at      TS_NOW,
+    PRIMARY KEY (log_id, signature_index)
+);
+
+CREATE INDEX collected_messages_bridge_id_message_valid_idx ON collected_messages (bridge_id) WHERE message_valid IS NULL;
+CREATE INDEX collected_signatures_bridge_id_msg_hash_idx ON collected_signatures (bridge_id, msg_hash);
+
+GRANT SELECT ON collected_signatures TO readonly;
+GRANT SELECT (message_valid) ON collected_messages TO readonly;
@@ -0,0 +1,5 @@
+REVOKE SELECT (simulation_success, simulation_error, simulated_at) ON messages FROM readonly;
+ALTER TABLE messages
+    DROP COLUMN simulation_success,
+    DROP COLUMN simulation_error,
+    DROP COLUMN simulated_at;
@@ -0,0 +1,6 @@
+ALTER TABLE messages
+    ADD COLUMN simulation_success BOOLEAN NULL,
+    ADD COLUMN simulation_error   TEXT NULL,
+    ADD COLUMN simulated_at       TIMESTAMP WITHOUT TIME ZONE NULL;
+
+GRANT SELECT (simulation_success, simulation_error, simulated_at) ON messages TO readonly;
@@ -0,0 +1,5 @@
+REVOKE SELECT (gas_used, revert_reason, failed_call_target) ON executed_messages FROM readonly;
+ALTER TABLE executed_messages
+    DROP COLUMN gas_used,
+    DROP COLUMN revert_reason,
+    DROP COLUMN failed_call_target;
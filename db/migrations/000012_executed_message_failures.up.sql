@@ -0,0 +1,6 @@
+ALTER TABLE executed_messages
+    ADD COLUMN gas_used           BIGINT NULL,
+    ADD COLUMN revert_reason      TEXT NULL,
+    ADD COLUMN failed_call_target BYTEA NULL CHECK (length(failed_call_target) = 20);
+
+GRANT SELECT (gas_used, revert_reason, failed_call_target) ON executed_messages TO readonly;
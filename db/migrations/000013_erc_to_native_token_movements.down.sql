@@ -0,0 +1,2 @@
+REVOKE SELECT ON erc_to_native_token_movements FROM readonly;
+DROP TABLE erc_to_native_token_movements;
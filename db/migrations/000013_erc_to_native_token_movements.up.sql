@@ -0,0 +1,16 @@
+CREATE TABLE erc_to_native_token_movements
+(
+    log_id       INT REFERENCES logs,
+    bridge_id    TEXT_ID,
+    token        ADDRESS,
+    kind         TEXT NOT NULL CHECK (kind IN ('deposit', 'release', 'invest', 'withdraw', 'swap_in', 'swap_out', 'interest')),
+    counterparty ADDRESS,
+    amount       UINT,
+    updated_at   TS_NOW,
+    created_at   TS_NOW,
+    PRIMARY KEY (log_id, kind)
+);
+
+CREATE INDEX erc_to_native_token_movements_bridge_id_token_idx ON erc_to_native_token_movements (bridge_id, token);
+
+GRANT SELECT ON erc_to_native_token_movements TO readonly;
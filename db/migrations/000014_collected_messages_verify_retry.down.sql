@@ -0,0 +1,4 @@
+REVOKE SELECT (verify_attempts, verify_retry_at) ON collected_messages FROM readonly;
+ALTER TABLE collected_messages
+    DROP COLUMN verify_attempts,
+    DROP COLUMN verify_retry_at;
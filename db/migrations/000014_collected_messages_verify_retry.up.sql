@@ -0,0 +1,5 @@
+ALTER TABLE collected_messages
+    ADD COLUMN verify_attempts INT                         NOT NULL DEFAULT 0,
+    ADD COLUMN verify_retry_at TIMESTAMP WITHOUT TIME ZONE NULL;
+
+GRANT SELECT (verify_attempts, verify_retry_at) ON collected_messages TO readonly;
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type APIKey struct {
+	ID        uint           `db:"id"`
+	Name      string         `db:"name"`
+	KeyHash   string         `db:"key_hash"`
+	Scopes    pq.StringArray `db:"scopes"`
+	RPS       *float64       `db:"rps"`
+	Burst     *int           `db:"burst"`
+	Revoked   bool           `db:"revoked"`
+	CreatedAt *time.Time     `db:"created_at"`
+	UpdatedAt *time.Time     `db:"updated_at"`
+}
+
+type APIKeysRepo interface {
+	GetByKeyHash(ctx context.Context, keyHash string) (*APIKey, error)
+}
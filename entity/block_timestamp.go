@@ -16,4 +16,9 @@ type BlockTimestamp struct {
 type BlockTimestampsRepo interface {
 	Ensure(ctx context.Context, cursor *BlockTimestamp) error
 	GetByBlockNumber(ctx context.Context, chainID string, blockNumber uint) (*BlockTimestamp, error)
+	// GetLastBefore returns timestamp of the latest known block of the chain, mined before the given time.
+	GetLastBefore(ctx context.Context, chainID string, ts time.Time) (*BlockTimestamp, error)
+	// PruneOrphaned deletes up to limit timestamps of the chain blocks up to toBlock, that have no logs
+	// and are not used by the chain logs cursors. It returns the number of deleted timestamps.
+	PruneOrphaned(ctx context.Context, chainID string, toBlock uint, limit uint) (uint, error)
 }
@@ -7,12 +7,18 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// CollectedMessage is a home-to-foreign message with collected signatures. MessageValid tells whether the message
+// stored in the home bridge contract matches the collected message, it is nil until collected signatures are verified.
+// Failed verifications are retried after VerifyRetryAt.
 type CollectedMessage struct {
 	LogID             uint           `db:"log_id"`
 	BridgeID          string         `db:"bridge_id"`
 	MsgHash           common.Hash    `db:"msg_hash"`
 	ResponsibleSigner common.Address `db:"responsible_signer"`
 	NumSignatures     uint           `db:"num_signatures"`
+	MessageValid      *bool          `db:"message_valid"`
+	VerifyAttempts    uint           `db:"verify_attempts"`
+	VerifyRetryAt     *time.Time     `db:"verify_retry_at"`
 	CreatedAt         *time.Time     `db:"created_at"`
 	UpdatedAt         *time.Time     `db:"updated_at"`
 }
@@ -20,4 +26,10 @@ type CollectedMessage struct {
 type CollectedMessagesRepo interface {
 	Ensure(ctx context.Context, msg *CollectedMessage) error
 	GetByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) (*CollectedMessage, error)
+	// FindUnverified returns the oldest collected messages, which signatures were not verified yet.
+	// Messages with failed verification attempts are skipped until their retry time.
+	FindUnverified(ctx context.Context, bridgeID string, limit uint) ([]*CollectedMessage, error)
+	SetMessageValid(ctx context.Context, logID uint, valid bool) error
+	// SetVerifyFailed increments verification attempts of the message and postpones the next attempt by retryAfter.
+	SetVerifyFailed(ctx context.Context, logID uint, retryAfter time.Duration) error
 }
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CollectedSignature is a validator signature of the collected home-to-foreign message,
+// as stored in the home bridge contract. Signer is a zero address if it can't be recovered from the signature.
+type CollectedSignature struct {
+	LogID     uint           `db:"log_id"`
+	BridgeID  string         `db:"bridge_id"`
+	MsgHash   common.Hash    `db:"msg_hash"`
+	Index     uint           `db:"signature_index"`
+	Signer    common.Address `db:"signer"`
+	Signature []byte         `db:"signature"`
+	CreatedAt *time.Time     `db:"created_at"`
+	UpdatedAt *time.Time     `db:"updated_at"`
+}
+
+type CollectedSignaturesRepo interface {
+	Ensure(ctx context.Context, sig *CollectedSignature) error
+	FindByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) ([]*CollectedSignature, error)
+}
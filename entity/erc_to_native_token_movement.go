@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type TokenMovementKind string
+
+const (
+	TokenMovementDeposit  TokenMovementKind = "deposit"
+	TokenMovementRelease  TokenMovementKind = "release"
+	TokenMovementInvest   TokenMovementKind = "invest"
+	TokenMovementWithdraw TokenMovementKind = "withdraw"
+	TokenMovementSwapIn   TokenMovementKind = "swap_in"
+	TokenMovementSwapOut  TokenMovementKind = "swap_out"
+	TokenMovementInterest TokenMovementKind = "interest"
+)
+
+// ErcToNativeTokenMovement is a change of the bridged token amount, accounted by the foreign ERC_TO_NATIVE bridge.
+// Counterparty is the token sender or receiver, the investment contract, the interest receiver,
+// or the other token of the migration, depending on the movement kind.
+type ErcToNativeTokenMovement struct {
+	LogID        uint              `db:"log_id"`
+	BridgeID     string            `db:"bridge_id"`
+	Token        common.Address    `db:"token"`
+	Kind         TokenMovementKind `db:"kind"`
+	Counterparty common.Address    `db:"counterparty"`
+	Amount       string            `db:"amount"`
+	CreatedAt    *time.Time        `db:"created_at"`
+	UpdatedAt    *time.Time        `db:"updated_at"`
+}
+
+// ErcToNativeTokenBalance is a sum of the token movements of the bridge.
+// Locked is the amount of tokens deposited by users and not yet released, including the invested part,
+// Invested is the amount of tokens in the investment contracts, and Claimed is the total paid interest.
+type ErcToNativeTokenBalance struct {
+	Token    common.Address `db:"token"`
+	Locked   string         `db:"locked"`
+	Invested string         `db:"invested"`
+	Claimed  string         `db:"claimed"`
+}
+
+type ErcToNativeTokenMovementsRepo interface {
+	Ensure(ctx context.Context, movement *ErcToNativeTokenMovement) error
+	FindBalances(ctx context.Context, bridgeID string) ([]*ErcToNativeTokenBalance, error)
+}
@@ -7,13 +7,18 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// ExecutedMessage is an execution of the AMB or ERC_TO_NATIVE message. For failed AMB executions, GasUsed is taken
+// from the transaction receipt, while RevertReason and FailedCallTarget are taken from the transaction trace, if available.
 type ExecutedMessage struct {
-	LogID     uint        `db:"log_id"`
-	BridgeID  string      `db:"bridge_id"`
-	MessageID common.Hash `db:"message_id"`
-	Status    bool        `db:"status"`
-	CreatedAt *time.Time  `db:"created_at"`
-	UpdatedAt *time.Time  `db:"updated_at"`
+	LogID            uint            `db:"log_id"`
+	BridgeID         string          `db:"bridge_id"`
+	MessageID        common.Hash     `db:"message_id"`
+	Status           bool            `db:"status"`
+	GasUsed          *uint           `db:"gas_used"`
+	RevertReason     *string         `db:"revert_reason"`
+	FailedCallTarget *common.Address `db:"failed_call_target"`
+	CreatedAt        *time.Time      `db:"created_at"`
+	UpdatedAt        *time.Time      `db:"updated_at"`
 }
 
 type ExecutedMessagesRepo interface {
@@ -42,6 +42,12 @@ type LogsRepo interface {
 	GetByID(ctx context.Context, id uint) (*Log, error)
 	Find(ctx context.Context, filter LogsFilter) ([]*Log, error)
 	FindByIDs(ctx context.Context, ids []uint) ([]*Log, error)
+	// Iterate calls fn for each log matching the filter, without loading all of them into memory.
+	// fn is called outside of any open query, so it can use the repositories for additional lookups.
+	Iterate(ctx context.Context, filter LogsFilter, fn func(*Log) error) error
+	// PruneUnreferenced deletes up to limit logs of the chain up to toBlock, that are not referenced by any other table.
+	// Logs above the last processed block of any chain logs cursor are never deleted. It returns the number of deleted logs.
+	PruneUnreferenced(ctx context.Context, chainID string, toBlock uint, limit uint) (uint, error)
 }
 
 func NewLog(chainID string, log types.Log) *Log {
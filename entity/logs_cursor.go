@@ -18,5 +18,10 @@ type LogsCursor struct {
 
 type LogsCursorsRepo interface {
 	Ensure(ctx context.Context, cursor *LogsCursor) error
+	// UpdateFetchedBlock and UpdateProcessedBlock update only the corresponding block number of the existing cursor,
+	// so that concurrent log fetching and processing don't overwrite each other's progress.
+	// Missing cursor is created from the given one.
+	UpdateFetchedBlock(ctx context.Context, cursor *LogsCursor) error
+	UpdateProcessedBlock(ctx context.Context, cursor *LogsCursor) error
 	GetByChainIDAndAddress(ctx context.Context, chainID string, addr common.Address) (*LogsCursor, error)
 }
@@ -14,20 +14,25 @@ const (
 	DirectionHomeToForeign Direction = "home_to_foreign"
 )
 
+// Message is an AMB message. SimulationSuccess and SimulationError hold the outcome of the latest eth_call
+// simulation of the pending message execution, they are nil until the message is simulated.
 type Message struct {
-	ID         uint           `db:"id"`
-	BridgeID   string         `db:"bridge_id"`
-	MsgHash    common.Hash    `db:"msg_hash"`
-	MessageID  common.Hash    `db:"message_id"`
-	Direction  Direction      `db:"direction"`
-	Sender     common.Address `db:"sender"`
-	Executor   common.Address `db:"executor"`
-	Data       []byte         `db:"data"`
-	DataType   uint           `db:"data_type"`
-	GasLimit   uint           `db:"gas_limit"`
-	RawMessage []byte         `db:"raw_message"`
-	CreatedAt  *time.Time     `db:"created_at"`
-	UpdatedAt  *time.Time     `db:"updated_at"`
+	ID                uint           `db:"id"`
+	BridgeID          string         `db:"bridge_id"`
+	MsgHash           common.Hash    `db:"msg_hash"`
+	MessageID         common.Hash    `db:"message_id"`
+	Direction         Direction      `db:"direction"`
+	Sender            common.Address `db:"sender"`
+	Executor          common.Address `db:"executor"`
+	Data              []byte         `db:"data"`
+	DataType          uint           `db:"data_type"`
+	GasLimit          uint           `db:"gas_limit"`
+	RawMessage        []byte         `db:"raw_message"`
+	SimulationSuccess *bool          `db:"simulation_success"`
+	SimulationError   *string        `db:"simulation_error"`
+	SimulatedAt       *time.Time     `db:"simulated_at"`
+	CreatedAt         *time.Time     `db:"created_at"`
+	UpdatedAt         *time.Time     `db:"updated_at"`
 }
 
 func (m *Message) GetMsgHash() common.Hash {
@@ -51,4 +56,8 @@ type MessagesRepo interface {
 	GetByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) (*Message, error)
 	GetByMessageID(ctx context.Context, bridgeID string, messageID common.Hash) (*Message, error)
 	FindPendingMessages(ctx context.Context, bridgeID string) ([]*Message, error)
+	// FindRecentPendingMessages returns pending messages first seen after since, or without known timestamp yet.
+	FindRecentPendingMessages(ctx context.Context, bridgeID string, since time.Time) ([]*Message, error)
+	// SetSimulationResult stores the outcome of the execution simulation, errMsg is ignored for successful simulations.
+	SetSimulationResult(ctx context.Context, bridgeID string, msgHash common.Hash, success bool, errMsg string) error
 }
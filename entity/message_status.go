@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type MessageState string
+
+const (
+	MessageStatePending   MessageState = "pending"
+	MessageStateSigned    MessageState = "signed"
+	MessageStateCollected MessageState = "collected"
+	MessageStateExecuted  MessageState = "executed"
+	MessageStateFailed    MessageState = "failed"
+)
+
+// MessageStatus is a denormalized lifecycle state of the AMB or ERC_TO_NATIVE message,
+// derived from its sent, signed, collected and executed events.
+type MessageStatus struct {
+	BridgeID        string       `db:"bridge_id"`
+	MsgHash         common.Hash  `db:"msg_hash"`
+	MessageID       common.Hash  `db:"message_id"`
+	Direction       Direction    `db:"direction"`
+	State           MessageState `db:"state"`
+	Signatures      uint         `db:"signatures"`
+	Collected       bool         `db:"collected"`
+	ExecutionStatus *bool        `db:"execution_status"`
+	FirstEventAt    *time.Time   `db:"first_event_at"`
+	LastEventAt     *time.Time   `db:"last_event_at"`
+	CreatedAt       *time.Time   `db:"created_at"`
+	UpdatedAt       *time.Time   `db:"updated_at"`
+}
+
+type MessageStatusesRepo interface {
+	// Refresh recomputes status of the message from all its events stored so far, so that events
+	// can be handled in any order and more than once. Unknown messages are ignored.
+	Refresh(ctx context.Context, bridgeID string, msgHash common.Hash) error
+	// RefreshByMessageID is the same as Refresh, for events referencing messages by their message id.
+	RefreshByMessageID(ctx context.Context, bridgeID string, messageID common.Hash) error
+	GetByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) (*MessageStatus, error)
+	CountByState(ctx context.Context, bridgeID string) (map[MessageState]uint, error)
+}
+
+// NewMessageState returns the latest reached state of the message with the given events.
+func NewMessageState(signatures uint, collected bool, executionStatus *bool) MessageState {
+	switch {
+	case executionStatus != nil && *executionStatus:
+		return MessageStateExecuted
+	case executionStatus != nil:
+		return MessageStateFailed
+	case collected:
+		return MessageStateCollected
+	case signatures > 0:
+		return MessageStateSigned
+	default:
+		return MessageStatePending
+	}
+}
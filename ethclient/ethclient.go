@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -30,9 +32,16 @@ type Client interface {
 	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, error)
 	TransactionReceiptByHash(ctx context.Context, hash common.Hash) (*types.Receipt, error)
 	CallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error)
+	CallContractWithStorage(ctx context.Context, msg ethereum.CallMsg, storage StorageOverride) ([]byte, error)
+	BalanceAt(ctx context.Context, account common.Address) (*big.Int, error)
 	TransactionSender(tx *types.Transaction) (common.Address, error)
+	TraceTransaction(ctx context.Context, hash common.Hash) (*CallFrame, error)
 }
 
+// StorageOverride replaces storage slots of the given accounts for the duration of eth_call,
+// slots which are not listed keep their current values.
+type StorageOverride map[common.Address]map[common.Hash]common.Hash
+
 type rpcClient struct {
 	chainID   string
 	url       string
@@ -180,10 +189,74 @@ func (c *rpcClient) CallContract(ctx context.Context, msg ethereum.CallMsg) ([]b
 	return res, err
 }
 
+// CallContractWithStorage executes eth_call at the latest block with the state override of the given storage slots.
+// State overrides are supported by geth, erigon and nethermind nodes.
+func (c *rpcClient) CallContractWithStorage(ctx context.Context, msg ethereum.CallMsg, storage StorageOverride) ([]byte, error) {
+	defer ObserveDuration(c.chainID, c.url, "eth_call")()
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	override := make(map[common.Address]map[string]map[common.Hash]common.Hash, len(storage))
+	for account, slots := range storage {
+		override[account] = map[string]map[common.Hash]common.Hash{"stateDiff": slots}
+	}
+	var res hexutil.Bytes
+	err := c.rawClient.CallContext(ctx, &res, "eth_call", toCallArg(msg), "latest", override)
+	ObserveError(c.chainID, c.url, "eth_call", err)
+	return res, err
+}
+
+// BalanceAt returns the native coin balance of the given account at the latest block.
+func (c *rpcClient) BalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	defer ObserveDuration(c.chainID, c.url, "eth_getBalance")()
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	balance, err := c.client.BalanceAt(ctx, account, nil)
+	ObserveError(c.chainID, c.url, "eth_getBalance", err)
+	return balance, err
+}
+
 func (c *rpcClient) TransactionSender(tx *types.Transaction) (common.Address, error) {
 	return c.signer.Sender(tx)
 }
 
+// TraceTransaction returns the call tree of the transaction, built by the callTracer of debug_traceTransaction.
+// The method is usually available only on archive nodes with the debug namespace enabled.
+func (c *rpcClient) TraceTransaction(ctx context.Context, hash common.Hash) (*CallFrame, error) {
+	defer ObserveDuration(c.chainID, c.url, "debug_traceTransaction")()
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res *CallFrame
+	err := c.rawClient.CallContext(ctx, &res, "debug_traceTransaction", hash, map[string]string{"tracer": "callTracer"})
+	ObserveError(c.chainID, c.url, "debug_traceTransaction", err)
+	if err == nil && res == nil {
+		return nil, ethereum.NotFound
+	}
+	return res, err
+}
+
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	return arg
+}
+
 func toFilterArg(q ethereum.FilterQuery) (interface{}, error) {
 	arg := map[string]interface{}{
 		"address": q.Addresses,
@@ -203,3 +276,34 @@ func toFilterArg(q ethereum.FilterQuery) (interface{}, error) {
 	arg["toBlock"] = hexutil.EncodeBig(q.ToBlock)
 	return arg, nil
 }
+
+// IsExecutionError tells whether the eth_call error is caused by the failed execution of the call itself,
+// e.g. by a revert, rather than by the RPC node or transport failure.
+func IsExecutionError(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	msg := strings.ToLower(rpcErr.Error())
+	return rpcErr.ErrorCode() == 3 ||
+		strings.Contains(msg, "revert") ||
+		strings.Contains(msg, "out of gas") ||
+		strings.Contains(msg, "invalid opcode")
+}
+
+// RevertReason returns the reason string of the failed eth_call, decoded from the revert data returned by the node.
+// The RPC error message is returned if the call was reverted without a reason or the node does not return revert data.
+func RevertReason(err error) string {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return err.Error()
+	}
+	if hexData, ok := dataErr.ErrorData().(string); ok {
+		if data, err2 := hexutil.Decode(hexData); err2 == nil {
+			if reason, err3 := abi.UnpackRevert(data); err3 == nil {
+				return reason
+			}
+		}
+	}
+	return dataErr.Error()
+}
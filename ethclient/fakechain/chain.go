@@ -0,0 +1,587 @@
+// Package fakechain provides a scriptable in-memory chain implementing ethclient.Client,
+// which allows testing chain indexing and event handling without a live RPC.
+package fakechain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/omni/tokenbridge-monitor/ethclient"
+)
+
+const (
+	defaultGasLimit  = 1_000_000
+	defaultBlockTime = 5 * time.Second
+)
+
+var (
+	ErrExecutionReverted error = &CallError{}
+	ErrUnknownSender           = errors.New("unknown transaction sender")
+	ErrTraceNotAvailable       = errors.New("the method debug_traceTransaction does not exist/is not available")
+)
+
+// CallError is a JSON-RPC error of the failed eth_call, with optional revert data.
+type CallError struct {
+	Message string
+	Data    []byte
+}
+
+// NewRevertError returns an eth_call error of the call reverted with the given reason string.
+func NewRevertError(reason string) *CallError {
+	encoded, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	if err != nil {
+		panic(err)
+	}
+	return &CallError{
+		Message: "execution reverted: " + reason,
+		Data:    append(Selector("Error(string)"), encoded...),
+	}
+}
+
+func (e *CallError) Error() string {
+	if e.Message == "" {
+		return "execution reverted"
+	}
+	return e.Message
+}
+
+func (e *CallError) ErrorCode() int {
+	return 3
+}
+
+func (e *CallError) ErrorData() interface{} {
+	if len(e.Data) == 0 {
+		return nil
+	}
+	return hexutil.Encode(e.Data)
+}
+
+var stringType, _ = abi.NewType("string", "", nil)
+
+// GenesisTime is the timestamp of the genesis block of all fake chains.
+var GenesisTime = time.Unix(1600000000, 0)
+
+// Log is an event emitted by a transaction.
+type Log struct {
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+}
+
+// Tx is a transaction to be included in a block. Failed transactions are included with failed receipt status
+// and without logs.
+type Tx struct {
+	From   common.Address
+	To     common.Address
+	Value  *big.Int
+	Data   []byte
+	Failed bool
+	Logs   []Log
+}
+
+// Block is a mined block with its transactions and receipts.
+type Block struct {
+	Header       *types.Header
+	Transactions []*types.Transaction
+	Receipts     []*types.Receipt
+}
+
+// Number returns the block number.
+func (b *Block) Number() uint {
+	return uint(b.Header.Number.Uint64())
+}
+
+// Logs returns all logs of the block.
+func (b *Block) Logs() []types.Log {
+	var logs []types.Log
+	for _, receipt := range b.Receipts {
+		for _, log := range receipt.Logs {
+			logs = append(logs, *log)
+		}
+	}
+	return logs
+}
+
+type txLocation struct {
+	block *Block
+	index int
+}
+
+type callKey struct {
+	to   common.Address
+	data string
+}
+
+// storageCheck is a storage slot value, which is required for successful calls of the contract.
+type storageCheck struct {
+	account common.Address
+	slot    common.Hash
+	value   common.Hash
+	err     error
+}
+
+// Chain is a fake chain backend. Blocks are mined explicitly with Mine, chain reorganizations are
+// simulated with Rewind followed by mining of the new blocks. All methods are safe for concurrent use.
+type Chain struct {
+	mu        sync.Mutex
+	chainID   *big.Int
+	blocks    []*Block
+	txs       map[common.Hash]*txLocation
+	senders   map[common.Hash]common.Address
+	calls     map[callKey][]byte
+	callErrs  map[callKey]error
+	checks    map[common.Address]storageCheck
+	traces    map[common.Hash]*ethclient.CallFrame
+	balances  map[common.Address]*big.Int
+	errors    map[string]error
+	nonce     uint64
+	forks     uint64
+	blockTime time.Duration
+}
+
+var _ ethclient.Client = (*Chain)(nil)
+
+// New creates a fake chain with the given chain id, containing only the genesis block.
+func New(chainID string) *Chain {
+	id, ok := new(big.Int).SetString(chainID, 10)
+	if !ok {
+		panic(fmt.Sprintf("invalid chain id %q", chainID))
+	}
+	c := &Chain{
+		chainID:   id,
+		txs:       make(map[common.Hash]*txLocation),
+		senders:   make(map[common.Hash]common.Address),
+		calls:     make(map[callKey][]byte),
+		callErrs:  make(map[callKey]error),
+		checks:    make(map[common.Address]storageCheck),
+		traces:    make(map[common.Hash]*ethclient.CallFrame),
+		balances:  make(map[common.Address]*big.Int),
+		errors:    make(map[string]error),
+		blockTime: defaultBlockTime,
+	}
+	c.blocks = []*Block{{
+		Header: &types.Header{
+			Number:   big.NewInt(0),
+			Time:     uint64(GenesisTime.Unix()),
+			GasLimit: defaultGasLimit,
+		},
+	}}
+	return c
+}
+
+// ChainID returns the chain id as it is used in the monitor config.
+func (c *Chain) ChainID() string {
+	return c.chainID.String()
+}
+
+// Head returns the latest block number.
+func (c *Chain) Head() uint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return uint(len(c.blocks) - 1)
+}
+
+// Block returns the canonical block with the given number, or nil if it does not exist.
+func (c *Chain) Block(n uint) *Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n >= uint(len(c.blocks)) {
+		return nil
+	}
+	return c.blocks[n]
+}
+
+// Mine appends a new block with the given transactions.
+func (c *Chain) Mine(txs ...*Tx) *Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	parent := c.blocks[len(c.blocks)-1].Header
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Time:       parent.Time + uint64(c.blockTime.Seconds()),
+		GasLimit:   defaultGasLimit,
+		// fork number makes blocks mined after rewind different from the removed ones
+		Extra: new(big.Int).SetUint64(c.forks).Bytes(),
+	}
+	block := &Block{Header: header}
+	blockHash := header.Hash()
+
+	logIndex := uint(0)
+	for i, tx := range txs {
+		to := tx.To
+		value := tx.Value
+		if value == nil {
+			value = new(big.Int)
+		}
+		transaction := types.NewTx(&types.LegacyTx{
+			Nonce:    c.nonce,
+			GasPrice: big.NewInt(1),
+			Gas:      defaultGasLimit,
+			To:       &to,
+			Value:    value,
+			Data:     tx.Data,
+		})
+		c.nonce++
+		receipt := &types.Receipt{
+			Type:             types.LegacyTxType,
+			Status:           types.ReceiptStatusSuccessful,
+			TxHash:           transaction.Hash(),
+			GasUsed:          defaultGasLimit / 10,
+			BlockHash:        blockHash,
+			BlockNumber:      header.Number,
+			TransactionIndex: uint(i),
+		}
+		if tx.Failed {
+			receipt.Status = types.ReceiptStatusFailed
+		} else {
+			for _, log := range tx.Logs {
+				receipt.Logs = append(receipt.Logs, &types.Log{
+					Address:     log.Address,
+					Topics:      log.Topics,
+					Data:        log.Data,
+					BlockNumber: header.Number.Uint64(),
+					TxHash:      transaction.Hash(),
+					TxIndex:     uint(i),
+					BlockHash:   blockHash,
+					Index:       logIndex,
+				})
+				logIndex++
+			}
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		block.Transactions = append(block.Transactions, transaction)
+		block.Receipts = append(block.Receipts, receipt)
+		c.txs[transaction.Hash()] = &txLocation{block: block, index: i}
+		c.senders[transaction.Hash()] = tx.From
+	}
+	c.blocks = append(c.blocks, block)
+	return block
+}
+
+// MineEmpty appends n empty blocks, and returns the last one.
+func (c *Chain) MineEmpty(n uint) *Block {
+	var block *Block
+	for i := uint(0); i < n; i++ {
+		block = c.Mine()
+	}
+	return block
+}
+
+// Rewind removes all blocks after the given block number, together with their transactions,
+// so that the following calls to Mine build an alternative chain.
+func (c *Chain) Rewind(n uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n+1 >= uint(len(c.blocks)) {
+		return
+	}
+	for _, block := range c.blocks[n+1:] {
+		for _, tx := range block.Transactions {
+			delete(c.txs, tx.Hash())
+		}
+	}
+	c.blocks = c.blocks[:n+1]
+	c.forks++
+}
+
+// SetCallResult configures the result of eth_call to the given contract. Data is either the full calldata,
+// or a 4-byte method selector, matching all calls of the method. Full calldata matches take precedence.
+func (c *Chain) SetCallResult(to common.Address, data, result []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls[callKey{to, string(data)}] = result
+}
+
+// SetCallError makes eth_call to the given contract fail with err, data is matched the same way as in SetCallResult.
+func (c *Chain) SetCallError(to common.Address, data []byte, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.callErrs[callKey{to, string(data)}] = err
+}
+
+// SetCallStorageCheck makes all eth_call requests to the given contract revert with the reason,
+// unless the storage slot of the account is overridden with the value, e.g. bridge messageSender checked by the AMB mediators.
+func (c *Chain) SetCallStorageCheck(to, account common.Address, slot, value common.Hash, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks[to] = storageCheck{account: account, slot: slot, value: value, err: NewRevertError(reason)}
+}
+
+// SetTrace configures the call tree of the transaction, returned by debug_traceTransaction.
+// Transactions without a configured trace fail with ErrTraceNotAvailable.
+func (c *Chain) SetTrace(hash common.Hash, trace *ethclient.CallFrame) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.traces[hash] = trace
+}
+
+// SetBalance sets the native coin balance of the given account, returned by eth_getBalance.
+func (c *Chain) SetBalance(account common.Address, balance *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.balances[account] = new(big.Int).Set(balance)
+}
+
+// SetError makes all subsequent requests of the given RPC method (e.g. eth_getLogs) fail with err,
+// nil err restores normal operation.
+func (c *Chain) SetError(method string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		delete(c.errors, method)
+	} else {
+		c.errors[method] = err
+	}
+}
+
+// checkError should be called with c.mu held.
+func (c *Chain) checkError(ctx context.Context, method string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.errors[method]
+}
+
+func (c *Chain) Close() {}
+
+func (c *Chain) BlockNumber(ctx context.Context) (uint, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkError(ctx, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+	return uint(len(c.blocks) - 1), nil
+}
+
+func (c *Chain) HeaderByNumber(ctx context.Context, n uint) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkError(ctx, "eth_getBlockByNumber"); err != nil {
+		return nil, err
+	}
+	if n >= uint(len(c.blocks)) {
+		return nil, ethereum.NotFound
+	}
+	return types.CopyHeader(c.blocks[n].Header), nil
+}
+
+func (c *Chain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkError(ctx, "eth_getLogs"); err != nil {
+		return nil, err
+	}
+	return c.filterLogs(q)
+}
+
+// FilterLogsSafe is the same as FilterLogs, but fails if the chain head is behind the requested toBlock,
+// same as the real client does.
+func (c *Chain) FilterLogsSafe(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkError(ctx, "eth_getLogs"); err != nil {
+		return nil, err
+	}
+	if q.BlockHash != nil || q.ToBlock == nil || q.ToBlock.Sign() <= 0 {
+		return nil, fmt.Errorf("only positive toBlock is supported: %w", ethclient.ErrInvalidLogsQuery)
+	}
+	logs, err := c.filterLogs(q)
+	if err != nil {
+		return nil, err
+	}
+	head := uint64(len(c.blocks) - 1)
+	if head < q.ToBlock.Uint64() {
+		return nil, fmt.Errorf("current block %d is older than toBlock %s in the query: %w", head, q.ToBlock, ethclient.ErrNodeIsNotSynced)
+	}
+	return logs, nil
+}
+
+// filterLogs should be called with c.mu held.
+func (c *Chain) filterLogs(q ethereum.FilterQuery) ([]types.Log, error) {
+	if q.BlockHash != nil {
+		return nil, fmt.Errorf("block hash filter is not supported: %w", ethclient.ErrInvalidLogsQuery)
+	}
+	head := uint64(len(c.blocks) - 1)
+	from, to := uint64(0), head
+	if q.FromBlock != nil {
+		from = q.FromBlock.Uint64()
+	}
+	if q.ToBlock != nil && q.ToBlock.Uint64() < to {
+		to = q.ToBlock.Uint64()
+	}
+
+	logs := make([]types.Log, 0, 10)
+	for n := from; n <= to && n <= head; n++ {
+		for _, log := range c.blocks[n].Logs() {
+			if matchLog(&log, q) {
+				logs = append(logs, log)
+			}
+		}
+	}
+	return logs, nil
+}
+
+// matchLog implements eth_getLogs address and topics filtering: empty lists match anything,
+// topics are matched by position.
+func matchLog(log *types.Log, q ethereum.FilterQuery) bool {
+	if len(q.Addresses) > 0 {
+		found := false
+		for _, addr := range q.Addresses {
+			if addr == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(q.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range q.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range topics {
+			if topic == log.Topics[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Chain) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkError(ctx, "eth_getTransactionByHash"); err != nil {
+		return nil, err
+	}
+	loc, ok := c.txs[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return loc.block.Transactions[loc.index], nil
+}
+
+func (c *Chain) TransactionReceiptByHash(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkError(ctx, "eth_getTransactionReceipt"); err != nil {
+		return nil, err
+	}
+	loc, ok := c.txs[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return loc.block.Receipts[loc.index], nil
+}
+
+func (c *Chain) CallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return c.CallContractWithStorage(ctx, msg, nil)
+}
+
+func (c *Chain) CallContractWithStorage(ctx context.Context, msg ethereum.CallMsg, storage ethclient.StorageOverride) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkError(ctx, "eth_call"); err != nil {
+		return nil, err
+	}
+	if msg.To == nil {
+		return nil, ErrExecutionReverted
+	}
+	if check, ok := c.checks[*msg.To]; ok && storage[check.account][check.slot] != check.value {
+		return nil, check.err
+	}
+	keys := []callKey{{*msg.To, string(msg.Data)}}
+	if len(msg.Data) >= 4 {
+		keys = append(keys, callKey{*msg.To, string(msg.Data[:4])})
+	}
+	for _, key := range keys {
+		if err, ok := c.callErrs[key]; ok {
+			return nil, err
+		}
+		if res, ok := c.calls[key]; ok {
+			return common.CopyBytes(res), nil
+		}
+	}
+	return nil, ErrExecutionReverted
+}
+
+func (c *Chain) BalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkError(ctx, "eth_getBalance"); err != nil {
+		return nil, err
+	}
+	if balance, ok := c.balances[account]; ok {
+		return new(big.Int).Set(balance), nil
+	}
+	return new(big.Int), nil
+}
+
+func (c *Chain) TransactionSender(tx *types.Transaction) (common.Address, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sender, ok := c.senders[tx.Hash()]
+	if !ok {
+		return common.Address{}, ErrUnknownSender
+	}
+	return sender, nil
+}
+
+func (c *Chain) TraceTransaction(ctx context.Context, hash common.Hash) (*ethclient.CallFrame, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkError(ctx, "debug_traceTransaction"); err != nil {
+		return nil, err
+	}
+	trace, ok := c.traces[hash]
+	if !ok {
+		return nil, ErrTraceNotAvailable
+	}
+	return trace, nil
+}
+
+// Selector returns the 4-byte selector of the given method signature, e.g. "validatorContract()".
+func Selector(method string) []byte {
+	return crypto.Keccak256([]byte(method))[:4]
+}
@@ -0,0 +1,151 @@
+package fakechain_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract"
+	"github.com/omni/tokenbridge-monitor/contract/bridgeabi"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/ethclient/fakechain"
+)
+
+var (
+	bridgeAddress    = common.HexToAddress("0x01")
+	validatorAddress = common.HexToAddress("0x02")
+	validator1       = common.HexToAddress("0x11")
+	validator2       = common.HexToAddress("0x12")
+)
+
+func TestChain_FilterLogs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := fakechain.New("100")
+	bridge := fakechain.NewBridge(config.BridgeModeArbitraryMessage, bridgeAddress, validatorAddress)
+	chain.Mine(&fakechain.Tx{To: validatorAddress, Logs: []fakechain.Log{bridge.ValidatorAdded(validator1), bridge.ValidatorAdded(validator2)}})
+	chain.MineEmpty(2)
+	chain.Mine(&fakechain.Tx{To: validatorAddress, Logs: []fakechain.Log{bridge.ValidatorRemoved(validator1)}})
+	chain.Mine(&fakechain.Tx{To: validatorAddress, Failed: true, Logs: []fakechain.Log{bridge.ValidatorRemoved(validator2)}})
+
+	head, err := chain.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(5), head)
+
+	for name, tc := range map[string]struct {
+		query    ethereum.FilterQuery
+		expected []uint
+	}{
+		"all":             {ethereum.FilterQuery{}, []uint{1, 1, 4}},
+		"block range":     {ethereum.FilterQuery{FromBlock: big.NewInt(2), ToBlock: big.NewInt(4)}, []uint{4}},
+		"other address":   {ethereum.FilterQuery{Addresses: []common.Address{bridgeAddress}}, nil},
+		"topic wildcard":  {ethereum.FilterQuery{Topics: [][]common.Hash{{}, {validator1.Hash()}}}, []uint{1, 4}},
+		"topic variants":  {ethereum.FilterQuery{Topics: [][]common.Hash{{bridgeabi.ArbitraryMessageABI.Events["ValidatorAdded"].ID}, {validator1.Hash(), validator2.Hash()}}}, []uint{1, 1}},
+		"too many topics": {ethereum.FilterQuery{Topics: [][]common.Hash{{}, {}, {}}}, nil},
+	} {
+		logs, err := chain.FilterLogs(ctx, tc.query)
+		require.NoError(t, err, name)
+		blocks := make([]uint, 0, len(logs))
+		for _, log := range logs {
+			blocks = append(blocks, uint(log.BlockNumber))
+		}
+		require.ElementsMatch(t, tc.expected, blocks, name)
+	}
+
+	_, err = chain.FilterLogsSafe(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(10)})
+	require.ErrorIs(t, err, ethclient.ErrNodeIsNotSynced)
+	logs, err := chain.FilterLogsSafe(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(5)})
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+
+	failedTx := chain.Block(5).Transactions[0]
+	receipt, err := chain.TransactionReceiptByHash(ctx, failedTx.Hash())
+	require.NoError(t, err)
+	require.Zero(t, receipt.Status)
+}
+
+func TestChain_Rewind(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := fakechain.New("100")
+	bridge := fakechain.NewBridge(config.BridgeModeArbitraryMessage, bridgeAddress, validatorAddress)
+	chain.MineEmpty(3)
+	removed := chain.Mine(&fakechain.Tx{From: validator1, To: validatorAddress, Logs: []fakechain.Log{bridge.ValidatorAdded(validator1)}})
+	removedTx := removed.Transactions[0]
+
+	chain.Rewind(3)
+	added := chain.Mine(&fakechain.Tx{From: validator2, To: validatorAddress, Logs: []fakechain.Log{bridge.ValidatorAdded(validator2)}})
+	require.Equal(t, removed.Number(), added.Number())
+	require.NotEqual(t, removed.Header.Hash(), added.Header.Hash())
+
+	_, err := chain.TransactionByHash(ctx, removedTx.Hash())
+	require.ErrorIs(t, err, ethereum.NotFound)
+	tx, err := chain.TransactionByHash(ctx, added.Transactions[0].Hash())
+	require.NoError(t, err)
+	sender, err := chain.TransactionSender(tx)
+	require.NoError(t, err)
+	require.Equal(t, validator2, sender)
+
+	logs, err := chain.FilterLogs(ctx, ethereum.FilterQuery{})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, added.Header.Hash(), logs[0].BlockHash)
+	require.Equal(t, validator2.Hash(), logs[0].Topics[1])
+}
+
+func TestBridge_Events(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := fakechain.New("100")
+	bridge := fakechain.NewBridge(config.BridgeModeArbitraryMessage, bridgeAddress, validatorAddress)
+	bridge.Deploy(chain)
+
+	bridgeContract := contract.NewBridgeContract(chain, bridgeAddress, config.BridgeModeArbitraryMessage)
+	addr, err := bridgeContract.ValidatorContractAddress(ctx)
+	require.NoError(t, err)
+	require.Equal(t, validatorAddress, addr)
+	_, err = bridgeContract.RequiredSignatures(ctx)
+	require.ErrorIs(t, err, fakechain.ErrExecutionReverted)
+	require.True(t, ethclient.IsExecutionError(err))
+	require.Equal(t, "execution reverted", ethclient.RevertReason(err))
+	chain.SetCallError(bridgeAddress, fakechain.Selector("requiredSignatures()"), fakechain.NewRevertError("not initialized"))
+	_, err = bridgeContract.RequiredSignatures(ctx)
+	require.True(t, ethclient.IsExecutionError(err))
+	require.Equal(t, "not initialized", ethclient.RevertReason(err))
+
+	msg := &fakechain.AMBMessage{
+		MessageID:          fakechain.AMBMessageID(1),
+		Sender:             common.HexToAddress("0x21"),
+		Executor:           common.HexToAddress("0x22"),
+		GasLimit:           100000,
+		SourceChainID:      big.NewInt(100),
+		DestinationChainID: big.NewInt(1),
+		Data:               []byte{1, 2, 3},
+	}
+	block := chain.Mine(&fakechain.Tx{To: bridgeAddress, Logs: []fakechain.Log{
+		bridge.UserRequestForSignature(msg),
+		bridge.CollectedSignatures(validator1, msg.Hash(), 2),
+	}})
+	logs := block.Logs()
+	require.Len(t, logs, 2)
+
+	event, data, err := bridgeContract.ABI.ParseLog(entity.NewLog("100", logs[0]))
+	require.NoError(t, err)
+	require.Equal(t, bridgeabi.UserRequestForSignature, event)
+	require.Equal(t, msg.Encode(), data["encodedData"])
+
+	event, data, err = bridgeContract.ABI.ParseLog(entity.NewLog("100", logs[1]))
+	require.NoError(t, err)
+	require.Equal(t, bridgeabi.CollectedSignatures, event)
+	require.Equal(t, validator1, data["authorityResponsibleForRelay"])
+	require.Equal(t, [32]byte(msg.Hash()), data["messageHash"])
+}
@@ -0,0 +1,225 @@
+package fakechain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract"
+	"github.com/omni/tokenbridge-monitor/contract/abi"
+	"github.com/omni/tokenbridge-monitor/contract/bridgeabi"
+)
+
+// AMBMessage is an arbitrary message, encoded in the same way as AMB contracts of version 5 do.
+type AMBMessage struct {
+	MessageID          common.Hash
+	Sender             common.Address
+	Executor           common.Address
+	GasLimit           uint32
+	DataType           byte
+	SourceChainID      *big.Int
+	DestinationChainID *big.Int
+	Data               []byte
+}
+
+// AMBMessageID returns a message id of version 5 with the given nonce.
+func AMBMessageID(nonce uint64) common.Hash {
+	var id common.Hash
+	copy(id[:], []byte{0, 5, 0, 0})
+	binary.BigEndian.PutUint64(id[24:], nonce)
+	return id
+}
+
+// Encode returns the message in the format of UserRequestForSignature and UserRequestForAffirmation events.
+func (m *AMBMessage) Encode() []byte {
+	srcChainID := m.SourceChainID.Bytes()
+	dstChainID := m.DestinationChainID.Bytes()
+	gasLimit := make([]byte, 4)
+	binary.BigEndian.PutUint32(gasLimit, m.GasLimit)
+
+	res := append([]byte{}, m.MessageID[:]...)
+	res = append(res, m.Sender[:]...)
+	res = append(res, m.Executor[:]...)
+	res = append(res, gasLimit...)
+	res = append(res, byte(len(srcChainID)), byte(len(dstChainID)), m.DataType)
+	res = append(res, srcChainID...)
+	res = append(res, dstChainID...)
+	res = append(res, m.Data...)
+	return res
+}
+
+// Hash returns the message hash, which is signed by the bridge validators.
+func (m *AMBMessage) Hash() common.Hash {
+	return crypto.Keccak256Hash(m.Encode())
+}
+
+// Bridge builds logs of the bridge contract and its validator contract.
+type Bridge struct {
+	Mode              config.BridgeMode
+	Address           common.Address
+	ValidatorContract common.Address
+	abi               abi.ABI
+}
+
+func NewBridge(mode config.BridgeMode, address, validatorContract common.Address) *Bridge {
+	return &Bridge{
+		Mode:              mode,
+		Address:           address,
+		ValidatorContract: validatorContract,
+		abi:               contract.BridgeABI(mode),
+	}
+}
+
+// Deploy configures chain responses to the bridge contract calls made by the monitor on startup.
+func (b *Bridge) Deploy(c *Chain) {
+	c.SetCallResult(b.Address, Selector("validatorContract()"), common.LeftPadBytes(b.ValidatorContract[:], 32))
+}
+
+// log encodes the event with the given signature (e.g. bridgeabi.ValidatorAdded), args are given in the order
+// of the event inputs. It panics if the event is not present in the bridge ABI, or args do not match its inputs.
+func (b *Bridge) log(address common.Address, signature string, args ...interface{}) Log {
+	var event *gethabi.Event
+	for _, e := range b.abi.Events {
+		if e.String() == signature {
+			e := e
+			event = &e
+			break
+		}
+	}
+	if event == nil {
+		panic(fmt.Sprintf("event %q is not present in %s bridge ABI", signature, b.Mode))
+	}
+	if len(args) != len(event.Inputs) {
+		panic(fmt.Sprintf("event %q expects %d args, got %d", signature, len(event.Inputs), len(args)))
+	}
+
+	topics := []common.Hash{event.ID}
+	var nonIndexed []interface{}
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			nonIndexed = append(nonIndexed, args[i])
+			continue
+		}
+		topic, err := gethabi.MakeTopics([]interface{}{args[i]})
+		if err != nil {
+			panic(fmt.Sprintf("can't encode topic %s of event %q: %s", input.Name, signature, err))
+		}
+		topics = append(topics, topic[0][0])
+	}
+	data, err := event.Inputs.NonIndexed().Pack(nonIndexed...)
+	if err != nil {
+		panic(fmt.Sprintf("can't encode data of event %q: %s", signature, err))
+	}
+	return Log{
+		Address: address,
+		Topics:  topics,
+		Data:    data,
+	}
+}
+
+// UserRequestForSignature is emitted by the home AMB contract for home to foreign messages.
+func (b *Bridge) UserRequestForSignature(msg *AMBMessage) Log {
+	return b.log(b.Address, bridgeabi.UserRequestForSignature, msg.MessageID, msg.Encode())
+}
+
+// UserRequestForAffirmation is emitted by the foreign AMB contract for foreign to home messages.
+func (b *Bridge) UserRequestForAffirmation(msg *AMBMessage) Log {
+	return b.log(b.Address, bridgeabi.UserRequestForAffirmation, msg.MessageID, msg.Encode())
+}
+
+func (b *Bridge) SignedForUserRequest(signer common.Address, msgHash common.Hash) Log {
+	return b.log(b.Address, bridgeabi.SignedForUserRequest, signer, msgHash)
+}
+
+func (b *Bridge) SignedForAffirmation(signer common.Address, msgHash common.Hash) Log {
+	return b.log(b.Address, bridgeabi.SignedForAffirmation, signer, msgHash)
+}
+
+func (b *Bridge) CollectedSignatures(relayer common.Address, msgHash common.Hash, numSignatures uint) Log {
+	return b.log(b.Address, bridgeabi.CollectedSignatures, relayer, msgHash, new(big.Int).SetUint64(uint64(numSignatures)))
+}
+
+// AffirmationCompleted is emitted by the home AMB contract after foreign to home message execution.
+func (b *Bridge) AffirmationCompleted(msg *AMBMessage, status bool) Log {
+	return b.log(b.Address, bridgeabi.AffirmationCompleted, msg.Sender, msg.Executor, msg.MessageID, status)
+}
+
+// RelayedMessage is emitted by the foreign AMB contract after home to foreign message execution.
+func (b *Bridge) RelayedMessage(msg *AMBMessage, status bool) Log {
+	return b.log(b.Address, bridgeabi.RelayedMessage, msg.Sender, msg.Executor, msg.MessageID, status)
+}
+
+// ErcToNativeUserRequestForSignature is emitted by the home ERC_TO_NATIVE contract for home to foreign transfers.
+func (b *Bridge) ErcToNativeUserRequestForSignature(recipient common.Address, value *big.Int) Log {
+	return b.log(b.Address, bridgeabi.ErcToNativeUserRequestForSignature, recipient, value)
+}
+
+// ErcToNativeUserRequestForAffirmation is emitted by the foreign ERC_TO_NATIVE contract for foreign to home transfers.
+func (b *Bridge) ErcToNativeUserRequestForAffirmation(recipient common.Address, value *big.Int) Log {
+	return b.log(b.Address, bridgeabi.ErcToNativeUserRequestForAffirmation, recipient, value)
+}
+
+// ErcToNativeTransfer is emitted by the bridged token contract.
+func (b *Bridge) ErcToNativeTransfer(token, from, to common.Address, value *big.Int) Log {
+	return b.log(token, bridgeabi.ErcToNativeTransfer, from, to, value)
+}
+
+// ErcToNativeSignedForAffirmation should be emitted by the transaction with ErcToNativeExecuteAffirmationData calldata.
+func (b *Bridge) ErcToNativeSignedForAffirmation(signer common.Address, transactionHash common.Hash) Log {
+	return b.log(b.Address, bridgeabi.ErcToNativeSignedForAffirmation, signer, transactionHash)
+}
+
+// ErcToNativeTokensSwapped is emitted by the foreign ERC_TO_NATIVE contract when the bridged token is migrated.
+func (b *Bridge) ErcToNativeTokensSwapped(from, to common.Address, value *big.Int) Log {
+	return b.log(b.Address, bridgeabi.ErcToNativeTokensSwapped, from, to, value)
+}
+
+// ErcToNativePaidInterest is emitted by the foreign ERC_TO_NATIVE contract when the interest on the invested tokens is paid.
+func (b *Bridge) ErcToNativePaidInterest(token, receiver common.Address, value *big.Int) Log {
+	return b.log(b.Address, bridgeabi.ErcToNativePaidInterest, token, receiver, value)
+}
+
+func (b *Bridge) ErcToNativeAffirmationCompleted(recipient common.Address, value *big.Int, transactionHash common.Hash) Log {
+	return b.log(b.Address, bridgeabi.ErcToNativeAffirmationCompleted, recipient, value, transactionHash)
+}
+
+func (b *Bridge) ErcToNativeRelayedMessage(recipient common.Address, value *big.Int, transactionHash common.Hash) Log {
+	return b.log(b.Address, bridgeabi.ErcToNativeRelayedMessage, recipient, value, transactionHash)
+}
+
+// ErcToNativeExecuteAffirmationData returns calldata of the executeAffirmation call, which is sent by the validators
+// of the ERC_TO_NATIVE bridge to confirm foreign to home transfers.
+func ErcToNativeExecuteAffirmationData(recipient common.Address, value *big.Int, transactionHash common.Hash) []byte {
+	res := Selector("executeAffirmation(address,uint256,bytes32)")
+	res = append(res, common.LeftPadBytes(recipient[:], 32)...)
+	res = append(res, common.LeftPadBytes(value.Bytes(), 32)...)
+	res = append(res, transactionHash[:]...)
+	return res
+}
+
+// ErcToNativeMsgHash returns the hash of the home to foreign ERC_TO_NATIVE message, which is signed by the validators.
+func ErcToNativeMsgHash(recipient common.Address, value *big.Int, transactionHash common.Hash, foreignBridge common.Address) common.Hash {
+	msg := append([]byte{}, recipient[:]...)
+	msg = append(msg, common.LeftPadBytes(value.Bytes(), 32)...)
+	msg = append(msg, transactionHash[:]...)
+	msg = append(msg, foreignBridge[:]...)
+	return crypto.Keccak256Hash(msg)
+}
+
+// ErcToNativeAffirmationHash returns the hash of the foreign to home ERC_TO_NATIVE message, which is signed by the validators.
+func ErcToNativeAffirmationHash(recipient common.Address, value *big.Int, transactionHash common.Hash) common.Hash {
+	return crypto.Keccak256Hash(ErcToNativeExecuteAffirmationData(recipient, value, transactionHash)[16:])
+}
+
+func (b *Bridge) ValidatorAdded(validator common.Address) Log {
+	return b.log(b.ValidatorContract, bridgeabi.ValidatorAdded, validator)
+}
+
+func (b *Bridge) ValidatorRemoved(validator common.Address) Log {
+	return b.log(b.ValidatorContract, bridgeabi.ValidatorRemoved, validator)
+}
@@ -0,0 +1,308 @@
+package ethclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var ErrNoRecordedResponse = errors.New("no recorded response for the request")
+
+// Record is a single client request together with its response, as stored in the fixture file.
+type Record struct {
+	Method string          `json:"method"`
+	Args   json.RawMessage `json:"args,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// Fixture holds recorded client requests, grouped by chain id.
+type Fixture struct {
+	Chains map[string][]*Record `json:"chains"`
+}
+
+// Recorder wraps clients, so that all their requests and responses are saved into a single fixture.
+type Recorder struct {
+	mu      sync.Mutex
+	fixture *Fixture
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{fixture: &Fixture{Chains: make(map[string][]*Record)}}
+}
+
+// Wrap returns a client, which records all requests made to the given client.
+func (r *Recorder) Wrap(client Client, chainID string) Client {
+	return &recordingClient{
+		client:   client,
+		chainID:  chainID,
+		recorder: r,
+	}
+}
+
+// Save writes all requests recorded so far to the fixture file.
+func (r *Recorder) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.fixture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("can't encode fixture: %w", err)
+	}
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("can't write fixture file: %w", err)
+	}
+	return nil
+}
+
+func (r *Recorder) add(chainID string, rec *Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fixture.Chains[chainID] = append(r.fixture.Chains[chainID], rec)
+}
+
+// LoadFixture reads the fixture file previously written by Recorder.Save.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read fixture file: %w", err)
+	}
+	var fixture Fixture
+	if err = json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("can't decode fixture file: %w", err)
+	}
+	return &fixture, nil
+}
+
+// ChainIDs returns ids of all chains present in the fixture.
+func (f *Fixture) ChainIDs() []string {
+	ids := make([]string, 0, len(f.Chains))
+	for id := range f.Chains {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Client returns a client, which serves recorded responses for the given chain without making any network requests.
+// Identical requests are answered in the order they were recorded, the last response is repeated afterwards.
+// Requests that were never recorded fail with ErrNoRecordedResponse.
+func (f *Fixture) Client(chainID string) (Client, error) {
+	records, ok := f.Chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("fixture does not contain requests for chain %s: %w", chainID, ErrNoRecordedResponse)
+	}
+	c := &replayClient{
+		chainID:   chainID,
+		responses: make(map[string][]*Record, len(records)),
+	}
+	for _, rec := range records {
+		key, err := recordKey(rec.Method, rec.Args)
+		if err != nil {
+			return nil, err
+		}
+		c.responses[key] = append(c.responses[key], rec)
+	}
+	return c, nil
+}
+
+func recordKey(method string, args json.RawMessage) (string, error) {
+	var buf bytes.Buffer
+	if len(args) > 0 {
+		if err := json.Compact(&buf, args); err != nil {
+			return "", fmt.Errorf("can't normalize %s request args: %w", method, err)
+		}
+	}
+	return method + ":" + buf.String(), nil
+}
+
+// callWithStorageArgs are the recorded arguments of CallContractWithStorage.
+type callWithStorageArgs struct {
+	Msg     ethereum.CallMsg
+	Storage StorageOverride
+}
+
+type recordingClient struct {
+	client   Client
+	chainID  string
+	recorder *Recorder
+}
+
+func record[T any](c *recordingClient, method string, args interface{}, res T, err error) (T, error) {
+	rec := &Record{Method: method}
+	if args != nil {
+		rec.Args, _ = json.Marshal(args)
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	} else {
+		rec.Result, _ = json.Marshal(res)
+	}
+	c.recorder.add(c.chainID, rec)
+	return res, err
+}
+
+func (c *recordingClient) Close() {
+	c.client.Close()
+}
+
+func (c *recordingClient) BlockNumber(ctx context.Context) (uint, error) {
+	n, err := c.client.BlockNumber(ctx)
+	return record(c, "BlockNumber", nil, n, err)
+}
+
+func (c *recordingClient) HeaderByNumber(ctx context.Context, n uint) (*types.Header, error) {
+	header, err := c.client.HeaderByNumber(ctx, n)
+	return record(c, "HeaderByNumber", n, header, err)
+}
+
+func (c *recordingClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	logs, err := c.client.FilterLogs(ctx, q)
+	return record(c, "FilterLogs", q, logs, err)
+}
+
+func (c *recordingClient) FilterLogsSafe(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	logs, err := c.client.FilterLogsSafe(ctx, q)
+	return record(c, "FilterLogsSafe", q, logs, err)
+}
+
+func (c *recordingClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, error) {
+	tx, err := c.client.TransactionByHash(ctx, hash)
+	return record(c, "TransactionByHash", hash, tx, err)
+}
+
+func (c *recordingClient) TransactionReceiptByHash(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, err := c.client.TransactionReceiptByHash(ctx, hash)
+	return record(c, "TransactionReceiptByHash", hash, receipt, err)
+}
+
+func (c *recordingClient) CallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	res, err := c.client.CallContract(ctx, msg)
+	_, err = record(c, "CallContract", msg, hexutil.Bytes(res), err)
+	return res, err
+}
+
+func (c *recordingClient) CallContractWithStorage(ctx context.Context, msg ethereum.CallMsg, storage StorageOverride) ([]byte, error) {
+	res, err := c.client.CallContractWithStorage(ctx, msg, storage)
+	_, err = record(c, "CallContractWithStorage", callWithStorageArgs{msg, storage}, hexutil.Bytes(res), err)
+	return res, err
+}
+
+func (c *recordingClient) BalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	balance, err := c.client.BalanceAt(ctx, account)
+	return record(c, "BalanceAt", account, balance, err)
+}
+
+func (c *recordingClient) TransactionSender(tx *types.Transaction) (common.Address, error) {
+	sender, err := c.client.TransactionSender(tx)
+	return record(c, "TransactionSender", tx.Hash(), sender, err)
+}
+
+func (c *recordingClient) TraceTransaction(ctx context.Context, hash common.Hash) (*CallFrame, error) {
+	trace, err := c.client.TraceTransaction(ctx, hash)
+	return record(c, "TraceTransaction", hash, trace, err)
+}
+
+type replayClient struct {
+	mu        sync.Mutex
+	chainID   string
+	responses map[string][]*Record
+}
+
+func replay[T any](c *replayClient, method string, args interface{}) (T, error) {
+	var res T
+	var rawArgs json.RawMessage
+	if args != nil {
+		var err error
+		if rawArgs, err = json.Marshal(args); err != nil {
+			return res, fmt.Errorf("can't encode %s request args: %w", method, err)
+		}
+	}
+	key, err := recordKey(method, rawArgs)
+	if err != nil {
+		return res, err
+	}
+
+	c.mu.Lock()
+	records := c.responses[key]
+	if len(records) == 0 {
+		c.mu.Unlock()
+		return res, fmt.Errorf("chain %s, %s(%s): %w", c.chainID, method, rawArgs, ErrNoRecordedResponse)
+	}
+	rec := records[0]
+	if len(records) > 1 {
+		c.responses[key] = records[1:]
+	}
+	c.mu.Unlock()
+
+	if rec.Error != "" {
+		if rec.Error == ethereum.NotFound.Error() {
+			return res, ethereum.NotFound
+		}
+		return res, errors.New(rec.Error)
+	}
+	if err = json.Unmarshal(rec.Result, &res); err != nil {
+		return res, fmt.Errorf("can't decode recorded %s response: %w", method, err)
+	}
+	return res, nil
+}
+
+func (c *replayClient) Close() {}
+
+func (c *replayClient) BlockNumber(context.Context) (uint, error) {
+	return replay[uint](c, "BlockNumber", nil)
+}
+
+func (c *replayClient) HeaderByNumber(_ context.Context, n uint) (*types.Header, error) {
+	return replay[*types.Header](c, "HeaderByNumber", n)
+}
+
+func (c *replayClient) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return replay[[]types.Log](c, "FilterLogs", q)
+}
+
+func (c *replayClient) FilterLogsSafe(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return replay[[]types.Log](c, "FilterLogsSafe", q)
+}
+
+func (c *replayClient) TransactionByHash(_ context.Context, hash common.Hash) (*types.Transaction, error) {
+	return replay[*types.Transaction](c, "TransactionByHash", hash)
+}
+
+func (c *replayClient) TransactionReceiptByHash(_ context.Context, hash common.Hash) (*types.Receipt, error) {
+	return replay[*types.Receipt](c, "TransactionReceiptByHash", hash)
+}
+
+func (c *replayClient) CallContract(_ context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	res, err := replay[hexutil.Bytes](c, "CallContract", msg)
+	return res, err
+}
+
+func (c *replayClient) CallContractWithStorage(_ context.Context, msg ethereum.CallMsg, storage StorageOverride) ([]byte, error) {
+	res, err := replay[hexutil.Bytes](c, "CallContractWithStorage", callWithStorageArgs{msg, storage})
+	return res, err
+}
+
+func (c *replayClient) BalanceAt(_ context.Context, account common.Address) (*big.Int, error) {
+	return replay[*big.Int](c, "BalanceAt", account)
+}
+
+func (c *replayClient) TransactionSender(tx *types.Transaction) (common.Address, error) {
+	return replay[common.Address](c, "TransactionSender", tx.Hash())
+}
+
+func (c *replayClient) TraceTransaction(_ context.Context, hash common.Hash) (*CallFrame, error) {
+	return replay[*CallFrame](c, "TraceTransaction", hash)
+}
@@ -0,0 +1,103 @@
+package ethclient_test
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/ethclient/fakechain"
+)
+
+func TestRecorder_Replay(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	bridgeAddress := common.HexToAddress("0x01")
+	validator := common.HexToAddress("0x11")
+	chain := fakechain.New("100")
+	bridge := fakechain.NewBridge(config.BridgeModeArbitraryMessage, bridgeAddress, common.HexToAddress("0x02"))
+	bridge.Deploy(chain)
+	chain.MineEmpty(2)
+	block := chain.Mine(&fakechain.Tx{From: validator, To: bridgeAddress, Logs: []fakechain.Log{
+		bridge.SignedForUserRequest(validator, common.HexToHash("0xaa")),
+	}})
+	txHash := block.Transactions[0].Hash()
+	query := ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(3), Addresses: []common.Address{bridgeAddress}}
+	call := ethereum.CallMsg{To: &bridgeAddress, Data: fakechain.Selector("validatorContract()")}
+
+	recorder := ethclient.NewRecorder()
+	client := recorder.Wrap(chain, "100")
+	head, err := client.BlockNumber(ctx)
+	require.NoError(t, err)
+	header, err := client.HeaderByNumber(ctx, 3)
+	require.NoError(t, err)
+	logs, err := client.FilterLogsSafe(ctx, query)
+	require.NoError(t, err)
+	tx, err := client.TransactionByHash(ctx, txHash)
+	require.NoError(t, err)
+	receipt, err := client.TransactionReceiptByHash(ctx, txHash)
+	require.NoError(t, err)
+	sender, err := client.TransactionSender(tx)
+	require.NoError(t, err)
+	res, err := client.CallContract(ctx, call)
+	require.NoError(t, err)
+	_, err = client.TransactionByHash(ctx, common.HexToHash("0xbb"))
+	require.ErrorIs(t, err, ethereum.NotFound)
+	chain.MineEmpty(1)
+	_, err = client.BlockNumber(ctx)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(t, recorder.Save(path))
+	fixture, err := ethclient.LoadFixture(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"100"}, fixture.ChainIDs())
+	_, err = fixture.Client("1")
+	require.ErrorIs(t, err, ethclient.ErrNoRecordedResponse)
+	replay, err := fixture.Client("100")
+	require.NoError(t, err)
+
+	replayedHead, err := replay.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, head, replayedHead)
+	replayedHead, err = replay.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, head+1, replayedHead)
+	replayedHead, err = replay.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, head+1, replayedHead, "last response should be repeated")
+
+	replayedHeader, err := replay.HeaderByNumber(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), replayedHeader.Hash())
+	replayedLogs, err := replay.FilterLogsSafe(ctx, query)
+	require.NoError(t, err)
+	require.Equal(t, logs, replayedLogs)
+	replayedTx, err := replay.TransactionByHash(ctx, txHash)
+	require.NoError(t, err)
+	require.Equal(t, txHash, replayedTx.Hash())
+	replayedReceipt, err := replay.TransactionReceiptByHash(ctx, txHash)
+	require.NoError(t, err)
+	require.Equal(t, receipt.Status, replayedReceipt.Status)
+	require.Equal(t, receipt.Logs, replayedReceipt.Logs)
+	replayedSender, err := replay.TransactionSender(replayedTx)
+	require.NoError(t, err)
+	require.Equal(t, sender, replayedSender)
+	replayedRes, err := replay.CallContract(ctx, call)
+	require.NoError(t, err)
+	require.Equal(t, res, replayedRes)
+	_, err = replay.TransactionByHash(ctx, common.HexToHash("0xbb"))
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	_, err = replay.FilterLogs(ctx, query)
+	require.ErrorIs(t, err, ethclient.ErrNoRecordedResponse)
+	_, err = replay.HeaderByNumber(ctx, 2)
+	require.ErrorIs(t, err, ethclient.ErrNoRecordedResponse)
+}
@@ -0,0 +1,64 @@
+package ethclient
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// CallFrame is a single call of the traced transaction, in the format of the callTracer.
+type CallFrame struct {
+	Type    string         `json:"type"`
+	From    common.Address `json:"from"`
+	To      common.Address `json:"to"`
+	Input   hexutil.Bytes  `json:"input,omitempty"`
+	Output  hexutil.Bytes  `json:"output,omitempty"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Error   string         `json:"error,omitempty"`
+	Calls   []*CallFrame   `json:"calls,omitempty"`
+}
+
+// FailedCall returns the innermost call, which has caused the first failed call of the trace, or nil if all calls succeeded.
+// Within a failed call, the last failed sub call is followed, since the earlier ones might have been handled by the caller.
+func (f *CallFrame) FailedCall() *CallFrame {
+	failed := f.firstFailed()
+	if failed == nil {
+		return nil
+	}
+	for {
+		var next *CallFrame
+		for _, call := range failed.Calls {
+			if call.Error != "" {
+				next = call
+			}
+		}
+		if next == nil {
+			return failed
+		}
+		failed = next
+	}
+}
+
+func (f *CallFrame) firstFailed() *CallFrame {
+	if f.Error != "" {
+		return f
+	}
+	for _, call := range f.Calls {
+		if failed := call.firstFailed(); failed != nil {
+			return failed
+		}
+	}
+	return nil
+}
+
+// RevertReason returns the reason string of the failed call, decoded from its output.
+// The call error is returned for calls without a reason string, together with the raw output, if there is any.
+func (f *CallFrame) RevertReason() string {
+	if reason, err := abi.UnpackRevert(f.Output); err == nil {
+		return reason
+	}
+	if len(f.Output) > 0 {
+		return f.Error + ": " + f.Output.String()
+	}
+	return f.Error
+}
@@ -0,0 +1,35 @@
+package ethclient_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/ethclient/fakechain"
+)
+
+func TestCallFrame_FailedCall(t *testing.T) {
+	t.Parallel()
+
+	handled := &ethclient.CallFrame{To: common.HexToAddress("0x03"), Error: "out of gas"}
+	target := &ethclient.CallFrame{
+		To:     common.HexToAddress("0x05"),
+		Error:  "execution reverted",
+		Output: fakechain.NewRevertError("not allowed").Data,
+	}
+	trace := &ethclient.CallFrame{
+		To: common.HexToAddress("0x01"),
+		Calls: []*ethclient.CallFrame{
+			{To: common.HexToAddress("0x02")},
+			{To: common.HexToAddress("0x04"), Error: "execution reverted", Calls: []*ethclient.CallFrame{handled, target}},
+		},
+	}
+
+	require.Equal(t, target, trace.FailedCall())
+	require.Equal(t, "not allowed", trace.FailedCall().RevertReason())
+	require.Equal(t, "out of gas", handled.RevertReason())
+	require.Equal(t, "execution reverted: 0x01", (&ethclient.CallFrame{Error: "execution reverted", Output: []byte{1}}).RevertReason())
+	require.Nil(t, trace.Calls[0].FailedCall())
+}
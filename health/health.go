@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/monitor"
+	"github.com/omni/tokenbridge-monitor/presenter/http/render"
+)
+
+const defaultPingTimeout = 5 * time.Second
+
+type DBPinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type StatusProvider interface {
+	Status() *monitor.BridgeStatus
+}
+
+type Response struct {
+	Status  string                  `json:"status"`
+	Error   string                  `json:"error,omitempty"`
+	Bridges []*monitor.BridgeStatus `json:"bridges,omitempty"`
+}
+
+// Handler serves liveness, readiness and sync status endpoints.
+type Handler struct {
+	logger logging.Logger
+	db     DBPinger
+
+	mu       sync.RWMutex
+	monitors []StatusProvider
+}
+
+func NewHandler(logger logging.Logger, db DBPinger) *Handler {
+	return &Handler{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// SetMonitors replaces the list of bridge monitors reported by the handler.
+// Until it is called, the service is not considered ready.
+func (h *Handler) SetMonitors(monitors []StatusProvider) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.monitors = monitors
+}
+
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", h.Healthz)
+	mux.HandleFunc("/readyz", h.Readyz)
+	mux.HandleFunc("/status", h.Status)
+}
+
+func (h *Handler) statuses() ([]*monitor.BridgeStatus, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	res := make([]*monitor.BridgeStatus, len(h.monitors))
+	for i, m := range h.monitors {
+		res[i] = m.Status()
+	}
+	return res, h.monitors != nil
+}
+
+// Healthz reports that the process is running and the database is reachable.
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultPingTimeout)
+	defer cancel()
+
+	if err := h.db.PingContext(ctx); err != nil {
+		h.logger.WithError(err).Warn("health check failed, database is unreachable")
+		render.JSON(w, r, http.StatusServiceUnavailable, &Response{Status: "error", Error: "database is unreachable"})
+		return
+	}
+	render.JSON(w, r, http.StatusOK, &Response{Status: "ok"})
+}
+
+// Readyz reports that all enabled bridges are synced and their chain heads are fresh.
+// Not ready bridges are listed in the response.
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	statuses, initialized := h.statuses()
+	if !initialized {
+		render.JSON(w, r, http.StatusServiceUnavailable, &Response{Status: "error", Error: "monitors are not initialized"})
+		return
+	}
+
+	var notReady []*monitor.BridgeStatus
+	for _, status := range statuses {
+		if !status.IsReady() {
+			notReady = append(notReady, status)
+		}
+	}
+	if len(notReady) > 0 {
+		render.JSON(w, r, http.StatusServiceUnavailable, &Response{Status: "error", Error: "some bridges are not ready", Bridges: notReady})
+		return
+	}
+	render.JSON(w, r, http.StatusOK, &Response{Status: "ok"})
+}
+
+// Status reports indexing progress of all bridge contracts.
+func (h *Handler) Status(w http.ResponseWriter, r *http.Request) {
+	statuses, _ := h.statuses()
+	render.JSON(w, r, http.StatusOK, &Response{Status: "ok", Bridges: statuses})
+}
@@ -0,0 +1,63 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/health"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/monitor"
+)
+
+var errUnreachable = errors.New("unreachable")
+
+type pinger struct {
+	err error
+}
+
+func (p pinger) PingContext(context.Context) error {
+	return p.err
+}
+
+type statusProvider monitor.BridgeStatus
+
+func (s *statusProvider) Status() *monitor.BridgeStatus {
+	return (*monitor.BridgeStatus)(s)
+}
+
+func serve(h *health.Handler, path string) int {
+	mux := http.NewServeMux()
+	h.Register(mux)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w.Code
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := logging.New()
+
+	require.Equal(t, http.StatusOK, serve(health.NewHandler(logger, pinger{}), "/healthz"))
+	require.Equal(t, http.StatusServiceUnavailable, serve(health.NewHandler(logger, pinger{errUnreachable}), "/healthz"))
+
+	h := health.NewHandler(logger, pinger{})
+	require.Equal(t, http.StatusServiceUnavailable, serve(h, "/readyz"))
+
+	ready := &monitor.ContractStatus{Synced: true, HeadFresh: true}
+	stale := &monitor.ContractStatus{Synced: true, HeadFresh: false}
+	h.SetMonitors([]health.StatusProvider{&statusProvider{BridgeID: "a", Home: ready, Foreign: ready}})
+	require.Equal(t, http.StatusOK, serve(h, "/readyz"))
+
+	h.SetMonitors([]health.StatusProvider{
+		&statusProvider{BridgeID: "a", Home: ready, Foreign: ready},
+		&statusProvider{BridgeID: "b", Home: ready, Foreign: stale},
+	})
+	require.Equal(t, http.StatusServiceUnavailable, serve(h, "/readyz"))
+	require.Equal(t, http.StatusOK, serve(h, "/status"))
+}
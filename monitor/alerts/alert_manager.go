@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/omni/tokenbridge-monitor/config"
 	"github.com/omni/tokenbridge-monitor/db"
 	"github.com/omni/tokenbridge-monitor/logging"
@@ -78,6 +80,13 @@ func NewAlertManager(logger logging.Logger, db *db.DB, cfg *config.BridgeConfig)
 				Func:     provider.FindFailedInformationRequests,
 				Metric:   NewAlertFailedInformationRequest(cfg.ID),
 			}
+		case "failed_information_callback":
+			jobs[name] = &Job{
+				Interval: time.Minute * 5,
+				Timeout:  time.Second * 20,
+				Func:     provider.FindFailedInformationCallbacks,
+				Metric:   NewAlertFailedInformationCallback(cfg.ID),
+			}
 		case "different_information_signatures":
 			jobs[name] = &Job{
 				Interval: time.Minute * 5,
@@ -106,6 +115,34 @@ func NewAlertManager(logger logging.Logger, db *db.DB, cfg *config.BridgeConfig)
 				Func:     provider.FindStuckErcToNativeMessages,
 				Metric:   NewAlertStuckErcToNativeMessageConfirmation(cfg.ID),
 			}
+		case "failed_erc_to_native_message_execution":
+			jobs[name] = &Job{
+				Interval: time.Minute * 5,
+				Timeout:  time.Second * 20,
+				Func:     provider.FindFailedErcToNativeExecutions,
+				Metric:   NewAlertFailedErcToNativeMessageExecution(cfg.ID),
+			}
+		case "unknown_collected_signature":
+			jobs[name] = &Job{
+				Interval: time.Minute * 5,
+				Timeout:  time.Second * 20,
+				Func:     provider.FindUnknownCollectedSignatures,
+				Metric:   NewAlertUnknownCollectedSignature(cfg.ID),
+			}
+		case "invalid_collected_message":
+			jobs[name] = &Job{
+				Interval: time.Minute * 5,
+				Timeout:  time.Second * 20,
+				Func:     provider.FindInvalidCollectedMessages,
+				Metric:   NewAlertInvalidCollectedMessage(cfg.ID),
+			}
+		case "predicted_message_failure":
+			jobs[name] = &Job{
+				Interval: time.Minute * 5,
+				Timeout:  time.Second * 20,
+				Func:     provider.FindPredictedMessageFailures,
+				Metric:   NewAlertPredictedMessageFailure(cfg.ID),
+			}
 		case "last_validator_activity":
 			jobs[name] = &Job{
 				Interval: time.Minute * 10,
@@ -114,6 +151,7 @@ func NewAlertManager(logger logging.Logger, db *db.DB, cfg *config.BridgeConfig)
 				Metric:   NewAlertLastValidatorActivity(cfg.ID),
 			}
 		default:
+			(&AlertManager{jobs: jobs}).UnregisterMetrics()
 			return nil, fmt.Errorf("unknown alert type %q: %w", name, config.ErrInvalidConfig)
 		}
 		jobs[name].Params = &AlertJobParams{
@@ -153,3 +191,21 @@ func (m *AlertManager) Start(ctx context.Context, isSynced func() bool) {
 		go job.Start(ctx, isSynced)
 	}
 }
+
+// RegisterMetrics registers alert metrics of all jobs, previously unregistered by UnregisterMetrics.
+func (m *AlertManager) RegisterMetrics() error {
+	for name, job := range m.jobs {
+		if err := prometheus.Register(job.Metric); err != nil {
+			return fmt.Errorf("can't register metric for alert %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// UnregisterMetrics removes alert metrics of all jobs, so that the same alerts can be registered again
+// by the new alert manager.
+func (m *AlertManager) UnregisterMetrics() {
+	for _, job := range m.jobs {
+		prometheus.Unregister(job.Metric)
+	}
+}
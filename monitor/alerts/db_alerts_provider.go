@@ -141,42 +141,22 @@ func (p *DBAlertsProvider) FindStuckMessages(ctx context.Context, params *AlertJ
 		SELECT l.chain_id,
 		       l.block_number,
 		       l.transaction_hash,
-		       sm.msg_hash,
-		       count(s.log_id) as count,
-		       EXTRACT(EPOCH FROM now() - ts.timestamp)::int as age
-		FROM sent_messages sm
+		       ms.msg_hash,
+		       ms.signatures as count,
+		       EXTRACT(EPOCH FROM now() - ms.first_event_at)::int as age
+		FROM message_status ms
+		         JOIN messages m on m.bridge_id = ms.bridge_id AND m.msg_hash = ms.msg_hash
+		         JOIN sent_messages sm on sm.bridge_id = ms.bridge_id AND sm.msg_hash = ms.msg_hash
 		         JOIN logs l on l.id = sm.log_id
-		         JOIN block_timestamps ts on ts.chain_id = l.chain_id AND ts.block_number = l.block_number
-		         JOIN messages m on sm.bridge_id = m.bridge_id AND m.msg_hash = sm.msg_hash
-		         LEFT JOIN signed_messages s on s.bridge_id = m.bridge_id AND m.msg_hash = s.msg_hash
-		         LEFT JOIN collected_messages cm on m.bridge_id = cm.bridge_id AND cm.msg_hash = m.msg_hash
-		         LEFT JOIN executed_messages em on m.bridge_id = em.bridge_id AND em.message_id = m.message_id
-		WHERE m.direction::direction_enum = 'home_to_foreign'
+		WHERE ms.bridge_id = $1
+		  AND ms.state IN ('pending', 'signed', 'collected')
 		  AND (
-		    cm.log_id IS NULL OR
-		    (em.log_id IS NULL AND m.data_type = 0 AND m.sender = ANY($4))
-		  )
-		  AND sm.bridge_id = $1
-		  AND l.block_number >= $2
-		GROUP BY sm.log_id, l.id, ts.timestamp
-		UNION
-		SELECT l.chain_id,
-		       l.block_number,
-		       l.transaction_hash,
-		       sm.msg_hash,
-		       count(s.log_id) as count,
-		       EXTRACT(EPOCH FROM now() - ts.timestamp)::int as age
-		FROM sent_messages sm
-		         JOIN logs l on l.id = sm.log_id
-		         JOIN block_timestamps ts on ts.chain_id = l.chain_id AND ts.block_number = l.block_number
-		         JOIN messages m on sm.bridge_id = m.bridge_id AND m.msg_hash = sm.msg_hash
-		         LEFT JOIN signed_messages s on s.bridge_id = m.bridge_id AND m.msg_hash = s.msg_hash
-		         LEFT JOIN executed_messages em on m.bridge_id = em.bridge_id AND em.message_id = m.message_id
-		WHERE m.direction::direction_enum = 'foreign_to_home'
-		  AND em.log_id IS NULL
-		  AND sm.bridge_id = $1
-		  AND l.block_number >= $3
-		GROUP BY sm.log_id, l.id, ts.timestamp`
+		    (
+		      ms.direction::direction_enum = 'home_to_foreign' AND l.block_number >= $2 AND
+		      (ms.state != 'collected' OR (m.data_type = 0 AND m.sender = ANY($4)))
+		    ) OR
+		    (ms.direction::direction_enum = 'foreign_to_home' AND l.block_number >= $3)
+		  )`
 	res := make([]StuckMessage, 0, 5)
 	var whitelisted pq.ByteaArray
 	for _, addr := range params.HomeWhitelistedSenders {
@@ -190,16 +170,21 @@ func (p *DBAlertsProvider) FindStuckMessages(ctx context.Context, params *AlertJ
 }
 
 type FailedExecution struct {
-	ChainID         string         `db:"chain_id" json:"chain_id"`
-	BlockNumber     uint64         `db:"block_number" json:"block_number,string"`
-	Age             time.Duration  `db:"age" json:"_value,string"`
-	TransactionHash common.Hash    `db:"transaction_hash" json:"tx_hash"`
-	Sender          common.Address `db:"sender" json:"sender"`
-	Executor        common.Address `db:"executor" json:"executor"`
+	ChainID          string         `db:"chain_id" json:"chain_id"`
+	BlockNumber      uint64         `db:"block_number" json:"block_number,string"`
+	Age              time.Duration  `db:"age" json:"_value,string"`
+	TransactionHash  common.Hash    `db:"transaction_hash" json:"tx_hash"`
+	Sender           common.Address `db:"sender" json:"sender"`
+	Executor         common.Address `db:"executor" json:"executor"`
+	GasUsed          uint64         `db:"gas_used" json:"gas_used,string"`
+	RevertReason     string         `db:"revert_reason" json:"revert_reason"`
+	FailedCallTarget string         `db:"failed_call_target" json:"failed_call_target"`
 }
 
 func (p *DBAlertsProvider) FindFailedExecutions(ctx context.Context, params *AlertJobParams) (interface{}, error) {
-	q, args, err := sq.Select("l.chain_id", "l.block_number", "l.transaction_hash", "m.sender", "m.executor", "EXTRACT(EPOCH FROM now() - bt.timestamp)::int as age").
+	q, args, err := sq.Select("l.chain_id", "l.block_number", "l.transaction_hash", "m.sender", "m.executor", "EXTRACT(EPOCH FROM now() - bt.timestamp)::int as age",
+		"COALESCE(em.gas_used, 0) as gas_used", "COALESCE(em.revert_reason, '') as revert_reason",
+		"COALESCE('0x' || encode(em.failed_call_target, 'hex'), '') as failed_call_target").
 		From("messages m").
 		Join("executed_messages em on m.bridge_id = em.bridge_id AND em.message_id = m.message_id").
 		Join("logs l ON l.id = em.log_id").
@@ -285,11 +270,7 @@ func (p *DBAlertsProvider) FindFailedInformationRequests(ctx context.Context, pa
 		Join("executed_information_requests er on r.bridge_id = er.bridge_id AND er.message_id = r.message_id").
 		Join("logs l ON l.id = er.log_id").
 		Join("block_timestamps bt on bt.chain_id = l.chain_id AND bt.block_number = l.block_number").
-		Where(sq.Or{
-			sq.Eq{"er.status": false},
-			sq.Eq{"er.callback_status": false},
-		}).
-		Where(sq.Eq{"er.bridge_id": params.Bridge}).
+		Where(sq.Eq{"er.status": false, "er.bridge_id": params.Bridge}).
 		Where(sq.And{
 			sq.Eq{"l.chain_id": params.HomeChainID},
 			sq.GtOrEq{"l.block_number": params.HomeStartBlockNumber},
@@ -307,6 +288,40 @@ func (p *DBAlertsProvider) FindFailedInformationRequests(ctx context.Context, pa
 	return res, nil
 }
 
+type FailedInformationCallback struct {
+	ChainID         string         `db:"chain_id" json:"chain_id"`
+	BlockNumber     uint64         `db:"block_number" json:"block_number,string"`
+	Age             time.Duration  `db:"age" json:"_value,string"`
+	TransactionHash common.Hash    `db:"transaction_hash" json:"tx_hash"`
+	MessageID       common.Hash    `db:"message_id" json:"message_id"`
+	Sender          common.Address `db:"sender" json:"sender"`
+	Executor        common.Address `db:"executor" json:"executor"`
+}
+
+func (p *DBAlertsProvider) FindFailedInformationCallbacks(ctx context.Context, params *AlertJobParams) (interface{}, error) {
+	q, args, err := sq.Select("l.chain_id", "l.block_number", "l.transaction_hash", "r.message_id", "r.sender", "r.executor", "EXTRACT(EPOCH FROM now() - bt.timestamp)::int as age").
+		From("information_requests r").
+		Join("executed_information_requests er on r.bridge_id = er.bridge_id AND er.message_id = r.message_id").
+		Join("logs l ON l.id = er.log_id").
+		Join("block_timestamps bt on bt.chain_id = l.chain_id AND bt.block_number = l.block_number").
+		Where(sq.Eq{"er.status": true, "er.callback_status": false, "er.bridge_id": params.Bridge}).
+		Where(sq.And{
+			sq.Eq{"l.chain_id": params.HomeChainID},
+			sq.GtOrEq{"l.block_number": params.HomeStartBlockNumber},
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	res := make([]FailedInformationCallback, 0, 5)
+	err = p.db.SelectContext(ctx, &res, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't select alerts: %w", err)
+	}
+	return res, nil
+}
+
 type DifferentInformationSignature struct {
 	ChainID         string        `db:"chain_id" json:"chain_id"`
 	BlockNumber     uint64        `db:"block_number" json:"block_number,string"`
@@ -503,47 +518,181 @@ func (p *DBAlertsProvider) FindStuckErcToNativeMessages(ctx context.Context, par
 		SELECT l.chain_id,
 		       l.block_number,
 		       l.transaction_hash,
-		       sm.msg_hash,
-		       count(s.log_id) as count,
-		       EXTRACT(EPOCH FROM now() - ts.timestamp)::int as age,
+		       ms.msg_hash,
+		       ms.signatures as count,
+		       EXTRACT(EPOCH FROM now() - ms.first_event_at)::int as age,
 		       m.sender,
 		       m.receiver,
 		       m.value / 1e18 as value
-		FROM sent_messages sm
+		FROM message_status ms
+		         JOIN erc_to_native_messages m on m.bridge_id = ms.bridge_id AND m.msg_hash = ms.msg_hash
+		         JOIN sent_messages sm on sm.bridge_id = ms.bridge_id AND sm.msg_hash = ms.msg_hash
 		         JOIN logs l on l.id = sm.log_id
-		         JOIN block_timestamps ts on ts.chain_id = l.chain_id AND ts.block_number = l.block_number
-		         JOIN erc_to_native_messages m on sm.bridge_id = m.bridge_id AND m.msg_hash = sm.msg_hash
-		         LEFT JOIN signed_messages s on s.bridge_id = m.bridge_id AND m.msg_hash = s.msg_hash
-		         LEFT JOIN collected_messages cm on m.bridge_id = cm.bridge_id AND cm.msg_hash = m.msg_hash
-		WHERE m.direction::direction_enum = 'home_to_foreign'
-		  AND cm.log_id IS NULL
-		  AND sm.bridge_id = $1
-		  AND l.block_number >= $2
-		GROUP BY sm.log_id, l.id, ts.timestamp, m.id
-		UNION
+		WHERE ms.bridge_id = $1
+		  AND ms.state IN ('pending', 'signed', 'collected')
+		  AND (
+		    (ms.direction::direction_enum = 'home_to_foreign' AND l.block_number >= $2 AND ms.state != 'collected') OR
+		    (ms.direction::direction_enum = 'foreign_to_home' AND l.block_number >= $3 AND m.value > 0)
+		  )`
+	res := make([]StuckErcToNativeMessage, 0, 5)
+	err := p.db.SelectContext(ctx, &res, query, params.Bridge, params.HomeStartBlockNumber, params.ForeignStartBlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("can't select alerts: %w", err)
+	}
+	return res, nil
+}
+
+type FailedErcToNativeExecution struct {
+	ChainID         string         `db:"chain_id" json:"chain_id"`
+	BlockNumber     uint64         `db:"block_number" json:"block_number,string"`
+	Age             time.Duration  `db:"age" json:"_value,string"`
+	TransactionHash common.Hash    `db:"transaction_hash" json:"tx_hash"`
+	MsgHash         common.Hash    `db:"msg_hash" json:"msg_hash"`
+	Sender          common.Address `db:"sender" json:"sender"`
+	Receiver        common.Address `db:"receiver" json:"receiver"`
+	Value           string         `db:"value" json:"value"`
+}
+
+func (p *DBAlertsProvider) FindFailedErcToNativeExecutions(ctx context.Context, params *AlertJobParams) (interface{}, error) {
+	q, args, err := sq.Select("l.chain_id", "l.block_number", "l.transaction_hash", "m.msg_hash", "m.sender", "m.receiver", "m.value / 1e18 as value", "EXTRACT(EPOCH FROM now() - bt.timestamp)::int as age").
+		From("erc_to_native_messages m").
+		Join("executed_messages em on m.bridge_id = em.bridge_id AND em.message_id = m.msg_hash").
+		Join("logs l ON l.id = em.log_id").
+		Join("block_timestamps bt on bt.chain_id = l.chain_id AND bt.block_number = l.block_number").
+		Where(sq.Eq{"em.status": false, "em.bridge_id": params.Bridge}).
+		Where(sq.Or{
+			sq.And{
+				sq.Eq{"l.chain_id": params.HomeChainID},
+				sq.GtOrEq{"l.block_number": params.HomeStartBlockNumber},
+			},
+			sq.And{
+				sq.Eq{"l.chain_id": params.ForeignChainID},
+				sq.GtOrEq{"l.block_number": params.ForeignStartBlockNumber},
+			},
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	res := make([]FailedErcToNativeExecution, 0, 5)
+	err = p.db.SelectContext(ctx, &res, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't select alerts: %w", err)
+	}
+	return res, nil
+}
+
+type UnknownCollectedSignature struct {
+	ChainID         string         `db:"chain_id" json:"chain_id"`
+	BlockNumber     uint64         `db:"block_number" json:"block_number,string"`
+	Age             time.Duration  `db:"age" json:"_value,string"`
+	TransactionHash common.Hash    `db:"transaction_hash" json:"tx_hash"`
+	MsgHash         common.Hash    `db:"msg_hash" json:"msg_hash"`
+	Signer          common.Address `db:"signer" json:"signer"`
+}
+
+// FindUnknownCollectedSignatures finds collected signatures, which signer was not an active bridge validator
+// at the time when signatures were collected, unrecoverable signatures have a zero signer address.
+func (p *DBAlertsProvider) FindUnknownCollectedSignatures(ctx context.Context, params *AlertJobParams) (interface{}, error) {
+	query := `
 		SELECT l.chain_id,
 		       l.block_number,
 		       l.transaction_hash,
-		       sm.msg_hash,
-		       count(s.log_id) as count,
-		       EXTRACT(EPOCH FROM now() - ts.timestamp)::int as age,
-		       m.sender,
-		       m.receiver,
-		       m.value / 1e18 as value
-		FROM sent_messages sm
-		         JOIN logs l on l.id = sm.log_id
-		         JOIN block_timestamps ts on ts.chain_id = l.chain_id AND ts.block_number = l.block_number
-		         JOIN erc_to_native_messages m on sm.bridge_id = m.bridge_id AND m.msg_hash = sm.msg_hash
-		         LEFT JOIN signed_messages s on s.bridge_id = m.bridge_id AND m.msg_hash = s.msg_hash
-		         LEFT JOIN executed_messages em on m.bridge_id = em.bridge_id AND em.message_id = m.msg_hash
-		WHERE m.direction::direction_enum = 'foreign_to_home'
-		  AND em.log_id IS NULL
-		  AND sm.bridge_id = $1
+		       cs.msg_hash,
+		       cs.signer,
+		       EXTRACT(EPOCH FROM now() - bt.timestamp)::int as age
+		FROM collected_signatures cs
+		         JOIN logs l ON l.id = cs.log_id
+		         JOIN block_timestamps bt ON bt.chain_id = l.chain_id AND bt.block_number = l.block_number
+		WHERE cs.bridge_id = $1
+		  AND l.chain_id = $2
 		  AND l.block_number >= $3
-		  AND m.value > 0
-		GROUP BY sm.log_id, l.id, ts.timestamp, m.id`
-	res := make([]StuckErcToNativeMessage, 0, 5)
-	err := p.db.SelectContext(ctx, &res, query, params.Bridge, params.HomeStartBlockNumber, params.ForeignStartBlockNumber)
+		  AND NOT EXISTS(SELECT 1
+		                 FROM bridge_validators v
+		                          JOIN logs vl ON vl.id = v.log_id
+		                          LEFT JOIN logs rl ON rl.id = v.removed_log_id
+		                 WHERE v.bridge_id = cs.bridge_id
+		                   AND v.chain_id = l.chain_id
+		                   AND v.address = cs.signer
+		                   AND vl.block_number <= l.block_number
+		                   AND (rl.id IS NULL OR rl.block_number >= l.block_number))`
+	res := make([]UnknownCollectedSignature, 0, 5)
+	err := p.db.SelectContext(ctx, &res, query, params.Bridge, params.HomeChainID, params.HomeStartBlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("can't select alerts: %w", err)
+	}
+	return res, nil
+}
+
+type InvalidCollectedMessage struct {
+	ChainID         string         `db:"chain_id" json:"chain_id"`
+	BlockNumber     uint64         `db:"block_number" json:"block_number,string"`
+	Age             time.Duration  `db:"age" json:"_value,string"`
+	TransactionHash common.Hash    `db:"transaction_hash" json:"tx_hash"`
+	MsgHash         common.Hash    `db:"msg_hash" json:"msg_hash"`
+	Relayer         common.Address `db:"relayer" json:"relayer"`
+}
+
+// FindInvalidCollectedMessages finds collected messages, which payload stored in the home bridge contract
+// does not match the collected message hash or the originally sent message.
+func (p *DBAlertsProvider) FindInvalidCollectedMessages(ctx context.Context, params *AlertJobParams) (interface{}, error) {
+	q, args, err := sq.Select("l.chain_id", "l.block_number", "l.transaction_hash", "cm.msg_hash", "cm.responsible_signer as relayer", "EXTRACT(EPOCH FROM now() - bt.timestamp)::int as age").
+		From("collected_messages cm").
+		Join("logs l ON l.id = cm.log_id").
+		Join("block_timestamps bt on bt.chain_id = l.chain_id AND bt.block_number = l.block_number").
+		Where(sq.Eq{"cm.message_valid": false, "cm.bridge_id": params.Bridge, "l.chain_id": params.HomeChainID}).
+		Where(sq.GtOrEq{"l.block_number": params.HomeStartBlockNumber}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	res := make([]InvalidCollectedMessage, 0, 5)
+	err = p.db.SelectContext(ctx, &res, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't select alerts: %w", err)
+	}
+	return res, nil
+}
+
+type PredictedMessageFailure struct {
+	ChainID         string         `db:"chain_id" json:"chain_id"`
+	BlockNumber     uint64         `db:"block_number" json:"block_number,string"`
+	Age             time.Duration  `db:"age" json:"_value,string"`
+	TransactionHash common.Hash    `db:"transaction_hash" json:"tx_hash"`
+	MsgHash         common.Hash    `db:"msg_hash" json:"msg_hash"`
+	Sender          common.Address `db:"sender" json:"sender"`
+	Executor        common.Address `db:"executor" json:"executor"`
+	Reason          string         `db:"reason" json:"reason"`
+}
+
+// FindPredictedMessageFailures finds pending AMB messages, which execution has failed in the latest simulation.
+func (p *DBAlertsProvider) FindPredictedMessageFailures(ctx context.Context, params *AlertJobParams) (interface{}, error) {
+	q, args, err := sq.Select("l.chain_id", "l.block_number", "l.transaction_hash", "m.msg_hash", "m.sender", "m.executor", "COALESCE(m.simulation_error, '') as reason", "EXTRACT(EPOCH FROM now() - bt.timestamp)::int as age").
+		From("messages m").
+		Join("message_status ms ON ms.bridge_id = m.bridge_id AND ms.msg_hash = m.msg_hash").
+		Join("sent_messages sm ON sm.bridge_id = m.bridge_id AND sm.msg_hash = m.msg_hash").
+		Join("logs l ON l.id = sm.log_id").
+		Join("block_timestamps bt on bt.chain_id = l.chain_id AND bt.block_number = l.block_number").
+		Where(sq.Eq{"m.simulation_success": false, "ms.execution_status": nil, "m.bridge_id": params.Bridge}).
+		Where(sq.Or{
+			sq.And{
+				sq.Eq{"l.chain_id": params.HomeChainID},
+				sq.GtOrEq{"l.block_number": params.HomeStartBlockNumber},
+			},
+			sq.And{
+				sq.Eq{"l.chain_id": params.ForeignChainID},
+				sq.GtOrEq{"l.block_number": params.ForeignStartBlockNumber},
+			},
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	res := make([]PredictedMessageFailure, 0, 5)
+	err = p.db.SelectContext(ctx, &res, q, args...)
 	if err != nil {
 		return nil, fmt.Errorf("can't select alerts: %w", err)
 	}
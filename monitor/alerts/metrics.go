@@ -40,7 +40,7 @@ var (
 			Name:        "failed_message_execution",
 			Help:        "Shows AMB message which execution has failed.",
 			ConstLabels: prometheus.Labels{"bridge_id": bridge},
-		}, []string{"chain_id", "block_number", "tx_hash", "sender", "executor"})
+		}, []string{"chain_id", "block_number", "tx_hash", "sender", "executor", "gas_used", "revert_reason", "failed_call_target"})
 	}
 	NewAlertUnknownInformationSignature = func(bridge string) *prometheus.GaugeVec {
 		return promauto.NewGaugeVec(prometheus.GaugeOpts{
@@ -74,10 +74,19 @@ var (
 			Namespace:   "alert",
 			Subsystem:   "monitor",
 			Name:        "failed_information_request",
-			Help:        "Shows AMB information requests which execution or callback has failed.",
+			Help:        "Shows AMB information requests which execution has failed.",
 			ConstLabels: prometheus.Labels{"bridge_id": bridge},
 		}, []string{"chain_id", "block_number", "tx_hash", "sender", "executor", "status", "callback_status"})
 	}
+	NewAlertFailedInformationCallback = func(bridge string) *prometheus.GaugeVec {
+		return promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   "alert",
+			Subsystem:   "monitor",
+			Name:        "failed_information_callback",
+			Help:        "Shows AMB information requests which were executed, but which callback has failed.",
+			ConstLabels: prometheus.Labels{"bridge_id": bridge},
+		}, []string{"chain_id", "block_number", "tx_hash", "message_id", "sender", "executor"})
+	}
 	NewAlertDifferentInformationSignatures = func(bridge string) *prometheus.GaugeVec {
 		return promauto.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace:   "alert",
@@ -114,6 +123,42 @@ var (
 			ConstLabels: prometheus.Labels{"bridge_id": bridge},
 		}, []string{"chain_id", "block_number", "tx_hash", "msg_hash", "count", "sender", "receiver", "value"})
 	}
+	NewAlertFailedErcToNativeMessageExecution = func(bridge string) *prometheus.GaugeVec {
+		return promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   "alert",
+			Subsystem:   "monitor",
+			Name:        "failed_erc_to_native_message_execution",
+			Help:        "Shows ERC_TO_NATIVE message which execution has failed or did not release the bridged value.",
+			ConstLabels: prometheus.Labels{"bridge_id": bridge},
+		}, []string{"chain_id", "block_number", "tx_hash", "msg_hash", "sender", "receiver", "value"})
+	}
+	NewAlertUnknownCollectedSignature = func(bridge string) *prometheus.GaugeVec {
+		return promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   "alert",
+			Subsystem:   "monitor",
+			Name:        "unknown_collected_signature",
+			Help:        "Shows collected home-to-foreign message signatures, which were not made by the active bridge validators.",
+			ConstLabels: prometheus.Labels{"bridge_id": bridge},
+		}, []string{"chain_id", "block_number", "tx_hash", "msg_hash", "signer"})
+	}
+	NewAlertInvalidCollectedMessage = func(bridge string) *prometheus.GaugeVec {
+		return promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   "alert",
+			Subsystem:   "monitor",
+			Name:        "invalid_collected_message",
+			Help:        "Shows collected home-to-foreign messages, which payload in the home bridge contract does not match the sent message.",
+			ConstLabels: prometheus.Labels{"bridge_id": bridge},
+		}, []string{"chain_id", "block_number", "tx_hash", "msg_hash", "relayer"})
+	}
+	NewAlertPredictedMessageFailure = func(bridge string) *prometheus.GaugeVec {
+		return promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   "alert",
+			Subsystem:   "monitor",
+			Name:        "predicted_message_failure",
+			Help:        "Shows pending AMB messages, which execution has failed in the latest eth_call simulation.",
+			ConstLabels: prometheus.Labels{"bridge_id": bridge},
+		}, []string{"chain_id", "block_number", "tx_hash", "msg_hash", "sender", "executor", "reason"})
+	}
 	NewAlertLastValidatorActivity = func(bridge string) *prometheus.GaugeVec {
 		return promauto.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace:   "alert",
@@ -0,0 +1,181 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/utils"
+)
+
+const defaultBalanceCheckInterval = time.Minute
+
+const (
+	BalanceRoleValidator = "validator"
+	BalanceRoleRelayer   = "relayer"
+)
+
+// BalanceMonitor periodically exports native coin balances of the active bridge validators
+// and configured relayers on both sides of the bridge.
+type BalanceMonitor struct {
+	logger   logging.Logger
+	repo     *repository.Repo
+	cfg      *config.BridgeConfig
+	interval time.Duration
+	sides    []*balanceMonitorSide
+
+	mu           sync.Mutex
+	metricLabels map[string]prometheus.Labels
+
+	// balances are kept separately from the metrics, so that they can be read while the check is in progress
+	balancesMu sync.RWMutex
+	balances   map[string]*big.Int
+}
+
+type balanceMonitorSide struct {
+	cfg    *config.BridgeSideConfig
+	client ethclient.Client
+}
+
+func NewBalanceMonitor(logger logging.Logger, repo *repository.Repo, cfg *config.BridgeConfig, homeClient, foreignClient ethclient.Client) *BalanceMonitor {
+	return &BalanceMonitor{
+		logger:   logger,
+		repo:     repo,
+		cfg:      cfg,
+		interval: defaultBalanceCheckInterval,
+		sides: []*balanceMonitorSide{
+			{cfg: cfg.Home, client: homeClient},
+			{cfg: cfg.Foreign, client: foreignClient},
+		},
+		metricLabels: make(map[string]prometheus.Labels),
+		balances:     make(map[string]*big.Int),
+	}
+}
+
+func (m *BalanceMonitor) Start(ctx context.Context) {
+	m.logger.Info("starting validator balances monitor")
+	for {
+		if err := m.Check(ctx); err != nil {
+			m.logger.WithError(err).Error("can't check validator balances")
+		}
+		if utils.ContextSleep(ctx, m.interval) == nil {
+			return
+		}
+	}
+}
+
+// Check updates balance metrics of all active validators and relayers,
+// balances of the no longer active validators are removed.
+func (m *BalanceMonitor) Check(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seen := make(map[string]bool, len(m.metricLabels))
+	for _, side := range m.sides {
+		chainID := side.cfg.Chain.ChainID
+		if threshold := side.cfg.Chain.ValidatorBalanceThreshold; threshold > 0 {
+			ValidatorBalanceThreshold.WithLabelValues(m.cfg.ID, chainID).Set(threshold)
+		}
+
+		accounts, err := m.findAccounts(ctx, side.cfg)
+		if err != nil {
+			return err
+		}
+		for _, acc := range accounts {
+			balance, err2 := side.client.BalanceAt(ctx, acc.address)
+			if err2 != nil {
+				return fmt.Errorf("can't get balance of %s on chain %s: %w", acc.address, chainID, err2)
+			}
+			labels := prometheus.Labels{
+				"bridge_id": m.cfg.ID,
+				"chain_id":  chainID,
+				"address":   acc.address.String(),
+				"role":      acc.role,
+			}
+			key := chainID + acc.address.String() + acc.role
+			seen[key] = true
+			m.metricLabels[key] = labels
+			ValidatorBalance.With(labels).Set(utils.WeiToEther(balance))
+			m.setBalance(chainID, acc.address, balance)
+		}
+	}
+	for key, labels := range m.metricLabels {
+		if !seen[key] {
+			ValidatorBalance.Delete(labels)
+			delete(m.metricLabels, key)
+		}
+	}
+	m.removeBalances(seen)
+	return nil
+}
+
+func (m *BalanceMonitor) setBalance(chainID string, addr common.Address, balance *big.Int) {
+	m.balancesMu.Lock()
+	defer m.balancesMu.Unlock()
+
+	m.balances[chainID+addr.String()] = balance
+}
+
+// removeBalances removes balances of the accounts, which were not checked during the last check.
+func (m *BalanceMonitor) removeBalances(seen map[string]bool) {
+	m.balancesMu.Lock()
+	defer m.balancesMu.Unlock()
+
+	for key := range m.balances {
+		if !seen[key+BalanceRoleValidator] && !seen[key+BalanceRoleRelayer] {
+			delete(m.balances, key)
+		}
+	}
+}
+
+// Balance returns the native balance of the validator or the relayer, collected during the last check.
+func (m *BalanceMonitor) Balance(chainID string, addr common.Address) (*big.Int, bool) {
+	m.balancesMu.RLock()
+	defer m.balancesMu.RUnlock()
+
+	balance, ok := m.balances[chainID+addr.String()]
+	return balance, ok
+}
+
+type balanceAccount struct {
+	address common.Address
+	role    string
+}
+
+func (m *BalanceMonitor) findAccounts(ctx context.Context, cfg *config.BridgeSideConfig) ([]balanceAccount, error) {
+	validators, err := m.repo.BridgeValidators.FindActiveValidators(ctx, m.cfg.ID, cfg.Chain.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("can't find active validators: %w", err)
+	}
+	res := make([]balanceAccount, 0, len(validators)+len(cfg.RelayerAddresses))
+	for _, v := range validators {
+		res = append(res, balanceAccount{v.Address, BalanceRoleValidator})
+	}
+	for _, addr := range cfg.RelayerAddresses {
+		res = append(res, balanceAccount{addr, BalanceRoleRelayer})
+	}
+	return res, nil
+}
+
+// UnregisterMetrics removes balance metrics of the stopped monitor.
+func (m *BalanceMonitor) UnregisterMetrics() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, labels := range m.metricLabels {
+		ValidatorBalance.Delete(labels)
+		delete(m.metricLabels, key)
+	}
+	for _, side := range m.sides {
+		ValidatorBalanceThreshold.DeleteLabelValues(m.cfg.ID, side.cfg.Chain.ChainID)
+	}
+}
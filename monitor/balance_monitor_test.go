@@ -0,0 +1,56 @@
+package monitor_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/monitor"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
+)
+
+func TestBalanceMonitor_Check(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := newTestBridge(t, config.BridgeModeArbitraryMessage, repository.NewMemoryRepo(memory.NewStore()), nil)
+	relayer := common.HexToAddress("0x5000000000000000000000000000000000000001")
+	b.cfg.Home.Chain.ValidatorBalanceThreshold = 0.5
+	b.cfg.Foreign.RelayerAddresses = []common.Address{relayer}
+
+	validator := &entity.BridgeValidator{LogID: 1, BridgeID: b.cfg.ID, ChainID: b.home.ChainID(), Address: validator1}
+	require.NoError(t, b.repo.BridgeValidators.Ensure(ctx, validator))
+	b.home.SetBalance(validator1, big.NewInt(2e18))
+	b.foreign.SetBalance(relayer, big.NewInt(1e17))
+
+	m := monitor.NewBalanceMonitor(logging.NullLogger(), b.repo, b.cfg, b.home, b.foreign)
+	defer m.UnregisterMetrics()
+	require.NoError(t, m.Check(ctx))
+
+	validatorBalance := monitor.ValidatorBalance.WithLabelValues(b.cfg.ID, b.home.ChainID(), validator1.String(), monitor.BalanceRoleValidator)
+	require.InDelta(t, 2, testutil.ToFloat64(validatorBalance), 1e-9)
+	relayerBalance := monitor.ValidatorBalance.WithLabelValues(b.cfg.ID, b.foreign.ChainID(), relayer.String(), monitor.BalanceRoleRelayer)
+	require.InDelta(t, 0.1, testutil.ToFloat64(relayerBalance), 1e-9)
+	threshold := monitor.ValidatorBalanceThreshold.WithLabelValues(b.cfg.ID, b.home.ChainID())
+	require.InDelta(t, 0.5, testutil.ToFloat64(threshold), 1e-9)
+	balance, ok := m.Balance(b.home.ChainID(), validator1)
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(2e18), balance)
+
+	// balances of removed validators are no longer exported
+	removedLogID := uint(2)
+	validator.RemovedLogID = &removedLogID
+	require.NoError(t, b.repo.BridgeValidators.Ensure(ctx, validator))
+	require.NoError(t, m.Check(ctx))
+	require.False(t, monitor.ValidatorBalance.DeleteLabelValues(b.cfg.ID, b.home.ChainID(), validator1.String(), monitor.BalanceRoleValidator))
+	_, ok = m.Balance(b.home.ChainID(), validator1)
+	require.False(t, ok)
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/omni/tokenbridge-monitor/config"
 	"github.com/omni/tokenbridge-monitor/contract"
+	"github.com/omni/tokenbridge-monitor/contract/bridgeabi"
 	"github.com/omni/tokenbridge-monitor/db"
 	"github.com/omni/tokenbridge-monitor/entity"
 	"github.com/omni/tokenbridge-monitor/ethclient"
@@ -30,23 +31,39 @@ const (
 	defaultBlockRangesChanCap  = 10
 	defaultLogsChanCap         = 200
 	defaultEventHandlersMapCap = 20
+	// defaultHeadStaleFactor is the number of missed block index intervals after which chain head is considered stale.
+	defaultHeadStaleFactor = 3
 )
 
 var ErrIncompatibleABI = errors.New("incompatible ABI")
 
 type ContractMonitor struct {
-	bridgeCfg            *config.BridgeConfig
-	cfg                  *config.BridgeSideConfig
-	logger               logging.Logger
-	repo                 *repository.Repo
-	client               ethclient.Client
-	logsCursor           *entity.LogsCursor
-	blocksRangeChan      chan *BlocksRange
-	logsChan             chan *LogsBatch
-	contract             *contract.BridgeContract
-	eventHandlers        map[string]EventHandler
-	headBlock            uint
-	isSynced             bool
+	bridgeCfg        *config.BridgeConfig
+	cfg              *config.BridgeSideConfig
+	logger           logging.Logger
+	repo             *repository.Repo
+	client           ethclient.Client
+	logsCursor       *entity.LogsCursor
+	blocksRangeChan  chan *BlocksRange
+	logsChan         chan *LogsBatch
+	contract         *contract.BridgeContract
+	eventHandlers    map[string]EventHandler
+	eventPrefetchers map[string]EventHandler
+
+	// wg tracks running fetchers and processor, abortProcessing interrupts the in-flight logs batch on shutdown
+	wg              sync.WaitGroup
+	abortProcessing context.CancelFunc
+
+	// mu guards the logs cursor and the sync status fields below
+	mu                     sync.RWMutex
+	headBlock              uint
+	headUpdatedAt          time.Time
+	isSynced               bool
+	lastProcessedBlockTime time.Time
+	lastRPCError           error
+	lastRPCErrorAt         time.Time
+
+	metricLabels         prometheus.Labels
 	syncedMetric         prometheus.Gauge
 	headBlockMetric      prometheus.Gauge
 	fetchedBlockMetric   prometheus.Gauge
@@ -63,10 +80,13 @@ func NewContractMonitor(ctx context.Context, logger logging.Logger, repo *reposi
 		logger.WithFields(logrus.Fields{
 			"chain_id":                   cfg.Chain.ChainID,
 			"bridge_address":             cfg.Address,
-			"validator_contract_address": cfg.ValidatorContractAddress,
+			"validator_contract_address": addr,
 			"start_block":                cfg.StartBlock,
 		}).Info("obtained validator contract address")
-		cfg.ValidatorContractAddress = addr
+		// shared bridge config is left intact, so that it can be compared with the reloaded one
+		sideCfg := *cfg
+		sideCfg.ValidatorContractAddress = addr
+		cfg = &sideCfg
 	}
 	logsCursor, err := repo.LogsCursors.GetByChainIDAndAddress(ctx, cfg.Chain.ChainID, cfg.Address)
 	if err != nil {
@@ -102,6 +122,8 @@ func NewContractMonitor(ctx context.Context, logger logging.Logger, repo *reposi
 		logsChan:             make(chan *LogsBatch, defaultLogsChanCap),
 		contract:             bridgeContract,
 		eventHandlers:        make(map[string]EventHandler, defaultEventHandlersMapCap),
+		eventPrefetchers:     make(map[string]EventHandler),
+		metricLabels:         commonLabels,
 		syncedMetric:         SyncedContract.With(commonLabels),
 		headBlockMetric:      LatestHeadBlock.With(commonLabels),
 		fetchedBlockMetric:   LatestFetchedBlock.With(commonLabels),
@@ -110,13 +132,71 @@ func NewContractMonitor(ctx context.Context, logger logging.Logger, repo *reposi
 }
 
 func (m *ContractMonitor) IsSynced() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.isSynced
 }
 
+// Status returns a snapshot of the contract indexing progress.
+func (m *ContractMonitor) Status() *ContractStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	status := &ContractStatus{
+		ChainID:        m.cfg.Chain.ChainID,
+		Address:        m.cfg.Address,
+		HeadBlock:      m.headBlock,
+		FetchedBlock:   m.logsCursor.LastFetchedBlock,
+		ProcessedBlock: m.logsCursor.LastProcessedBlock,
+		Synced:         m.isSynced,
+		HeadFresh:      m.isHeadFresh(),
+	}
+	if !m.headUpdatedAt.IsZero() {
+		status.HeadUpdatedAt = &m.headUpdatedAt
+	}
+	if m.headBlock > m.logsCursor.LastProcessedBlock {
+		status.LagBlocks = m.headBlock - m.logsCursor.LastProcessedBlock
+		if !m.lastProcessedBlockTime.IsZero() {
+			status.LagSeconds = uint(time.Since(m.lastProcessedBlockTime).Seconds())
+		}
+	}
+	if m.lastRPCError != nil {
+		status.LastRPCError = m.lastRPCError.Error()
+		status.LastRPCErrorAt = &m.lastRPCErrorAt
+	}
+	return status
+}
+
+// isHeadFresh checks that chain head was successfully requested within the last few block index intervals.
+func (m *ContractMonitor) isHeadFresh() bool {
+	if m.headUpdatedAt.IsZero() {
+		return false
+	}
+	interval := m.cfg.Chain.BlockIndexInterval
+	if m.cfg.Chain.RPC != nil {
+		interval += m.cfg.Chain.RPC.Timeout
+	}
+	return time.Since(m.headUpdatedAt) < defaultHeadStaleFactor*interval
+}
+
+// UnregisterMetrics removes contract metrics of the stopped monitor.
+func (m *ContractMonitor) UnregisterMetrics() {
+	SyncedContract.Delete(m.metricLabels)
+	LatestHeadBlock.Delete(m.metricLabels)
+	LatestFetchedBlock.Delete(m.metricLabels)
+	LatestProcessedBlock.Delete(m.metricLabels)
+}
+
 func (m *ContractMonitor) RegisterEventHandler(event string, handler EventHandler) {
 	m.eventHandlers[event] = handler
 }
 
+// RegisterEventPrefetcher registers the function, which requests RPC data needed by the event handler.
+// Prefetchers are called for the logs batch before its database transaction is started.
+func (m *ContractMonitor) RegisterEventPrefetcher(event string, prefetcher EventHandler) {
+	m.eventPrefetchers[event] = prefetcher
+}
+
 func (m *ContractMonitor) VerifyEventHandlersABI() error {
 	events := m.contract.ABI.AllEvents()
 	for e := range m.eventHandlers {
@@ -127,20 +207,59 @@ func (m *ContractMonitor) VerifyEventHandlersABI() error {
 	return nil
 }
 
+// Start runs block fetcher, logs fetcher and logs processor until the given context is cancelled.
+// Logs batch, which is being processed at the moment of cancellation, is processed till the end,
+// use Shutdown to wait for it.
 func (m *ContractMonitor) Start(ctx context.Context) {
+	m.mu.RLock()
 	lastProcessedBlock := m.logsCursor.LastProcessedBlock
 	lastFetchedBlock := m.logsCursor.LastFetchedBlock
+	m.mu.RUnlock()
 	m.processedBlockMetric.Set(float64(lastProcessedBlock))
 	m.fetchedBlockMetric.Set(float64(lastFetchedBlock))
-	go m.StartBlockFetcher(ctx, lastFetchedBlock+1)
-	go m.StartLogsProcessor(ctx)
-	m.LoadUnprocessedLogs(ctx, lastProcessedBlock+1, lastFetchedBlock)
-	go m.StartLogsFetcher(ctx)
+
+	// processing context is not derived from ctx, so that the current batch is not interrupted
+	processCtx, cancel := context.WithCancel(context.Background())
+	m.abortProcessing = cancel
+	m.wg.Add(3)
+	go func() {
+		defer m.wg.Done()
+		m.StartBlockFetcher(ctx, lastFetchedBlock+1)
+	}()
+	go func() {
+		defer m.wg.Done()
+		defer cancel()
+		m.StartLogsProcessor(ctx, processCtx)
+	}()
+	go func() {
+		defer m.wg.Done()
+		m.LoadUnprocessedLogs(ctx, lastProcessedBlock+1, lastFetchedBlock)
+		m.StartLogsFetcher(ctx)
+	}()
+}
+
+// Shutdown waits until the monitor started by Start stops after its context cancellation.
+// If the given context expires first, processing of the current logs batch is aborted.
+func (m *ContractMonitor) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		if m.abortProcessing != nil {
+			m.abortProcessing()
+		}
+		return fmt.Errorf("logs processing was not finished: %w", ctx.Err())
+	}
 }
 
 //nolint:cyclop
 func (m *ContractMonitor) ProcessBlockRange(ctx context.Context, fromBlock, toBlock uint) error {
-	if toBlock > m.logsCursor.LastProcessedBlock {
+	if toBlock > m.Status().ProcessedBlock {
 		return fmt.Errorf("can't manually process logs further then current lastProcessedBlock: %w", config.ErrInvalidConfig)
 	}
 
@@ -162,7 +281,7 @@ func (m *ContractMonitor) ProcessBlockRange(ctx context.Context, fromBlock, toBl
 	}()
 
 	go m.StartLogsFetcher(ctx)
-	go m.StartLogsProcessor(ctx)
+	go m.StartLogsProcessor(ctx, ctx)
 
 	finishedFetching := false
 	for {
@@ -203,7 +322,7 @@ func (m *ContractMonitor) LoadUnprocessedLogs(ctx context.Context, fromBlock, to
 		if err != nil {
 			m.logger.WithError(err).Error("can't find unprocessed logs in block range")
 		} else {
-			m.submitLogs(logs, toBlock)
+			m.submitLogs(ctx, logs, toBlock)
 			break
 		}
 
@@ -219,6 +338,7 @@ func (m *ContractMonitor) StartBlockFetcher(ctx context.Context, start uint) {
 	for {
 		head, err := m.client.BlockNumber(ctx)
 		if err != nil {
+			m.recordRPCError(err)
 			m.logger.WithError(err).Error("can't fetch latest block number")
 		} else {
 			head -= m.cfg.BlockConfirmations
@@ -230,7 +350,11 @@ func (m *ContractMonitor) StartBlockFetcher(ctx context.Context, start uint) {
 					"from_block": batch.From,
 					"to_block":   batch.To,
 				}).Info("scheduling new block range logs search")
-				m.blocksRangeChan <- batch
+				select {
+				case m.blocksRangeChan <- batch:
+				case <-ctx.Done():
+					return
+				}
 			}
 			start = head + 1
 		}
@@ -254,6 +378,9 @@ func (m *ContractMonitor) StartLogsFetcher(ctx context.Context) {
 			for {
 				err := m.tryToFetchLogs(ctx, blocksRange)
 				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
 					m.logger.WithError(err).WithFields(logrus.Fields{
 						"from_block": blocksRange.From,
 						"to_block":   blocksRange.To,
@@ -282,24 +409,40 @@ func (m *ContractMonitor) buildFilterQueries(blocksRange *BlocksRange) []ethereu
 			if blocksRange.To < token.StartBlock || blocksRange.From > token.EndBlock {
 				continue
 			}
-			q = ethereum.FilterQuery{
-				FromBlock: big.NewInt(int64(blocksRange.From)),
-				ToBlock:   big.NewInt(int64(blocksRange.To)),
-				Addresses: []common.Address{token.Address},
-				Topics:    [][]common.Hash{{}, {}, {m.cfg.Address.Hash()}},
+			fromBlock, toBlock := blocksRange.From, blocksRange.To
+			if token.StartBlock > fromBlock {
+				fromBlock = token.StartBlock
 			}
-			if token.StartBlock > blocksRange.From {
-				q.FromBlock = big.NewInt(int64(token.StartBlock))
+			if token.EndBlock < toBlock {
+				toBlock = token.EndBlock
 			}
-			if token.EndBlock < blocksRange.To {
-				q.ToBlock = big.NewInt(int64(token.EndBlock))
+			// transfers to the bridge and from the bridge
+			transfer, bridge := bridgeabi.ErcToNativeTransferEventSignature, m.cfg.Address.Hash()
+			for _, topics := range [][][]common.Hash{{{transfer}, {}, {bridge}}, {{transfer}, {bridge}}} {
+				queries = append(queries, ethereum.FilterQuery{
+					FromBlock: big.NewInt(int64(fromBlock)),
+					ToBlock:   big.NewInt(int64(toBlock)),
+					Addresses: []common.Address{token.Address},
+					Topics:    topics,
+				})
 			}
-			queries = append(queries, q)
 		}
 	}
 	return queries
 }
 
+// uniqueLogs removes duplicates from the sorted logs.
+func uniqueLogs(logs []*entity.Log) []*entity.Log {
+	res := logs[:0]
+	for _, log := range logs {
+		if len(res) > 0 && log.BlockNumber == res[len(res)-1].BlockNumber && log.LogIndex == res[len(res)-1].LogIndex {
+			continue
+		}
+		res = append(res, log)
+	}
+	return res
+}
+
 func (m *ContractMonitor) tryToFetchLogs(ctx context.Context, blocksRange *BlocksRange) error {
 	qs := m.buildFilterQueries(blocksRange)
 	var logs []*entity.Log
@@ -312,6 +455,7 @@ func (m *ContractMonitor) tryToFetchLogs(ctx context.Context, blocksRange *Block
 			logsBatch, err = m.client.FilterLogs(ctx, q)
 		}
 		if err != nil {
+			m.recordRPCError(err)
 			return err
 		}
 		for _, log := range logsBatch {
@@ -322,6 +466,8 @@ func (m *ContractMonitor) tryToFetchLogs(ctx context.Context, blocksRange *Block
 		a, b := logs[i], logs[j]
 		return a.BlockNumber < b.BlockNumber || (a.BlockNumber == b.BlockNumber && a.LogIndex < b.LogIndex)
 	})
+	// token transfers from the bridge to itself are matched by both transfer queries
+	logs = uniqueLogs(logs)
 	m.logger.WithFields(logrus.Fields{
 		"count":      len(logs),
 		"from_block": blocksRange.From,
@@ -342,11 +488,11 @@ func (m *ContractMonitor) tryToFetchLogs(ctx context.Context, blocksRange *Block
 		return err
 	}
 
-	m.submitLogs(logs, blocksRange.To)
+	m.submitLogs(ctx, logs, blocksRange.To)
 	return nil
 }
 
-func (m *ContractMonitor) submitLogs(logs []*entity.Log, endBlock uint) {
+func (m *ContractMonitor) submitLogs(ctx context.Context, logs []*entity.Log, endBlock uint) {
 	logBatches := SplitLogsInBatches(logs)
 	m.logger.WithFields(logrus.Fields{
 		"count": len(logs),
@@ -357,102 +503,137 @@ func (m *ContractMonitor) submitLogs(logs []*entity.Log, endBlock uint) {
 			"count":        len(batch.Logs),
 			"block_number": batch.BlockNumber,
 		}).Debug("submitting logs batch to logs processor")
-		m.logsChan <- batch
+		select {
+		case m.logsChan <- batch:
+		case <-ctx.Done():
+			return
+		}
 	}
 	if len(logBatches) == 0 || logBatches[len(logBatches)-1].BlockNumber < endBlock {
-		m.logsChan <- &LogsBatch{
+		select {
+		case m.logsChan <- &LogsBatch{
 			BlockNumber: endBlock,
 			Logs:        nil,
+		}:
+		case <-ctx.Done():
 		}
 	}
 }
 
-func (m *ContractMonitor) StartLogsProcessor(ctx context.Context) {
+// StartLogsProcessor processes submitted logs batches until ctx is cancelled.
+// Batches are processed using processCtx, so that cancellation of ctx does not interrupt the current batch.
+func (m *ContractMonitor) StartLogsProcessor(ctx, processCtx context.Context) {
 	m.logger.Info("starting logs processor")
 	for {
 		select {
 		case <-ctx.Done():
+			m.logger.Info("stopping logs processor")
 			return
 		case logs := <-m.logsChan:
-			if logs == nil {
+			if logs == nil || ctx.Err() != nil {
 				continue
 			}
-			m.processLogsBatch(ctx, logs)
+			m.processLogsBatch(processCtx, logs)
 		}
 	}
 }
 
+// processLogsBatch applies all handlers of the logs batch and advances the logs cursor in a single transaction,
+// so that a partially processed batch is never persisted. RPC data needed by the handlers is prefetched
+// before the transaction is started, and the cursor is updated last, so that its row is locked only until the commit.
 func (m *ContractMonitor) processLogsBatch(ctx context.Context, logs *LogsBatch) {
-	wg := new(sync.WaitGroup)
-	wg.Add(2)
-	go func() {
-		defer wg.Done()
-		for {
-			err := m.tryToGetBlockTimestamp(ctx, logs.BlockNumber)
-			if err != nil {
-				m.logger.WithError(err).WithFields(logrus.Fields{
-					"block_number": logs.BlockNumber,
-				}).Error("failed to get block timestamp, retrying")
-				if utils.ContextSleep(ctx, time.Second) == nil {
-					return
-				}
-				continue
+	var blockTime time.Time
+	for {
+		var err error
+		blockTime, err = m.tryToGetBlockTimestamp(ctx, logs.BlockNumber)
+		if err != nil {
+			m.logger.WithError(err).WithFields(logrus.Fields{
+				"block_number": logs.BlockNumber,
+			}).Error("failed to get block timestamp, retrying")
+			if utils.ContextSleep(ctx, time.Second) == nil {
+				return
 			}
-			return
+			continue
 		}
-	}()
+		break
+	}
 
-	go func() {
-		defer wg.Done()
-		for {
-			err := m.tryToProcessLogsBatch(ctx, logs)
-			if err != nil {
-				m.logger.WithError(err).WithFields(logrus.Fields{
-					"block_number": logs.BlockNumber,
-					"count":        len(logs.Logs),
-				}).Error("failed to process logs batch, retrying")
-				if utils.ContextSleep(ctx, time.Second) == nil {
-					return
-				}
-				continue
+	ctx = withPrefetchedData(ctx)
+	for {
+		if err := m.tryToPrefetchLogsBatch(ctx, logs); err != nil {
+			m.logger.WithError(err).WithFields(logrus.Fields{
+				"block_number": logs.BlockNumber,
+				"count":        len(logs.Logs),
+			}).Error("failed to prefetch logs batch data, retrying")
+			if utils.ContextSleep(ctx, time.Second) == nil {
+				return
 			}
-			return
+			continue
 		}
-	}()
-	wg.Wait()
+		break
+	}
 
 	for {
-		err := m.recordProcessedBlockNumber(ctx, logs.BlockNumber)
+		err := m.repo.InTransaction(ctx, func(ctx context.Context) error {
+			if err := m.tryToProcessLogsBatch(ctx, logs); err != nil {
+				return err
+			}
+			return m.persistProcessedBlockNumber(ctx, logs.BlockNumber)
+		})
 		if err != nil {
-			m.logger.WithError(err).WithField("block_number", logs.BlockNumber).
-				Error("failed to update latest processed block number, retrying")
-			if utils.ContextSleep(ctx, 10*time.Second) == nil {
+			m.logger.WithError(err).WithFields(logrus.Fields{
+				"block_number": logs.BlockNumber,
+				"count":        len(logs.Logs),
+			}).Error("failed to process logs batch, retrying")
+			if utils.ContextSleep(ctx, time.Second) == nil {
 				return
 			}
 			continue
 		}
 		break
 	}
+	m.recordProcessedBlockNumber(logs.BlockNumber, blockTime)
 }
 
-func (m *ContractMonitor) tryToGetBlockTimestamp(ctx context.Context, blockNumber uint) error {
-	_, err := m.repo.BlockTimestamps.GetByBlockNumber(ctx, m.cfg.Chain.ChainID, blockNumber)
+func (m *ContractMonitor) tryToGetBlockTimestamp(ctx context.Context, blockNumber uint) (time.Time, error) {
+	ts, err := m.repo.BlockTimestamps.GetByBlockNumber(ctx, m.cfg.Chain.ChainID, blockNumber)
 	if err != nil {
 		if errors.Is(err, db.ErrNotFound) {
 			m.logger.WithField("block_number", blockNumber).Debug("fetching block timestamp")
 			header, err := m.client.HeaderByNumber(ctx, blockNumber)
 			if err != nil {
-				return fmt.Errorf("can't request block header: %w", err)
+				m.recordRPCError(err)
+				return time.Time{}, fmt.Errorf("can't request block header: %w", err)
 			}
-			return m.repo.BlockTimestamps.Ensure(ctx, &entity.BlockTimestamp{
+			blockTime := time.Unix(int64(header.Time), 0)
+			return blockTime, m.repo.BlockTimestamps.Ensure(ctx, &entity.BlockTimestamp{
 				ChainID:     m.cfg.Chain.ChainID,
 				BlockNumber: blockNumber,
-				Timestamp:   time.Unix(int64(header.Time), 0),
+				Timestamp:   blockTime,
 			})
 		}
-		return fmt.Errorf("can't get block timestamp from db: %w", err)
+		return time.Time{}, fmt.Errorf("can't get block timestamp from db: %w", err)
 	}
 	m.logger.WithField("block_number", blockNumber).Debug("timestamp already exists, skipping")
+	return ts.Timestamp, nil
+}
+
+func (m *ContractMonitor) tryToPrefetchLogsBatch(ctx context.Context, batch *LogsBatch) error {
+	if len(m.eventPrefetchers) == 0 {
+		return nil
+	}
+	ctx = logging.WithLogger(ctx, m.logger)
+	for _, log := range batch.Logs {
+		event, data, err := m.contract.ABI.ParseLog(log)
+		if err != nil {
+			return fmt.Errorf("can't parse log: %w", err)
+		}
+		if prefetch, ok := m.eventPrefetchers[event]; ok {
+			if err = prefetch(ctx, log, data); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -461,6 +642,7 @@ func (m *ContractMonitor) tryToProcessLogsBatch(ctx context.Context, batch *Logs
 		"count":        len(batch.Logs),
 		"block_number": batch.BlockNumber,
 	}).Debug("processing logs batch")
+	ctx = logging.WithLogger(ctx, m.logger)
 	for _, log := range batch.Logs {
 		event, data, err := m.contract.ABI.ParseLog(log)
 		if err != nil {
@@ -491,7 +673,19 @@ func (m *ContractMonitor) tryToProcessLogsBatch(ctx context.Context, batch *Logs
 	return nil
 }
 
+func (m *ContractMonitor) recordRPCError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastRPCError = err
+	m.lastRPCErrorAt = time.Now()
+}
+
 func (m *ContractMonitor) recordHeadBlockNumber(blockNumber uint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.headUpdatedAt = time.Now()
 	if blockNumber < m.headBlock {
 		return
 	}
@@ -501,6 +695,7 @@ func (m *ContractMonitor) recordHeadBlockNumber(blockNumber uint) {
 	m.recordIsSynced()
 }
 
+// recordIsSynced should be called with m.mu held.
 func (m *ContractMonitor) recordIsSynced() {
 	m.isSynced = m.logsCursor.LastProcessedBlock+defaultSyncedThreshold > m.headBlock
 	if m.isSynced {
@@ -511,30 +706,46 @@ func (m *ContractMonitor) recordIsSynced() {
 }
 
 func (m *ContractMonitor) recordFetchedBlockNumber(ctx context.Context, blockNumber uint) error {
+	m.mu.Lock()
 	if blockNumber < m.logsCursor.LastFetchedBlock {
+		m.mu.Unlock()
 		return nil
 	}
 
 	m.logsCursor.LastFetchedBlock = blockNumber
 	m.fetchedBlockMetric.Set(float64(blockNumber))
-	err := m.repo.LogsCursors.Ensure(ctx, m.logsCursor)
-	if err != nil {
-		return err
-	}
-	return nil
+	cursor := *m.logsCursor
+	m.mu.Unlock()
+
+	return m.repo.LogsCursors.UpdateFetchedBlock(ctx, &cursor)
 }
 
-func (m *ContractMonitor) recordProcessedBlockNumber(ctx context.Context, blockNumber uint) error {
+func (m *ContractMonitor) persistProcessedBlockNumber(ctx context.Context, blockNumber uint) error {
+	m.mu.RLock()
 	if blockNumber < m.logsCursor.LastProcessedBlock {
+		m.mu.RUnlock()
 		return nil
 	}
+	cursor := *m.logsCursor
+	m.mu.RUnlock()
+
+	cursor.LastProcessedBlock = blockNumber
+	return m.repo.LogsCursors.UpdateProcessedBlock(ctx, &cursor)
+}
+
+// recordProcessedBlockNumber updates in-memory cursor after the processed block number was persisted.
+func (m *ContractMonitor) recordProcessedBlockNumber(blockNumber uint, blockTime time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if blockNumber < m.logsCursor.LastProcessedBlock {
+		return
+	}
 
 	m.logsCursor.LastProcessedBlock = blockNumber
+	if !blockTime.IsZero() {
+		m.lastProcessedBlockTime = blockTime
+	}
 	m.processedBlockMetric.Set(float64(blockNumber))
 	m.recordIsSynced()
-	err := m.repo.LogsCursors.Ensure(ctx, m.logsCursor)
-	if err != nil {
-		return err
-	}
-	return nil
 }
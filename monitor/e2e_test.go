@@ -0,0 +1,469 @@
+package monitor_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/ethclient/fakechain"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/monitor"
+	"github.com/omni/tokenbridge-monitor/monitor/alerts"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
+)
+
+const (
+	testBlockConfirmations = 2
+	testSyncTimeout        = 10 * time.Second
+)
+
+var (
+	homeBridgeAddress       = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	homeValidatorAddress    = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	foreignBridgeAddress    = common.HexToAddress("0x2000000000000000000000000000000000000001")
+	foreignValidatorAddress = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	tokenAddress            = common.HexToAddress("0x2000000000000000000000000000000000000003")
+	validator1              = common.HexToAddress("0x3000000000000000000000000000000000000001")
+	validator2              = common.HexToAddress("0x3000000000000000000000000000000000000002")
+	user                    = common.HexToAddress("0x4000000000000000000000000000000000000001")
+)
+
+// testBridge is a bridge between two fake chains, monitored with the given repository.
+type testBridge struct {
+	cfg           *config.BridgeConfig
+	repo          *repository.Repo
+	dbConn        *db.DB
+	home          *fakechain.Chain
+	foreign       *fakechain.Chain
+	homeBridge    *fakechain.Bridge
+	foreignBridge *fakechain.Bridge
+}
+
+// testBackends returns the in-memory repository, and the Postgres one if TEST_POSTGRES_HOST is set.
+func testBackends(t *testing.T) map[string]func(t *testing.T) (*repository.Repo, *db.DB) {
+	t.Helper()
+
+	backends := map[string]func(t *testing.T) (*repository.Repo, *db.DB){
+		"memory": func(t *testing.T) (*repository.Repo, *db.DB) {
+			t.Helper()
+			return repository.NewMemoryRepo(memory.NewStore()), nil
+		},
+	}
+	host := os.Getenv("TEST_POSTGRES_HOST")
+	if host == "" {
+		return backends
+	}
+	backends["postgres"] = func(t *testing.T) (*repository.Repo, *db.DB) {
+		t.Helper()
+		port, err := strconv.Atoi(os.Getenv("TEST_POSTGRES_PORT"))
+		if err != nil {
+			port = 5432
+		}
+		conn, err := db.NewDB(&config.DBConfig{
+			User:     os.Getenv("TEST_POSTGRES_USER"),
+			Password: config.Secret(os.Getenv("TEST_POSTGRES_PASSWORD")),
+			Host:     host,
+			Port:     port,
+			DB:       os.Getenv("TEST_POSTGRES_DB"),
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, conn.Close())
+		})
+		// migrations source is relative to the package directory, instead of the repository root
+		require.NoError(t, conn.MigrateFrom("file://../db/migrations"))
+		return repository.NewRepo(conn), conn
+	}
+	return backends
+}
+
+// newTestBridge creates fake chains with random chain ids, so that tests can share the same database.
+func newTestBridge(t *testing.T, mode config.BridgeMode, repo *repository.Repo, dbConn *db.DB) *testBridge {
+	t.Helper()
+
+	//nolint:gosec
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	home := fakechain.New(strconv.FormatInt(rnd.Int63n(1e15)+1e15, 10))
+	foreign := fakechain.New(strconv.FormatInt(rnd.Int63n(1e15)+1e15, 10))
+	homeBridge := fakechain.NewBridge(mode, homeBridgeAddress, homeValidatorAddress)
+	foreignBridge := fakechain.NewBridge(mode, foreignBridgeAddress, foreignValidatorAddress)
+	homeBridge.Deploy(home)
+	foreignBridge.Deploy(foreign)
+
+	sideConfig := func(chain *fakechain.Chain, address common.Address) *config.BridgeSideConfig {
+		return &config.BridgeSideConfig{
+			Chain: &config.ChainConfig{
+				ChainID:            chain.ChainID(),
+				BlockIndexInterval: 10 * time.Millisecond,
+				RPC:                &config.RPCConfig{Timeout: time.Second},
+				SafeLogsRequest:    true,
+			},
+			Address:            address,
+			StartBlock:         1,
+			BlockConfirmations: testBlockConfirmations,
+			MaxBlockRangeSize:  5,
+		}
+	}
+	cfg := &config.BridgeConfig{
+		ID:         fmt.Sprintf("test-bridge-%d", rnd.Int63()),
+		BridgeMode: mode,
+		Home:       sideConfig(home, homeBridgeAddress),
+		Foreign:    sideConfig(foreign, foreignBridgeAddress),
+	}
+	if mode == config.BridgeModeErcToNative {
+		cfg.Foreign.ErcToNativeTokens = []config.TokenConfig{{Address: tokenAddress, StartBlock: 1, EndBlock: math.MaxUint32}}
+	}
+	return &testBridge{
+		cfg:           cfg,
+		repo:          repo,
+		dbConn:        dbConn,
+		home:          home,
+		foreign:       foreign,
+		homeBridge:    homeBridge,
+		foreignBridge: foreignBridge,
+	}
+}
+
+// start runs the bridge monitor until the end of the test.
+func (b *testBridge) start(t *testing.T) *monitor.Monitor {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m, err := monitor.NewMonitor(ctx, logging.NullLogger(), b.dbConn, b.repo, b.cfg, b.home, b.foreign)
+	require.NoError(t, err)
+	m.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), testSyncTimeout)
+		defer shutdownCancel()
+		require.NoError(t, m.Shutdown(shutdownCtx))
+		m.UnregisterMetrics()
+	})
+	return m
+}
+
+// waitForSync waits until both sides are processed up to the last confirmed blocks.
+func (b *testBridge) waitForSync(t *testing.T, m *monitor.Monitor) {
+	t.Helper()
+
+	homeHead := b.home.Head() - testBlockConfirmations
+	foreignHead := b.foreign.Head() - testBlockConfirmations
+	require.Eventually(t, func() bool {
+		status := m.Status()
+		return status.Home.ProcessedBlock >= homeHead && status.Foreign.ProcessedBlock >= foreignHead
+	}, testSyncTimeout, 10*time.Millisecond)
+}
+
+func newAMBMessage(nonce uint64, src, dst *fakechain.Chain) *fakechain.AMBMessage {
+	srcChainID, _ := new(big.Int).SetString(src.ChainID(), 10)
+	dstChainID, _ := new(big.Int).SetString(dst.ChainID(), 10)
+	return &fakechain.AMBMessage{
+		MessageID:          fakechain.AMBMessageID(nonce),
+		Sender:             user,
+		Executor:           user,
+		GasLimit:           200000,
+		SourceChainID:      srcChainID,
+		DestinationChainID: dstChainID,
+		Data:               []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+}
+
+func TestMonitor_AMB(t *testing.T) {
+	t.Parallel()
+
+	for name, newRepo := range testBackends(t) {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo, dbConn := newRepo(t)
+			b := newTestBridge(t, config.BridgeModeArbitraryMessage, repo, dbConn)
+			home, foreign, hb, fb := b.home, b.foreign, b.homeBridge, b.foreignBridge
+
+			toForeign := newAMBMessage(1, home, foreign)
+			failed := newAMBMessage(2, foreign, home)
+			stuck := newAMBMessage(3, foreign, home)
+
+			home.Mine(&fakechain.Tx{To: homeValidatorAddress, Logs: []fakechain.Log{hb.ValidatorAdded(validator1), hb.ValidatorAdded(validator2)}})
+			home.Mine(&fakechain.Tx{From: user, To: homeBridgeAddress, Logs: []fakechain.Log{hb.UserRequestForSignature(toForeign)}})
+			home.Mine(
+				&fakechain.Tx{From: validator1, To: homeBridgeAddress, Logs: []fakechain.Log{hb.SignedForUserRequest(validator1, toForeign.Hash())}},
+				&fakechain.Tx{From: validator2, To: homeBridgeAddress, Logs: []fakechain.Log{
+					hb.SignedForUserRequest(validator2, toForeign.Hash()),
+					hb.CollectedSignatures(validator2, toForeign.Hash(), 2),
+				}},
+			)
+			foreign.Mine(&fakechain.Tx{From: user, To: foreignBridgeAddress, Logs: []fakechain.Log{
+				fb.UserRequestForAffirmation(failed),
+				fb.UserRequestForAffirmation(stuck),
+			}})
+			foreign.Mine(&fakechain.Tx{From: validator2, To: foreignBridgeAddress, Logs: []fakechain.Log{fb.RelayedMessage(toForeign, true)}})
+			block := home.Mine(&fakechain.Tx{From: validator1, To: homeBridgeAddress, Logs: []fakechain.Log{
+				hb.SignedForAffirmation(validator1, failed.Hash()),
+				hb.AffirmationCompleted(failed, false),
+			}})
+			home.SetTrace(block.Transactions[0].Hash(), &ethclient.CallFrame{
+				Type: "CALL",
+				From: validator1,
+				To:   homeBridgeAddress,
+				Calls: []*ethclient.CallFrame{{
+					Type:   "CALL",
+					From:   homeBridgeAddress,
+					To:     failed.Executor,
+					Error:  "execution reverted",
+					Output: fakechain.NewRevertError("not allowed").Data,
+				}},
+			})
+			home.MineEmpty(testBlockConfirmations)
+			foreign.MineEmpty(testBlockConfirmations)
+
+			m := b.start(t)
+			b.waitForSync(t, m)
+			require.True(t, m.IsSynced())
+
+			validators, err := repo.BridgeValidators.FindActiveValidators(ctx, b.cfg.ID, home.ChainID())
+			require.NoError(t, err)
+			require.Len(t, validators, 2)
+
+			signed, err := repo.SignedMessages.FindByMsgHashes(ctx, b.cfg.ID, []common.Hash{toForeign.Hash()})
+			require.NoError(t, err)
+			require.Len(t, signed, 2)
+			collected, err := repo.CollectedMessages.GetByMsgHash(ctx, b.cfg.ID, toForeign.Hash())
+			require.NoError(t, err)
+			require.Equal(t, uint(2), collected.NumSignatures)
+			executed, err := repo.ExecutedMessages.GetByMessageID(ctx, b.cfg.ID, toForeign.MessageID)
+			require.NoError(t, err)
+			require.True(t, executed.Status)
+
+			// failed_message_execution alert condition
+			executed, err = repo.ExecutedMessages.GetByMessageID(ctx, b.cfg.ID, failed.MessageID)
+			require.NoError(t, err)
+			require.False(t, executed.Status)
+			require.Equal(t, uint(100000), *executed.GasUsed)
+			require.Equal(t, "not allowed", *executed.RevertReason)
+			require.Equal(t, failed.Executor, *executed.FailedCallTarget)
+
+			// stuck_message_confirmation alert condition
+			pending, err := repo.FindPendingMessages(ctx, b.cfg.ID, b.cfg.BridgeMode)
+			require.NoError(t, err)
+			require.Len(t, pending, 1)
+			require.Equal(t, stuck.Hash(), pending[0].GetMsgHash())
+
+			status, err := repo.MessageStatuses.GetByMsgHash(ctx, b.cfg.ID, toForeign.Hash())
+			require.NoError(t, err)
+			require.Equal(t, entity.MessageStateExecuted, status.State)
+			require.Equal(t, uint(2), status.Signatures)
+			require.True(t, status.Collected)
+			counts, err := repo.MessageStatuses.CountByState(ctx, b.cfg.ID)
+			require.NoError(t, err)
+			require.Equal(t, map[entity.MessageState]uint{
+				entity.MessageStatePending:  1,
+				entity.MessageStateExecuted: 1,
+				entity.MessageStateFailed:   1,
+			}, counts)
+
+			// new blocks are indexed after the initial synchronization
+			home.Mine(&fakechain.Tx{To: homeValidatorAddress, Logs: []fakechain.Log{hb.ValidatorRemoved(validator2)}})
+			home.MineEmpty(testBlockConfirmations)
+			b.waitForSync(t, m)
+			validators, err = repo.BridgeValidators.FindActiveValidators(ctx, b.cfg.ID, home.ChainID())
+			require.NoError(t, err)
+			require.Len(t, validators, 1)
+			require.Equal(t, validator1, validators[0].Address)
+
+			if dbConn != nil {
+				provider := alerts.NewDBAlertsProvider(dbConn)
+				params := &alerts.AlertJobParams{
+					Bridge:               b.cfg.ID,
+					HomeChainID:          home.ChainID(),
+					ForeignChainID:       foreign.ChainID(),
+					HomeBridgeAddress:    homeBridgeAddress,
+					ForeignBridgeAddress: foreignBridgeAddress,
+				}
+				res, err := provider.FindFailedExecutions(ctx, params)
+				require.NoError(t, err)
+				require.Len(t, res, 1)
+				res, err = provider.FindStuckMessages(ctx, params)
+				require.NoError(t, err)
+				require.Len(t, res, 1)
+				require.Equal(t, stuck.Hash(), res.([]alerts.StuckMessage)[0].MsgHash)
+			}
+		})
+	}
+}
+
+func TestMonitor_Reorg(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := newTestBridge(t, config.BridgeModeArbitraryMessage, repository.NewMemoryRepo(memory.NewStore()), nil)
+	b.home.MineEmpty(5)
+	b.foreign.MineEmpty(5)
+	m := b.start(t)
+
+	removed := newAMBMessage(1, b.foreign, b.home)
+	added := newAMBMessage(2, b.foreign, b.home)
+
+	// message is sent in the block, which is not yet confirmed, and then removed by the reorg
+	b.foreign.Mine(&fakechain.Tx{From: user, To: foreignBridgeAddress, Logs: []fakechain.Log{b.foreignBridge.UserRequestForAffirmation(removed)}})
+	b.waitForSync(t, m)
+
+	b.foreign.Rewind(b.foreign.Head() - 1)
+	b.foreign.Mine(&fakechain.Tx{From: user, To: foreignBridgeAddress, Logs: []fakechain.Log{b.foreignBridge.UserRequestForAffirmation(added)}})
+	b.foreign.MineEmpty(testBlockConfirmations)
+	b.waitForSync(t, m)
+
+	_, err := b.repo.Messages.GetByMessageID(ctx, b.cfg.ID, removed.MessageID)
+	require.ErrorIs(t, err, db.ErrNotFound)
+	msg, err := b.repo.Messages.GetByMessageID(ctx, b.cfg.ID, added.MessageID)
+	require.NoError(t, err)
+	require.Equal(t, entity.DirectionForeignToHome, msg.Direction)
+}
+
+func TestMonitor_ErcToNative(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := newTestBridge(t, config.BridgeModeErcToNative, repository.NewMemoryRepo(memory.NewStore()), nil)
+	home, foreign, hb, fb := b.home, b.foreign, b.homeBridge, b.foreignBridge
+	value := big.NewInt(1e18)
+
+	// home to foreign transfer, sender is taken from the transaction
+	sender := common.HexToAddress("0x4000000000000000000000000000000000000002")
+	block := home.Mine(&fakechain.Tx{From: sender, To: homeBridgeAddress, Value: value, Logs: []fakechain.Log{hb.ErcToNativeUserRequestForSignature(user, value)}})
+	homeTxHash := block.Transactions[0].Hash()
+	toForeignHash := fakechain.ErcToNativeMsgHash(user, value, homeTxHash, foreignBridgeAddress)
+	home.Mine(&fakechain.Tx{From: validator1, To: homeBridgeAddress, Logs: []fakechain.Log{
+		hb.SignedForUserRequest(validator1, toForeignHash),
+		hb.CollectedSignatures(validator1, toForeignHash, 1),
+	}})
+	foreign.Mine(&fakechain.Tx{From: validator1, To: foreignBridgeAddress, Logs: []fakechain.Log{
+		fb.ErcToNativeTransfer(tokenAddress, foreignBridgeAddress, user, value),
+		fb.ErcToNativeRelayedMessage(user, value, homeTxHash),
+	}})
+
+	// relay, which didn't release any tokens to the recipient
+	block = home.Mine(&fakechain.Tx{From: sender, To: homeBridgeAddress, Value: value, Logs: []fakechain.Log{hb.ErcToNativeUserRequestForSignature(user, value)}})
+	unreleasedTxHash := block.Transactions[0].Hash()
+	foreign.Mine(&fakechain.Tx{From: validator1, To: foreignBridgeAddress, Logs: []fakechain.Log{fb.ErcToNativeRelayedMessage(user, value, unreleasedTxHash)}})
+
+	// foreign to home transfers of the bridged token
+	block = foreign.Mine(&fakechain.Tx{From: user, To: tokenAddress, Logs: []fakechain.Log{fb.ErcToNativeTransfer(tokenAddress, user, foreignBridgeAddress, value)}})
+	executedTxHash := block.Transactions[0].Hash()
+	block = foreign.Mine(&fakechain.Tx{From: user, To: tokenAddress, Logs: []fakechain.Log{fb.ErcToNativeTransfer(tokenAddress, user, foreignBridgeAddress, value)}})
+	pendingTxHash := block.Transactions[0].Hash()
+	home.Mine(&fakechain.Tx{
+		From: validator1,
+		To:   homeBridgeAddress,
+		Data: fakechain.ErcToNativeExecuteAffirmationData(user, value, executedTxHash),
+		Logs: []fakechain.Log{
+			hb.ErcToNativeSignedForAffirmation(validator1, executedTxHash),
+			hb.ErcToNativeAffirmationCompleted(user, value, executedTxHash),
+		},
+	})
+	home.MineEmpty(testBlockConfirmations)
+	foreign.MineEmpty(testBlockConfirmations)
+
+	m := b.start(t)
+	b.waitForSync(t, m)
+
+	msg, err := b.repo.ErcToNativeMessages.GetByMsgHash(ctx, b.cfg.ID, toForeignHash)
+	require.NoError(t, err)
+	require.Equal(t, sender, msg.Sender)
+	require.Equal(t, user, msg.Receiver)
+	require.Equal(t, value.String(), msg.Value)
+	_, err = b.repo.CollectedMessages.GetByMsgHash(ctx, b.cfg.ID, toForeignHash)
+	require.NoError(t, err)
+	executed, err := b.repo.ExecutedMessages.GetByMessageID(ctx, b.cfg.ID, toForeignHash)
+	require.NoError(t, err)
+	require.True(t, executed.Status)
+	executed, err = b.repo.ExecutedMessages.GetByMessageID(ctx, b.cfg.ID, fakechain.ErcToNativeMsgHash(user, value, unreleasedTxHash, foreignBridgeAddress))
+	require.NoError(t, err)
+	require.False(t, executed.Status)
+
+	executedHash := fakechain.ErcToNativeAffirmationHash(user, value, executedTxHash)
+	msg, err = b.repo.ErcToNativeMessages.GetByMsgHash(ctx, b.cfg.ID, executedHash)
+	require.NoError(t, err)
+	require.Equal(t, user, msg.Sender)
+	signed, err := b.repo.SignedMessages.FindByMsgHashes(ctx, b.cfg.ID, []common.Hash{executedHash})
+	require.NoError(t, err)
+	require.Len(t, signed, 1)
+	executed, err = b.repo.ExecutedMessages.GetByMessageID(ctx, b.cfg.ID, executedHash)
+	require.NoError(t, err)
+	require.True(t, executed.Status)
+
+	pending, err := b.repo.FindPendingMessages(ctx, b.cfg.ID, b.cfg.BridgeMode)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, fakechain.ErcToNativeAffirmationHash(user, value, pendingTxHash), pending[0].GetMsgHash())
+}
+
+func TestMonitor_ErcToNativeTokenBalances(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := newTestBridge(t, config.BridgeModeErcToNative, repository.NewMemoryRepo(memory.NewStore()), nil)
+	foreign, fb := b.foreign, b.foreignBridge
+	newToken := common.HexToAddress("0x2000000000000000000000000000000000000004")
+	investment := common.HexToAddress("0x2000000000000000000000000000000000000005")
+	migrator := common.HexToAddress("0x2000000000000000000000000000000000000006")
+	interestReceiver := common.HexToAddress("0x2000000000000000000000000000000000000007")
+	b.cfg.Foreign.ErcToNativeTokens = append(b.cfg.Foreign.ErcToNativeTokens, config.TokenConfig{
+		Address:             newToken,
+		StartBlock:          1,
+		EndBlock:            math.MaxUint32,
+		InvestmentContracts: []common.Address{investment},
+	})
+
+	foreign.Mine(&fakechain.Tx{From: user, To: tokenAddress, Logs: []fakechain.Log{fb.ErcToNativeTransfer(tokenAddress, user, foreignBridgeAddress, big.NewInt(30))}})
+	foreign.Mine(&fakechain.Tx{From: user, To: foreignBridgeAddress, Logs: []fakechain.Log{
+		fb.ErcToNativeTransfer(tokenAddress, foreignBridgeAddress, migrator, big.NewInt(30)),
+		fb.ErcToNativeTransfer(newToken, migrator, foreignBridgeAddress, big.NewInt(30)),
+		fb.ErcToNativeTokensSwapped(tokenAddress, newToken, big.NewInt(30)),
+		fb.ErcToNativeTransfer(newToken, user, foreignBridgeAddress, big.NewInt(2)),
+	}})
+	foreign.Mine(&fakechain.Tx{From: user, To: foreignBridgeAddress, Logs: []fakechain.Log{fb.ErcToNativeTransfer(newToken, foreignBridgeAddress, investment, big.NewInt(20))}})
+	foreign.Mine(&fakechain.Tx{From: validator1, To: foreignBridgeAddress, Logs: []fakechain.Log{
+		fb.ErcToNativeTransfer(newToken, investment, foreignBridgeAddress, big.NewInt(10)),
+		fb.ErcToNativeTransfer(newToken, foreignBridgeAddress, user, big.NewInt(10)),
+		fb.ErcToNativeRelayedMessage(user, big.NewInt(10), common.HexToHash("0x01")),
+	}})
+	foreign.Mine(&fakechain.Tx{From: user, To: foreignBridgeAddress, Logs: []fakechain.Log{
+		fb.ErcToNativeTransfer(newToken, investment, foreignBridgeAddress, big.NewInt(3)),
+		fb.ErcToNativeTransfer(newToken, foreignBridgeAddress, interestReceiver, big.NewInt(3)),
+		fb.ErcToNativePaidInterest(newToken, interestReceiver, big.NewInt(3)),
+		fb.ErcToNativeTransfer(newToken, user, foreignBridgeAddress, big.NewInt(5)),
+	}})
+	b.home.MineEmpty(testBlockConfirmations)
+	foreign.MineEmpty(testBlockConfirmations)
+
+	m := b.start(t)
+	b.waitForSync(t, m)
+
+	balances, err := b.repo.ErcToNativeTokenMovements.FindBalances(ctx, b.cfg.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*entity.ErcToNativeTokenBalance{
+		{Token: tokenAddress, Locked: "0", Invested: "0", Claimed: "0"},
+		{Token: newToken, Locked: "27", Invested: "10", Claimed: "3"},
+	}, balances)
+
+	// only the user deposits are foreign to home messages, including the ones in the migration and interest transactions
+	pending, err := b.repo.FindPendingMessages(ctx, b.cfg.ID, b.cfg.BridgeMode)
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+}
@@ -0,0 +1,139 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/utils"
+)
+
+// Storage slots of the AMB contract, which hold messageSender(), messageId() and messageSourceChainId()
+// values during the message execution. Executors, such as OmniBridge mediators, check these values,
+// so they are set with the eth_call state override for the simulated call.
+var (
+	messageSenderSlot        = crypto.Keccak256Hash([]byte("messageSender"))
+	messageIDSlot            = crypto.Keccak256Hash([]byte("messageId"))
+	messageSourceChainIDSlot = crypto.Keccak256Hash([]byte("messageSourceChainId"))
+)
+
+// ExecutionSimulator periodically executes calls of the pending AMB messages with eth_call on the destination chain,
+// on behalf of the destination bridge contract. Predicted failures are stored on the message and reported
+// by the alert manager, before validators spend gas on the execution.
+type ExecutionSimulator struct {
+	logger        logging.Logger
+	repo          *repository.Repo
+	cfg           *config.BridgeConfig
+	homeClient    ethclient.Client
+	foreignClient ethclient.Client
+}
+
+func NewExecutionSimulator(logger logging.Logger, repo *repository.Repo, cfg *config.BridgeConfig, homeClient, foreignClient ethclient.Client) *ExecutionSimulator {
+	return &ExecutionSimulator{
+		logger:        logger,
+		repo:          repo,
+		cfg:           cfg,
+		homeClient:    homeClient,
+		foreignClient: foreignClient,
+	}
+}
+
+func (s *ExecutionSimulator) Start(ctx context.Context) {
+	s.logger.Info("starting pending messages execution simulator")
+	for {
+		if _, err := s.SimulatePending(ctx); err != nil {
+			s.logger.WithError(err).Error("can't simulate pending messages")
+		}
+		if utils.ContextSleep(ctx, s.cfg.ExecutionSimulation.Interval) == nil {
+			return
+		}
+	}
+}
+
+// SimulatePending simulates execution of the pending messages, which were sent within the configured max age,
+// it returns the number of simulated messages. Failed simulations of single messages are logged and skipped.
+func (s *ExecutionSimulator) SimulatePending(ctx context.Context) (uint, error) {
+	since := time.Now().Add(-s.cfg.ExecutionSimulation.MaxAge)
+	msgs, err := s.repo.Messages.FindRecentPendingMessages(ctx, s.cfg.ID, since)
+	if err != nil {
+		return 0, err
+	}
+	var n uint
+	for _, msg := range msgs {
+		if !isSimulatedMessage(msg) {
+			continue
+		}
+		logger := s.logger.WithFields(logrus.Fields{
+			"msg_hash": msg.MsgHash,
+			"executor": msg.Executor,
+		})
+		success, reason, err2 := s.Simulate(ctx, msg)
+		if err2 != nil {
+			if ctx.Err() != nil {
+				return n, ctx.Err()
+			}
+			logger.WithError(err2).Error("can't simulate pending message")
+			continue
+		}
+		if !success && (msg.SimulationSuccess == nil || *msg.SimulationSuccess) {
+			logger.WithField("reason", reason).Warn("pending message execution is predicted to fail")
+		}
+		if err2 = s.repo.Messages.SetSimulationResult(ctx, s.cfg.ID, msg.MsgHash, success, reason); err2 != nil {
+			logger.WithError(err2).Error("can't save message simulation result")
+			continue
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Simulate calls the message executor with the message data and gas limit on the destination chain,
+// on behalf of the bridge contract with the message sender, id and source chain id set in its storage.
+// Failed calls are reported together with their revert reason, while RPC errors are returned.
+func (s *ExecutionSimulator) Simulate(ctx context.Context, msg *entity.Message) (bool, string, error) {
+	client, bridge, sourceChain := s.foreignClient, s.cfg.Foreign.Address, s.cfg.Home.Chain
+	if msg.Direction == entity.DirectionForeignToHome {
+		client, bridge, sourceChain = s.homeClient, s.cfg.Home.Address, s.cfg.Foreign.Chain
+	}
+	sourceChainID, ok := new(big.Int).SetString(sourceChain.ChainID, 10)
+	if !ok {
+		sourceChainID = new(big.Int)
+	}
+	_, err := client.CallContractWithStorage(ctx, ethereum.CallMsg{
+		From: bridge,
+		To:   &msg.Executor,
+		Gas:  uint64(msg.GasLimit),
+		Data: msg.Data,
+	}, ethclient.StorageOverride{
+		bridge: {
+			messageSenderSlot:        msg.Sender.Hash(),
+			messageIDSlot:            msg.MessageID,
+			messageSourceChainIDSlot: common.BigToHash(sourceChainID),
+		},
+	})
+	if err == nil {
+		return true, "", nil
+	}
+	if ethclient.IsExecutionError(err) {
+		return false, ethclient.RevertReason(err), nil
+	}
+	return false, "", fmt.Errorf("can't simulate execution of message %s: %w", msg.MsgHash, err)
+}
+
+// isSimulatedMessage tells whether the message is a regular call in the current message format.
+// Data of the legacy messages is prefixed by the encoded data type, and they are not simulated.
+func isSimulatedMessage(msg *entity.Message) bool {
+	return bytes.Equal(msg.MessageID[:4], []byte{0, 5, 0, 0}) && (msg.DataType == 0 || msg.DataType == 128)
+}
@@ -0,0 +1,111 @@
+package monitor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/ethclient/fakechain"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/monitor"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
+)
+
+var errConnectionRefused = errors.New("connection refused")
+
+func TestExecutionSimulator_SimulatePending(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := newTestBridge(t, config.BridgeModeArbitraryMessage, repository.NewMemoryRepo(memory.NewStore()), nil)
+	b.cfg.ExecutionSimulation = &config.ExecutionSimulationConfig{}
+	executor := common.HexToAddress("0xe0")
+
+	legacyID := fakechain.AMBMessageID(4)
+	legacyID[1] = 4
+	msgs := []*entity.Message{
+		{MessageID: fakechain.AMBMessageID(1), Direction: entity.DirectionHomeToForeign, Data: []byte{1}},
+		{MessageID: fakechain.AMBMessageID(2), Direction: entity.DirectionHomeToForeign, Data: []byte{2}},
+		{MessageID: fakechain.AMBMessageID(3), Direction: entity.DirectionForeignToHome, Data: []byte{3}},
+		{MessageID: legacyID, Direction: entity.DirectionHomeToForeign, Data: []byte{0, 4}},
+	}
+	for i, msg := range msgs {
+		msg.BridgeID = b.cfg.ID
+		msg.MsgHash = common.BytesToHash([]byte{byte(i + 1)})
+		msg.Executor = executor
+		msg.GasLimit = 100000
+		require.NoError(t, b.repo.Messages.Ensure(ctx, msg))
+		require.NoError(t, b.repo.MessageStatuses.Refresh(ctx, b.cfg.ID, msg.MsgHash))
+	}
+	b.foreign.SetCallResult(executor, []byte{1}, nil)
+	b.foreign.SetCallError(executor, []byte{2}, fakechain.NewRevertError("not allowed"))
+
+	s := monitor.NewExecutionSimulator(logging.NullLogger(), b.repo, b.cfg, b.home, b.foreign)
+	n, err := s.SimulatePending(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(3), n)
+
+	for i, expected := range []struct {
+		success bool
+		reason  *string
+	}{
+		{true, nil},
+		{false, stringPtr("not allowed")},
+		{false, stringPtr("execution reverted")},
+	} {
+		msg, err2 := b.repo.Messages.GetByMsgHash(ctx, b.cfg.ID, msgs[i].MsgHash)
+		require.NoError(t, err2)
+		require.Equal(t, expected.success, *msg.SimulationSuccess, i)
+		require.Equal(t, expected.reason, msg.SimulationError, i)
+	}
+	legacy, err := b.repo.Messages.GetByMsgHash(ctx, b.cfg.ID, msgs[3].MsgHash)
+	require.NoError(t, err)
+	require.Nil(t, legacy.SimulationSuccess)
+
+	// RPC errors of one chain don't prevent simulation of the other messages
+	b.home.SetError("eth_call", errConnectionRefused)
+	n, err = s.SimulatePending(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(2), n)
+}
+
+func TestExecutionSimulator_MessageSender(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := newTestBridge(t, config.BridgeModeArbitraryMessage, repository.NewMemoryRepo(memory.NewStore()), nil)
+	b.cfg.ExecutionSimulation = &config.ExecutionSimulationConfig{}
+	mediator, otherMediator := common.HexToAddress("0xe0"), common.HexToAddress("0xe1")
+	// mediator accepts only messages from the mediator on the other side, same as OmniBridge mediators
+	b.foreign.SetCallStorageCheck(mediator, b.cfg.Foreign.Address, crypto.Keccak256Hash([]byte("messageSender")), otherMediator.Hash(), "not a mediator")
+	b.foreign.SetCallResult(mediator, []byte{1}, nil)
+
+	s := monitor.NewExecutionSimulator(logging.NullLogger(), b.repo, b.cfg, b.home, b.foreign)
+	for sender, expected := range map[common.Address]string{
+		otherMediator:               "",
+		common.HexToAddress("0x5e"): "not a mediator",
+	} {
+		success, reason, err := s.Simulate(ctx, &entity.Message{
+			MessageID: fakechain.AMBMessageID(1),
+			Direction: entity.DirectionHomeToForeign,
+			Sender:    sender,
+			Executor:  mediator,
+			GasLimit:  100000,
+			Data:      []byte{1},
+		})
+		require.NoError(t, err)
+		require.Equal(t, expected == "", success)
+		require.Equal(t, expected, reason)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/omni/tokenbridge-monitor/config"
@@ -19,18 +20,20 @@ import (
 type EventHandler func(ctx context.Context, log *entity.Log, data map[string]interface{}) error
 
 type BridgeEventHandler struct {
-	repo       *repository.Repo
-	bridgeID   string
-	homeClient ethclient.Client
-	cfg        *config.BridgeConfig
+	repo          *repository.Repo
+	bridgeID      string
+	homeClient    ethclient.Client
+	foreignClient ethclient.Client
+	cfg           *config.BridgeConfig
 }
 
-func NewBridgeEventHandler(repo *repository.Repo, cfg *config.BridgeConfig, homeClient ethclient.Client) *BridgeEventHandler {
+func NewBridgeEventHandler(repo *repository.Repo, cfg *config.BridgeConfig, homeClient, foreignClient ethclient.Client) *BridgeEventHandler {
 	return &BridgeEventHandler{
-		repo:       repo,
-		bridgeID:   cfg.ID,
-		homeClient: homeClient,
-		cfg:        cfg,
+		repo:          repo,
+		bridgeID:      cfg.ID,
+		homeClient:    homeClient,
+		foreignClient: foreignClient,
+		cfg:           cfg,
 	}
 }
 
@@ -44,11 +47,15 @@ func (p *BridgeEventHandler) HandleUserRequestForAffirmation(ctx context.Context
 	if err != nil {
 		return err
 	}
-	return p.repo.SentMessages.Ensure(ctx, &entity.SentMessage{
+	sent := &entity.SentMessage{
 		LogID:    log.ID,
 		BridgeID: p.bridgeID,
 		MsgHash:  message.MsgHash,
-	})
+	}
+	if err = p.repo.SentMessages.Ensure(ctx, sent); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.Refresh(ctx, p.bridgeID, sent.MsgHash)
 }
 
 func (p *BridgeEventHandler) HandleLegacyUserRequestForAffirmation(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -62,11 +69,15 @@ func (p *BridgeEventHandler) HandleLegacyUserRequestForAffirmation(ctx context.C
 	if err != nil {
 		return err
 	}
-	return p.repo.SentMessages.Ensure(ctx, &entity.SentMessage{
+	sent := &entity.SentMessage{
 		LogID:    log.ID,
 		BridgeID: p.bridgeID,
 		MsgHash:  message.MsgHash,
-	})
+	}
+	if err = p.repo.SentMessages.Ensure(ctx, sent); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.Refresh(ctx, p.bridgeID, sent.MsgHash)
 }
 
 func (p *BridgeEventHandler) HandleErcToNativeTransfer(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -74,36 +85,37 @@ func (p *BridgeEventHandler) HandleErcToNativeTransfer(ctx context.Context, log
 	if !ok {
 		return fmt.Errorf("from type %T is invalid: %w", data["from"], ErrWrongArgumentType)
 	}
+	to, ok := data["to"].(common.Address)
+	if !ok {
+		return fmt.Errorf("to type %T is invalid: %w", data["to"], ErrWrongArgumentType)
+	}
 	value, ok := data["value"].(*big.Int)
 	if !ok {
 		return fmt.Errorf("value type %T is invalid: %w", data["value"], ErrWrongArgumentType)
 	}
 
-	for _, token := range p.cfg.Foreign.ErcToNativeTokens {
-		if token.Address == log.Address {
-			for _, addr := range token.BlacklistedSenders {
-				if from == addr {
-					return nil
-				}
-			}
+	var token config.TokenConfig
+	for _, t := range p.cfg.Foreign.ErcToNativeTokens {
+		if t.Address == log.Address {
+			token = t
 			break
 		}
 	}
-	filter := entity.LogsFilter{
-		ChainID:   &log.ChainID,
-		Addresses: []common.Address{p.cfg.Foreign.Address},
-		FromBlock: &log.BlockNumber,
-		ToBlock:   &log.BlockNumber,
-		TxHash:    &log.TransactionHash,
-		Topic0:    []common.Hash{bridgeabi.ErcToNativeUserRequestForAffirmationEventSignature},
+	// token migrations and interest payments are accounted by their own events
+	isBridgeOperation, err := p.isBridgeOperationTransfer(ctx, log, &token, from, to, value)
+	if err != nil || isBridgeOperation {
+		return err
 	}
-	logs, err := p.repo.Logs.Find(ctx, filter)
-	if err != nil {
-		return fmt.Errorf("failed to get transaction logs for %s: %w", log.TransactionHash, err)
+	if err = p.addErcToNativeTokenMovement(ctx, log, &token, from, to, value); err != nil {
+		return err
 	}
-	if len(logs) > 0 {
+	if to != p.cfg.Foreign.Address || containsAddress(token.BlacklistedSenders, from) || containsAddress(token.InvestmentContracts, from) {
 		return nil
 	}
+	hasAffirmation, err := p.hasForeignBridgeLogs(ctx, log, bridgeabi.ErcToNativeUserRequestForAffirmationEventSignature)
+	if err != nil || hasAffirmation {
+		return err
+	}
 
 	valueBytes := common.BigToHash(value)
 	msg := from[:]
@@ -124,10 +136,167 @@ func (p *BridgeEventHandler) HandleErcToNativeTransfer(ctx context.Context, log
 	if err != nil {
 		return err
 	}
-	return p.repo.SentMessages.Ensure(ctx, &entity.SentMessage{
+	sent := &entity.SentMessage{
 		LogID:    log.ID,
 		BridgeID: p.bridgeID,
 		MsgHash:  msgHash,
+	}
+	if err = p.repo.SentMessages.Ensure(ctx, sent); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.Refresh(ctx, p.bridgeID, sent.MsgHash)
+}
+
+// hasForeignBridgeLogs checks if the transaction of the given log has any of the given foreign bridge events.
+func (p *BridgeEventHandler) hasForeignBridgeLogs(ctx context.Context, log *entity.Log, topics ...common.Hash) (bool, error) {
+	filter := entity.LogsFilter{
+		ChainID:   &log.ChainID,
+		Addresses: []common.Address{p.cfg.Foreign.Address},
+		FromBlock: &log.BlockNumber,
+		ToBlock:   &log.BlockNumber,
+		TxHash:    &log.TransactionHash,
+		Topic0:    topics,
+	}
+	logs, err := p.repo.Logs.Find(ctx, filter)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transaction logs for %s: %w", log.TransactionHash, err)
+	}
+	return len(logs) > 0, nil
+}
+
+// isBridgeOperationTransfer checks if the token transfer is a part of the token migration or the interest payment,
+// done by the foreign bridge in the same transaction. Such transfers are matched by the token, direction and value:
+// migrated tokens leave the bridge and new tokens arrive to it, while paid interest is withdrawn from the investment
+// contract and sent to the interest receiver.
+func (p *BridgeEventHandler) isBridgeOperationTransfer(ctx context.Context, log *entity.Log, token *config.TokenConfig, from, to common.Address, value *big.Int) (bool, error) {
+	bridge := p.cfg.Foreign.Address
+	if from != bridge && to != bridge {
+		return false, nil
+	}
+	filter := entity.LogsFilter{
+		ChainID:   &log.ChainID,
+		Addresses: []common.Address{bridge},
+		FromBlock: &log.BlockNumber,
+		ToBlock:   &log.BlockNumber,
+		TxHash:    &log.TransactionHash,
+		Topic0:    []common.Hash{bridgeabi.ErcToNativeTokensSwappedEventSignature, bridgeabi.ErcToNativePaidInterestEventSignature},
+	}
+	logs, err := p.repo.Logs.Find(ctx, filter)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transaction logs for %s: %w", log.TransactionHash, err)
+	}
+	for _, opLog := range logs {
+		event, data, err2 := bridgeabi.ErcToNativeABI.ParseLog(opLog)
+		if err2 != nil {
+			return false, fmt.Errorf("can't parse bridge log %d: %w", opLog.ID, err2)
+		}
+		opValue, ok := data["value"].(*big.Int)
+		if !ok || opValue.Cmp(value) != 0 {
+			continue
+		}
+		switch event {
+		case bridgeabi.ErcToNativeTokensSwapped:
+			if (data["from"] == log.Address && from == bridge) || (data["to"] == log.Address && to == bridge) {
+				return true, nil
+			}
+		case bridgeabi.ErcToNativePaidInterest:
+			if data["token"] != log.Address {
+				continue
+			}
+			if (from == bridge && data["to"] == to) || (to == bridge && containsAddress(token.InvestmentContracts, from)) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// addErcToNativeTokenMovement accounts the token transfer to or from the foreign bridge.
+// Transfers from the blacklisted senders are not accounted, since they are not user deposits.
+func (p *BridgeEventHandler) addErcToNativeTokenMovement(ctx context.Context, log *entity.Log, token *config.TokenConfig, from, to common.Address, value *big.Int) error {
+	bridge := p.cfg.Foreign.Address
+	var kind entity.TokenMovementKind
+	var counterparty common.Address
+	switch {
+	case from == bridge && to == bridge:
+		return nil
+	case from == bridge && containsAddress(token.InvestmentContracts, to):
+		kind, counterparty = entity.TokenMovementInvest, to
+	case from == bridge:
+		kind, counterparty = entity.TokenMovementRelease, to
+	case containsAddress(token.InvestmentContracts, from):
+		kind, counterparty = entity.TokenMovementWithdraw, from
+	case containsAddress(token.BlacklistedSenders, from):
+		return nil
+	default:
+		kind, counterparty = entity.TokenMovementDeposit, from
+	}
+	return p.repo.ErcToNativeTokenMovements.Ensure(ctx, &entity.ErcToNativeTokenMovement{
+		LogID:        log.ID,
+		BridgeID:     p.bridgeID,
+		Token:        log.Address,
+		Kind:         kind,
+		Counterparty: counterparty,
+		Amount:       value.String(),
+	})
+}
+
+func (p *BridgeEventHandler) HandleErcToNativeTokensSwapped(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
+	from, ok := data["from"].(common.Address)
+	if !ok {
+		return fmt.Errorf("from type %T is invalid: %w", data["from"], ErrWrongArgumentType)
+	}
+	to, ok := data["to"].(common.Address)
+	if !ok {
+		return fmt.Errorf("to type %T is invalid: %w", data["to"], ErrWrongArgumentType)
+	}
+	value, ok := data["value"].(*big.Int)
+	if !ok {
+		return fmt.Errorf("value type %T is invalid: %w", data["value"], ErrWrongArgumentType)
+	}
+
+	err := p.repo.ErcToNativeTokenMovements.Ensure(ctx, &entity.ErcToNativeTokenMovement{
+		LogID:        log.ID,
+		BridgeID:     p.bridgeID,
+		Token:        from,
+		Kind:         entity.TokenMovementSwapOut,
+		Counterparty: to,
+		Amount:       value.String(),
+	})
+	if err != nil {
+		return err
+	}
+	return p.repo.ErcToNativeTokenMovements.Ensure(ctx, &entity.ErcToNativeTokenMovement{
+		LogID:        log.ID,
+		BridgeID:     p.bridgeID,
+		Token:        to,
+		Kind:         entity.TokenMovementSwapIn,
+		Counterparty: from,
+		Amount:       value.String(),
+	})
+}
+
+func (p *BridgeEventHandler) HandleErcToNativePaidInterest(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
+	token, ok := data["token"].(common.Address)
+	if !ok {
+		return fmt.Errorf("token type %T is invalid: %w", data["token"], ErrWrongArgumentType)
+	}
+	receiver, ok := data["to"].(common.Address)
+	if !ok {
+		return fmt.Errorf("to type %T is invalid: %w", data["to"], ErrWrongArgumentType)
+	}
+	value, ok := data["value"].(*big.Int)
+	if !ok {
+		return fmt.Errorf("value type %T is invalid: %w", data["value"], ErrWrongArgumentType)
+	}
+
+	return p.repo.ErcToNativeTokenMovements.Ensure(ctx, &entity.ErcToNativeTokenMovement{
+		LogID:        log.ID,
+		BridgeID:     p.bridgeID,
+		Token:        token,
+		Kind:         entity.TokenMovementInterest,
+		Counterparty: receiver,
+		Amount:       value.String(),
 	})
 }
 
@@ -182,11 +351,15 @@ func (p *BridgeEventHandler) HandleErcToNativeUserRequestForAffirmation(ctx cont
 	if err != nil {
 		return err
 	}
-	return p.repo.SentMessages.Ensure(ctx, &entity.SentMessage{
+	sent := &entity.SentMessage{
 		LogID:    log.ID,
 		BridgeID: p.bridgeID,
 		MsgHash:  msgHash,
-	})
+	}
+	if err = p.repo.SentMessages.Ensure(ctx, sent); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.Refresh(ctx, p.bridgeID, sent.MsgHash)
 }
 
 func (p *BridgeEventHandler) HandleUserRequestForSignature(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -199,11 +372,15 @@ func (p *BridgeEventHandler) HandleUserRequestForSignature(ctx context.Context,
 	if err != nil {
 		return err
 	}
-	return p.repo.SentMessages.Ensure(ctx, &entity.SentMessage{
+	sent := &entity.SentMessage{
 		LogID:    log.ID,
 		BridgeID: p.bridgeID,
 		MsgHash:  message.MsgHash,
-	})
+	}
+	if err = p.repo.SentMessages.Ensure(ctx, sent); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.Refresh(ctx, p.bridgeID, sent.MsgHash)
 }
 
 func (p *BridgeEventHandler) HandleLegacyUserRequestForSignature(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -217,11 +394,15 @@ func (p *BridgeEventHandler) HandleLegacyUserRequestForSignature(ctx context.Con
 	if err != nil {
 		return err
 	}
-	return p.repo.SentMessages.Ensure(ctx, &entity.SentMessage{
+	sent := &entity.SentMessage{
 		LogID:    log.ID,
 		BridgeID: p.bridgeID,
 		MsgHash:  message.MsgHash,
-	})
+	}
+	if err = p.repo.SentMessages.Ensure(ctx, sent); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.Refresh(ctx, p.bridgeID, sent.MsgHash)
 }
 
 func (p *BridgeEventHandler) HandleErcToNativeUserRequestForSignature(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -266,11 +447,15 @@ func (p *BridgeEventHandler) HandleErcToNativeUserRequestForSignature(ctx contex
 	if err != nil {
 		return err
 	}
-	return p.repo.SentMessages.Ensure(ctx, &entity.SentMessage{
+	sent := &entity.SentMessage{
 		LogID:    log.ID,
 		BridgeID: p.bridgeID,
 		MsgHash:  msgHash,
-	})
+	}
+	if err = p.repo.SentMessages.Ensure(ctx, sent); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.Refresh(ctx, p.bridgeID, sent.MsgHash)
 }
 
 func (p *BridgeEventHandler) HandleSignedForUserRequest(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -283,12 +468,16 @@ func (p *BridgeEventHandler) HandleSignedForUserRequest(ctx context.Context, log
 		return fmt.Errorf("signer type %T is invalid: %w", data["signer"], ErrWrongArgumentType)
 	}
 
-	return p.repo.SignedMessages.Ensure(ctx, &entity.SignedMessage{
+	signed := &entity.SignedMessage{
 		LogID:    log.ID,
 		BridgeID: p.bridgeID,
 		MsgHash:  msgHash,
 		Signer:   validator,
-	})
+	}
+	if err := p.repo.SignedMessages.Ensure(ctx, signed); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.Refresh(ctx, p.bridgeID, signed.MsgHash)
 }
 
 func (p *BridgeEventHandler) HandleErcToNativeSignedForAffirmation(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -303,12 +492,16 @@ func (p *BridgeEventHandler) HandleErcToNativeSignedForAffirmation(ctx context.C
 	}
 	msg := tx.Data()[16:]
 
-	return p.repo.SignedMessages.Ensure(ctx, &entity.SignedMessage{
+	signed := &entity.SignedMessage{
 		LogID:    log.ID,
 		BridgeID: p.bridgeID,
 		MsgHash:  crypto.Keccak256Hash(msg),
 		Signer:   validator,
-	})
+	}
+	if err = p.repo.SignedMessages.Ensure(ctx, signed); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.Refresh(ctx, p.bridgeID, signed.MsgHash)
 }
 
 func (p *BridgeEventHandler) HandleRelayedMessage(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -321,12 +514,21 @@ func (p *BridgeEventHandler) HandleRelayedMessage(ctx context.Context, log *enti
 		return fmt.Errorf("status type %T is invalid: %w", data["status"], ErrWrongArgumentType)
 	}
 
-	return p.repo.ExecutedMessages.Ensure(ctx, &entity.ExecutedMessage{
+	executed := &entity.ExecutedMessage{
 		LogID:     log.ID,
 		BridgeID:  p.bridgeID,
 		MessageID: messageID,
 		Status:    status,
-	})
+	}
+	if !status {
+		if err := p.addExecutionFailureDetails(ctx, p.foreignClient, log, executed); err != nil {
+			return err
+		}
+	}
+	if err := p.repo.ExecutedMessages.Ensure(ctx, executed); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.RefreshByMessageID(ctx, p.bridgeID, executed.MessageID)
 }
 
 func (p *BridgeEventHandler) HandleErcToNativeRelayedMessage(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -350,12 +552,23 @@ func (p *BridgeEventHandler) HandleErcToNativeRelayedMessage(ctx context.Context
 	msg = append(msg, transactionHash[:]...)
 	msg = append(msg, log.Address[:]...)
 
-	return p.repo.ExecutedMessages.Ensure(ctx, &entity.ExecutedMessage{
+	receipt, err := transactionReceipt(ctx, p.foreignClient, log.TransactionHash)
+	if err != nil {
+		return err
+	}
+	status := receipt.Status == types.ReceiptStatusSuccessful &&
+		p.hasErcToNativeTokenTransfer(receipt, log.BlockNumber, log.Address, recipient, value)
+
+	executed := &entity.ExecutedMessage{
 		LogID:     log.ID,
 		BridgeID:  p.bridgeID,
 		MessageID: crypto.Keccak256Hash(msg),
-		Status:    true,
-	})
+		Status:    status,
+	}
+	if err = p.repo.ExecutedMessages.Ensure(ctx, executed); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.RefreshByMessageID(ctx, p.bridgeID, executed.MessageID)
 }
 
 func (p *BridgeEventHandler) HandleAffirmationCompleted(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -368,12 +581,75 @@ func (p *BridgeEventHandler) HandleAffirmationCompleted(ctx context.Context, log
 		return fmt.Errorf("status type %T is invalid: %w", data["status"], ErrWrongArgumentType)
 	}
 
-	return p.repo.ExecutedMessages.Ensure(ctx, &entity.ExecutedMessage{
+	executed := &entity.ExecutedMessage{
 		LogID:     log.ID,
 		BridgeID:  p.bridgeID,
 		MessageID: messageID,
 		Status:    status,
-	})
+	}
+	if !status {
+		if err := p.addExecutionFailureDetails(ctx, p.homeClient, log, executed); err != nil {
+			return err
+		}
+	}
+	if err := p.repo.ExecutedMessages.Ensure(ctx, executed); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.RefreshByMessageID(ctx, p.bridgeID, executed.MessageID)
+}
+
+// PrefetchHomeFailedExecution and PrefetchForeignFailedExecution request the receipt and the trace
+// of the failed AMB message execution, which are used by addExecutionFailureDetails.
+func (p *BridgeEventHandler) PrefetchHomeFailedExecution(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
+	return p.prefetchFailedExecution(ctx, p.homeClient, log, data)
+}
+
+func (p *BridgeEventHandler) PrefetchForeignFailedExecution(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
+	return p.prefetchFailedExecution(ctx, p.foreignClient, log, data)
+}
+
+func (p *BridgeEventHandler) prefetchFailedExecution(ctx context.Context, client ethclient.Client, log *entity.Log, data map[string]interface{}) error {
+	if status, ok := data["status"].(bool); !ok || status {
+		return nil
+	}
+	if err := prefetchReceipt(ctx, client, log.TransactionHash); err != nil {
+		return err
+	}
+	prefetchTrace(ctx, client, log.TransactionHash)
+	return nil
+}
+
+// addExecutionFailureDetails adds gas usage and the failed call of the failed AMB message execution.
+// Transaction trace is optional, since debug_traceTransaction is not available on most RPC nodes and for pruned transactions.
+func (p *BridgeEventHandler) addExecutionFailureDetails(ctx context.Context, client ethclient.Client, log *entity.Log, executed *entity.ExecutedMessage) error {
+	receipt, err := transactionReceipt(ctx, client, log.TransactionHash)
+	if err != nil {
+		return err
+	}
+	gasUsed := uint(receipt.GasUsed)
+	executed.GasUsed = &gasUsed
+
+	prefetchTrace(ctx, client, log.TransactionHash)
+	trace := prefetchedDataFromContext(ctx).traces[log.TransactionHash]
+	if trace == nil {
+		return nil
+	}
+	if call := trace.FailedCall(); call != nil {
+		reason := call.RevertReason()
+		executed.RevertReason = &reason
+		executed.FailedCallTarget = &call.To
+	}
+	return nil
+}
+
+// PrefetchHomeReceipt and PrefetchForeignReceipt request the receipt of the ERC_TO_NATIVE execution transaction,
+// which is used to check the execution status.
+func (p *BridgeEventHandler) PrefetchHomeReceipt(ctx context.Context, log *entity.Log, _ map[string]interface{}) error {
+	return prefetchReceipt(ctx, p.homeClient, log.TransactionHash)
+}
+
+func (p *BridgeEventHandler) PrefetchForeignReceipt(ctx context.Context, log *entity.Log, _ map[string]interface{}) error {
+	return prefetchReceipt(ctx, p.foreignClient, log.TransactionHash)
 }
 
 func (p *BridgeEventHandler) HandleErcToNativeAffirmationCompleted(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -396,12 +672,41 @@ func (p *BridgeEventHandler) HandleErcToNativeAffirmationCompleted(ctx context.C
 	msg = append(msg, valueBytes[:]...)
 	msg = append(msg, transactionHash[:]...)
 
-	return p.repo.ExecutedMessages.Ensure(ctx, &entity.ExecutedMessage{
+	receipt, err := transactionReceipt(ctx, p.homeClient, log.TransactionHash)
+	if err != nil {
+		return err
+	}
+
+	executed := &entity.ExecutedMessage{
 		LogID:     log.ID,
 		BridgeID:  p.bridgeID,
 		MessageID: crypto.Keccak256Hash(msg),
-		Status:    true,
-	})
+		Status:    receipt.Status == types.ReceiptStatusSuccessful,
+	}
+	if err = p.repo.ExecutedMessages.Ensure(ctx, executed); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.RefreshByMessageID(ctx, p.bridgeID, executed.MessageID)
+}
+
+// hasErcToNativeTokenTransfer checks that the relay transaction has actually released the bridged tokens,
+// by looking for the matching Transfer event of one of the bridged tokens in the transaction receipt.
+func (p *BridgeEventHandler) hasErcToNativeTokenTransfer(receipt *types.Receipt, blockNumber uint, bridge, recipient common.Address, value *big.Int) bool {
+	tokens := p.cfg.Foreign.ErcToNativeTokenAddresses(blockNumber, blockNumber)
+	for _, txLog := range receipt.Logs {
+		if len(txLog.Topics) != 3 || txLog.Topics[0] != bridgeabi.ErcToNativeTransferEventSignature {
+			continue
+		}
+		if !containsAddress(tokens, txLog.Address) {
+			continue
+		}
+		if common.BytesToAddress(txLog.Topics[1][:]) == bridge &&
+			common.BytesToAddress(txLog.Topics[2][:]) == recipient &&
+			new(big.Int).SetBytes(txLog.Data).Cmp(value) == 0 {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *BridgeEventHandler) HandleCollectedSignatures(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
@@ -418,13 +723,17 @@ func (p *BridgeEventHandler) HandleCollectedSignatures(ctx context.Context, log
 		return fmt.Errorf("NumberOfCollectedSignatures type %T is invalid: %w", data["NumberOfCollectedSignatures"], ErrWrongArgumentType)
 	}
 
-	return p.repo.CollectedMessages.Ensure(ctx, &entity.CollectedMessage{
+	collected := &entity.CollectedMessage{
 		LogID:             log.ID,
 		BridgeID:          p.bridgeID,
 		MsgHash:           msgHash,
 		ResponsibleSigner: relayer,
 		NumSignatures:     uint(numSignatures.Uint64()),
-	})
+	}
+	if err := p.repo.CollectedMessages.Ensure(ctx, collected); err != nil {
+		return err
+	}
+	return p.repo.MessageStatuses.Refresh(ctx, p.bridgeID, collected.MsgHash)
 }
 
 func (p *BridgeEventHandler) HandleUserRequestForInformation(ctx context.Context, log *entity.Log, data map[string]interface{}) error {
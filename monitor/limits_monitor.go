@@ -0,0 +1,151 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/utils"
+)
+
+const (
+	LimitDaily          = "daily"
+	LimitExecutionDaily = "execution_daily"
+)
+
+// LimitsMonitor periodically exports remaining daily and execution daily limits
+// of the bridge contracts on both sides of the bridge.
+type LimitsMonitor struct {
+	logger logging.Logger
+	cfg    *config.BridgeConfig
+	sides  []*limitsMonitorSide
+
+	mu           sync.Mutex
+	metricLabels map[string]prometheus.Labels
+
+	// limits are kept separately from the metrics, so that they can be read while the check is in progress
+	limitsMu sync.RWMutex
+	limits   map[string]*contract.BridgeLimits
+}
+
+type limitsMonitorSide struct {
+	cfg      *config.BridgeSideConfig
+	contract *contract.BridgeContract
+}
+
+func NewLimitsMonitor(logger logging.Logger, cfg *config.BridgeConfig, homeClient, foreignClient ethclient.Client) *LimitsMonitor {
+	return &LimitsMonitor{
+		logger: logger,
+		cfg:    cfg,
+		sides: []*limitsMonitorSide{
+			{cfg: cfg.Home, contract: contract.NewBridgeContract(homeClient, cfg.Home.Address, cfg.BridgeMode)},
+			{cfg: cfg.Foreign, contract: contract.NewBridgeContract(foreignClient, cfg.Foreign.Address, cfg.BridgeMode)},
+		},
+		metricLabels: make(map[string]prometheus.Labels),
+		limits:       make(map[string]*contract.BridgeLimits),
+	}
+}
+
+func (m *LimitsMonitor) Start(ctx context.Context) {
+	m.logger.Info("starting bridge limits monitor")
+	for {
+		if err := m.Check(ctx); err != nil {
+			m.logger.WithError(err).Error("can't check bridge limits")
+		}
+		if utils.ContextSleep(ctx, m.cfg.Limits.Interval) == nil {
+			return
+		}
+	}
+}
+
+// Check updates headroom metrics of all monitored bridge limits.
+func (m *LimitsMonitor) Check(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	BridgeLimitHeadroomThreshold.WithLabelValues(m.cfg.ID).Set(m.cfg.Limits.AlertThreshold)
+	for _, side := range m.sides {
+		for _, token := range m.cfg.LimitTokens(side.cfg) {
+			limits, err := side.contract.Limits(ctx, token)
+			if err != nil {
+				return fmt.Errorf("can't get limits on chain %s: %w", side.cfg.Chain.ChainID, err)
+			}
+			tokenLabel := ""
+			if token != nil {
+				tokenLabel = token.String()
+			}
+			m.setHeadroom(side.cfg.Chain.ChainID, tokenLabel, LimitDaily, limits.DailyLimit, limits.TotalSpentPerDay)
+			m.setHeadroom(side.cfg.Chain.ChainID, tokenLabel, LimitExecutionDaily, limits.ExecutionDailyLimit, limits.TotalExecutedPerDay)
+			m.setLimits(side.cfg.Chain.ChainID, tokenLabel, limits)
+		}
+	}
+	return nil
+}
+
+func (m *LimitsMonitor) setLimits(chainID, token string, limits *contract.BridgeLimits) {
+	m.limitsMu.Lock()
+	defer m.limitsMu.Unlock()
+
+	m.limits[chainID+token] = limits
+}
+
+// Limits returns the bridge limits of the given chain, collected during the last check.
+// Nil token is used for the single-token bridges.
+func (m *LimitsMonitor) Limits(chainID string, token *common.Address) (*contract.BridgeLimits, bool) {
+	m.limitsMu.RLock()
+	defer m.limitsMu.RUnlock()
+
+	tokenLabel := ""
+	if token != nil {
+		tokenLabel = token.String()
+	}
+	limits, ok := m.limits[chainID+tokenLabel]
+	return limits, ok
+}
+
+func (m *LimitsMonitor) setHeadroom(chainID, token, limit string, total, used *big.Int) {
+	labels := prometheus.Labels{
+		"bridge_id": m.cfg.ID,
+		"chain_id":  chainID,
+		"token":     token,
+		"limit":     limit,
+	}
+	m.metricLabels[chainID+token+limit] = labels
+
+	headroom := limitHeadroom(total, used)
+	BridgeLimitHeadroom.With(labels).Set(utils.WeiToEther(headroom))
+	percent := 0.0
+	if total.Sign() > 0 {
+		percent, _ = new(big.Rat).SetFrac(new(big.Int).Mul(headroom, big.NewInt(100)), total).Float64()
+	}
+	BridgeLimitHeadroomPercent.With(labels).Set(percent)
+}
+
+// limitHeadroom returns remaining amount of the daily limit, which is never negative.
+func limitHeadroom(total, used *big.Int) *big.Int {
+	if used.Cmp(total) >= 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Sub(total, used)
+}
+
+// UnregisterMetrics removes limits metrics of the stopped monitor.
+func (m *LimitsMonitor) UnregisterMetrics() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, labels := range m.metricLabels {
+		BridgeLimitHeadroom.Delete(labels)
+		BridgeLimitHeadroomPercent.Delete(labels)
+		delete(m.metricLabels, key)
+	}
+	BridgeLimitHeadroomThreshold.DeleteLabelValues(m.cfg.ID)
+}
@@ -0,0 +1,63 @@
+package monitor_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/ethclient/fakechain"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/monitor"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
+)
+
+func TestLimitsMonitor_Check(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := newTestBridge(t, config.BridgeModeErcToNative, repository.NewMemoryRepo(memory.NewStore()), nil)
+	b.cfg.Limits = &config.BridgeLimitsConfig{AlertThreshold: 10, ForeignTokens: []common.Address{tokenAddress}}
+
+	uint256 := func(v *big.Int) []byte {
+		return common.BigToHash(v).Bytes()
+	}
+	limit := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	b.home.SetCallResult(homeBridgeAddress, fakechain.Selector("getCurrentDay()"), uint256(big.NewInt(19000)))
+	b.home.SetCallResult(homeBridgeAddress, fakechain.Selector("dailyLimit()"), uint256(limit))
+	b.home.SetCallResult(homeBridgeAddress, fakechain.Selector("totalSpentPerDay(uint256)"), uint256(new(big.Int).Mul(big.NewInt(950), big.NewInt(1e18))))
+	b.home.SetCallResult(homeBridgeAddress, fakechain.Selector("executionDailyLimit()"), uint256(limit))
+	b.home.SetCallResult(homeBridgeAddress, fakechain.Selector("totalExecutedPerDay(uint256)"), uint256(new(big.Int)))
+	b.foreign.SetCallResult(foreignBridgeAddress, fakechain.Selector("getCurrentDay()"), uint256(big.NewInt(19000)))
+	b.foreign.SetCallResult(foreignBridgeAddress, fakechain.Selector("dailyLimit(address)"), uint256(limit))
+	b.foreign.SetCallResult(foreignBridgeAddress, fakechain.Selector("totalSpentPerDay(address,uint256)"), uint256(new(big.Int).Mul(limit, big.NewInt(2))))
+	b.foreign.SetCallResult(foreignBridgeAddress, fakechain.Selector("executionDailyLimit(address)"), uint256(limit))
+	b.foreign.SetCallResult(foreignBridgeAddress, fakechain.Selector("totalExecutedPerDay(address,uint256)"), uint256(new(big.Int)))
+
+	m := monitor.NewLimitsMonitor(logging.NullLogger(), b.cfg, b.home, b.foreign)
+	defer m.UnregisterMetrics()
+	require.NoError(t, m.Check(ctx))
+
+	homeDaily := monitor.BridgeLimitHeadroom.WithLabelValues(b.cfg.ID, b.home.ChainID(), "", monitor.LimitDaily)
+	require.InDelta(t, 50, testutil.ToFloat64(homeDaily), 1e-9)
+	homeDailyPercent := monitor.BridgeLimitHeadroomPercent.WithLabelValues(b.cfg.ID, b.home.ChainID(), "", monitor.LimitDaily)
+	require.InDelta(t, 5, testutil.ToFloat64(homeDailyPercent), 1e-9)
+	homeExecutionPercent := monitor.BridgeLimitHeadroomPercent.WithLabelValues(b.cfg.ID, b.home.ChainID(), "", monitor.LimitExecutionDaily)
+	require.InDelta(t, 100, testutil.ToFloat64(homeExecutionPercent), 1e-9)
+
+	// overspent limit has no headroom
+	foreignDaily := monitor.BridgeLimitHeadroom.WithLabelValues(b.cfg.ID, b.foreign.ChainID(), tokenAddress.String(), monitor.LimitDaily)
+	require.Zero(t, testutil.ToFloat64(foreignDaily))
+	require.InDelta(t, 10, testutil.ToFloat64(monitor.BridgeLimitHeadroomThreshold.WithLabelValues(b.cfg.ID)), 1e-9)
+
+	homeLimits, ok := m.Limits(b.home.ChainID(), nil)
+	require.True(t, ok)
+	require.Equal(t, limit, homeLimits.DailyLimit)
+	_, ok = m.Limits(b.foreign.ChainID(), nil)
+	require.False(t, ok)
+}
@@ -30,4 +30,34 @@ var (
 		Name:      "synced",
 		Help:      "Shows 1 if the contract is considered as synced up to chain head.",
 	}, []string{"bridge_id", "chain_id", "address"})
+	ValidatorBalance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "monitor",
+		Subsystem: "validator",
+		Name:      "balance",
+		Help:      "Shows native coin balance of the bridge validator or relayer on the particular chain.",
+	}, []string{"bridge_id", "chain_id", "address", "role"})
+	ValidatorBalanceThreshold = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "monitor",
+		Subsystem: "validator",
+		Name:      "balance_threshold",
+		Help:      "Shows configured minimal native coin balance of the bridge validators and relayers on the particular chain.",
+	}, []string{"bridge_id", "chain_id"})
+	BridgeLimitHeadroom = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "monitor",
+		Subsystem: "bridge",
+		Name:      "limit_headroom",
+		Help:      "Shows remaining amount of the bridge daily limit in the current day.",
+	}, []string{"bridge_id", "chain_id", "token", "limit"})
+	BridgeLimitHeadroomPercent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "monitor",
+		Subsystem: "bridge",
+		Name:      "limit_headroom_percent",
+		Help:      "Shows remaining percentage of the bridge daily limit in the current day.",
+	}, []string{"bridge_id", "chain_id", "token", "limit"})
+	BridgeLimitHeadroomThreshold = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "monitor",
+		Subsystem: "bridge",
+		Name:      "limit_headroom_threshold",
+		Help:      "Shows configured percentage of the remaining daily limit, below which limits alert is triggered.",
+	}, []string{"bridge_id"})
 )
@@ -3,8 +3,13 @@ package monitor
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract"
 	"github.com/omni/tokenbridge-monitor/contract/bridgeabi"
 	"github.com/omni/tokenbridge-monitor/db"
 	"github.com/omni/tokenbridge-monitor/ethclient"
@@ -16,10 +21,20 @@ import (
 type Monitor struct {
 	cfg            *config.BridgeConfig
 	logger         logging.Logger
+	dbConn         *db.DB
 	repo           *repository.Repo
 	homeMonitor    *ContractMonitor
 	foreignMonitor *ContractMonitor
-	alertManager   *alerts.AlertManager
+	balanceMonitor *BalanceMonitor
+	limitsMonitor  *LimitsMonitor
+	sigVerifier    *SignatureVerifier
+	simulator      *ExecutionSimulator
+	// jobs tracks background jobs started by Start, which are awaited by Shutdown together with contract monitors
+	jobs sync.WaitGroup
+
+	alertsMu     sync.Mutex
+	alertManager *alerts.AlertManager
+	alertsCancel context.CancelFunc
 }
 
 func NewMonitor(ctx context.Context, logger logging.Logger, dbConn *db.DB, repo *repository.Repo, cfg *config.BridgeConfig, homeClient, foreignClient ethclient.Client) (*Monitor, error) {
@@ -32,17 +47,23 @@ func NewMonitor(ctx context.Context, logger logging.Logger, dbConn *db.DB, repo
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize foreign side monitor: %w", err)
 	}
-	alertManager, err := alerts.NewAlertManager(logger, dbConn, cfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize alert manager: %w", err)
-	}
 	monitor := &Monitor{
 		cfg:            cfg,
 		logger:         logger,
+		dbConn:         dbConn,
 		repo:           repo,
 		homeMonitor:    homeMonitor,
 		foreignMonitor: foreignMonitor,
-		alertManager:   alertManager,
+		balanceMonitor: NewBalanceMonitor(logger.WithField("job", "balances"), repo, cfg, homeClient, foreignClient),
+	}
+	if cfg.Limits != nil {
+		monitor.limitsMonitor = NewLimitsMonitor(logger.WithField("job", "limits"), cfg, homeClient, foreignClient)
+	}
+	if cfg.SignatureVerification != nil {
+		monitor.sigVerifier = NewSignatureVerifier(logger.WithField("job", "signatures"), repo, cfg, homeClient)
+	}
+	if cfg.ExecutionSimulation != nil {
+		monitor.simulator = NewExecutionSimulator(logger.WithField("job", "simulation"), repo, cfg, homeClient, foreignClient)
 	}
 	switch cfg.BridgeMode {
 	case config.BridgeModeErcToNative:
@@ -58,28 +79,37 @@ func NewMonitor(ctx context.Context, logger logging.Logger, dbConn *db.DB, repo
 	if err != nil {
 		return nil, fmt.Errorf("foreign side contract does not ABI for registered event handler: %w", err)
 	}
+	// alert manager is created last, as it registers alert metrics, which can't be registered twice
+	monitor.alertManager, err = alerts.NewAlertManager(logger, dbConn, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize alert manager: %w", err)
+	}
 	return monitor, nil
 }
 
 func (m *Monitor) RegisterErcToNativeEventHandlers() {
-	handlers := NewBridgeEventHandler(m.repo, m.cfg, m.homeMonitor.client)
+	handlers := NewBridgeEventHandler(m.repo, m.cfg, m.homeMonitor.client, m.foreignMonitor.client)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.ErcToNativeUserRequestForSignature, handlers.HandleErcToNativeUserRequestForSignature)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.SignedForUserRequest, handlers.HandleSignedForUserRequest)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.CollectedSignatures, handlers.HandleCollectedSignatures)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.ErcToNativeSignedForAffirmation, handlers.HandleErcToNativeSignedForAffirmation)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.ErcToNativeAffirmationCompleted, handlers.HandleErcToNativeAffirmationCompleted)
+	m.homeMonitor.RegisterEventPrefetcher(bridgeabi.ErcToNativeAffirmationCompleted, handlers.PrefetchHomeReceipt)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.ValidatorAdded, handlers.HandleValidatorAdded)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.ValidatorRemoved, handlers.HandleValidatorRemoved)
 
 	m.foreignMonitor.RegisterEventHandler(bridgeabi.ErcToNativeUserRequestForAffirmation, handlers.HandleErcToNativeUserRequestForAffirmation)
 	m.foreignMonitor.RegisterEventHandler(bridgeabi.ErcToNativeTransfer, handlers.HandleErcToNativeTransfer)
 	m.foreignMonitor.RegisterEventHandler(bridgeabi.ErcToNativeRelayedMessage, handlers.HandleErcToNativeRelayedMessage)
+	m.foreignMonitor.RegisterEventPrefetcher(bridgeabi.ErcToNativeRelayedMessage, handlers.PrefetchForeignReceipt)
+	m.foreignMonitor.RegisterEventHandler(bridgeabi.ErcToNativeTokensSwapped, handlers.HandleErcToNativeTokensSwapped)
+	m.foreignMonitor.RegisterEventHandler(bridgeabi.ErcToNativePaidInterest, handlers.HandleErcToNativePaidInterest)
 	m.foreignMonitor.RegisterEventHandler(bridgeabi.ValidatorAdded, handlers.HandleValidatorAdded)
 	m.foreignMonitor.RegisterEventHandler(bridgeabi.ValidatorRemoved, handlers.HandleValidatorRemoved)
 }
 
 func (m *Monitor) RegisterAMBEventHandlers() {
-	handlers := NewBridgeEventHandler(m.repo, m.cfg, m.homeMonitor.client)
+	handlers := NewBridgeEventHandler(m.repo, m.cfg, m.homeMonitor.client, m.foreignMonitor.client)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.UserRequestForSignature, handlers.HandleUserRequestForSignature)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.LegacyUserRequestForSignature, handlers.HandleLegacyUserRequestForSignature)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.SignedForUserRequest, handlers.HandleSignedForUserRequest)
@@ -87,6 +117,8 @@ func (m *Monitor) RegisterAMBEventHandlers() {
 	m.homeMonitor.RegisterEventHandler(bridgeabi.SignedForAffirmation, handlers.HandleSignedForUserRequest)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.AffirmationCompleted, handlers.HandleAffirmationCompleted)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.LegacyAffirmationCompleted, handlers.HandleAffirmationCompleted)
+	m.homeMonitor.RegisterEventPrefetcher(bridgeabi.AffirmationCompleted, handlers.PrefetchHomeFailedExecution)
+	m.homeMonitor.RegisterEventPrefetcher(bridgeabi.LegacyAffirmationCompleted, handlers.PrefetchHomeFailedExecution)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.UserRequestForInformation, handlers.HandleUserRequestForInformation)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.SignedForInformation, handlers.HandleSignedForInformation)
 	m.homeMonitor.RegisterEventHandler(bridgeabi.InformationRetrieved, handlers.HandleInformationRetrieved)
@@ -97,15 +129,138 @@ func (m *Monitor) RegisterAMBEventHandlers() {
 	m.foreignMonitor.RegisterEventHandler(bridgeabi.LegacyUserRequestForAffirmation, handlers.HandleLegacyUserRequestForAffirmation)
 	m.foreignMonitor.RegisterEventHandler(bridgeabi.RelayedMessage, handlers.HandleRelayedMessage)
 	m.foreignMonitor.RegisterEventHandler(bridgeabi.LegacyRelayedMessage, handlers.HandleRelayedMessage)
+	m.foreignMonitor.RegisterEventPrefetcher(bridgeabi.RelayedMessage, handlers.PrefetchForeignFailedExecution)
+	m.foreignMonitor.RegisterEventPrefetcher(bridgeabi.LegacyRelayedMessage, handlers.PrefetchForeignFailedExecution)
 	m.foreignMonitor.RegisterEventHandler(bridgeabi.ValidatorAdded, handlers.HandleValidatorAdded)
 	m.foreignMonitor.RegisterEventHandler(bridgeabi.ValidatorRemoved, handlers.HandleValidatorRemoved)
 }
 
 func (m *Monitor) Start(ctx context.Context) {
 	m.logger.Info("starting bridge monitor")
-	go m.homeMonitor.Start(ctx)
-	go m.foreignMonitor.Start(ctx)
-	go m.alertManager.Start(ctx, m.IsSynced)
+	m.homeMonitor.Start(ctx)
+	m.foreignMonitor.Start(ctx)
+	m.startJob(ctx, m.balanceMonitor.Start)
+	if m.limitsMonitor != nil {
+		m.startJob(ctx, m.limitsMonitor.Start)
+	}
+	if m.sigVerifier != nil {
+		m.startJob(ctx, m.sigVerifier.Start)
+	}
+	if m.simulator != nil {
+		m.startJob(ctx, m.simulator.Start)
+	}
+
+	m.alertsMu.Lock()
+	defer m.alertsMu.Unlock()
+	m.startAlertManager(ctx)
+}
+
+func (m *Monitor) startJob(ctx context.Context, job func(ctx context.Context)) {
+	m.jobs.Add(1)
+	go func() {
+		defer m.jobs.Done()
+		job(ctx)
+	}()
+}
+
+// Shutdown waits for both contract monitors to finish processing of their current logs batches,
+// and for the background jobs to finish their current checks, after the context passed to Start is cancelled.
+func (m *Monitor) Shutdown(ctx context.Context) error {
+	errs := make(chan error, 3)
+	for _, contractMonitor := range []*ContractMonitor{m.homeMonitor, m.foreignMonitor} {
+		go func(contractMonitor *ContractMonitor) {
+			errs <- contractMonitor.Shutdown(ctx)
+		}(contractMonitor)
+	}
+	go func() {
+		errs <- m.waitJobs(ctx)
+	}()
+	var res error
+	for i := 0; i < 3; i++ {
+		if err := <-errs; err != nil && res == nil {
+			res = err
+		}
+	}
+	return res
+}
+
+func (m *Monitor) waitJobs(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		m.jobs.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("background jobs were not finished: %w", ctx.Err())
+	}
+}
+
+func (m *Monitor) BridgeID() string {
+	return m.cfg.ID
+}
+
+// Balance returns the native balance of the bridge validator or relayer, collected by the balance monitor.
+func (m *Monitor) Balance(chainID string, addr common.Address) (*big.Int, bool) {
+	return m.balanceMonitor.Balance(chainID, addr)
+}
+
+// Limits returns the bridge limits of the given chain, collected by the limits monitor.
+// Limits are not available if the limits monitoring is disabled.
+func (m *Monitor) Limits(chainID string, token *common.Address) (*contract.BridgeLimits, bool) {
+	if m.limitsMonitor == nil {
+		return nil, false
+	}
+	return m.limitsMonitor.Limits(chainID, token)
+}
+
+// startAlertManager should be called with m.alertsMu held.
+func (m *Monitor) startAlertManager(ctx context.Context) {
+	alertsCtx, cancel := context.WithCancel(ctx)
+	m.alertsCancel = cancel
+	go m.alertManager.Start(alertsCtx, m.IsSynced)
+}
+
+// UpdateAlerts stops current alert manager jobs and starts the new ones for the given alerts config.
+// In case of an error, previous alert manager is kept running.
+func (m *Monitor) UpdateAlerts(ctx context.Context, alertsCfg map[string]*config.BridgeAlertConfig) error {
+	m.alertsMu.Lock()
+	defer m.alertsMu.Unlock()
+
+	if m.alertsCancel != nil {
+		m.alertsCancel()
+	}
+	m.alertManager.UnregisterMetrics()
+
+	cfg := *m.cfg
+	cfg.Alerts = alertsCfg
+	alertManager, err := alerts.NewAlertManager(m.logger, m.dbConn, &cfg)
+	if err != nil {
+		if err2 := m.alertManager.RegisterMetrics(); err2 != nil {
+			m.logger.WithError(err2).Error("can't restore previous alert metrics")
+		}
+		m.startAlertManager(ctx)
+		return fmt.Errorf("failed to initialize alert manager: %w", err)
+	}
+	m.alertManager = alertManager
+	m.startAlertManager(ctx)
+	return nil
+}
+
+// UnregisterMetrics removes contract, balance, limits and alert metrics of the stopped monitor.
+func (m *Monitor) UnregisterMetrics() {
+	m.homeMonitor.UnregisterMetrics()
+	m.foreignMonitor.UnregisterMetrics()
+	m.balanceMonitor.UnregisterMetrics()
+	if m.limitsMonitor != nil {
+		m.limitsMonitor.UnregisterMetrics()
+	}
+
+	m.alertsMu.Lock()
+	defer m.alertsMu.Unlock()
+	m.alertManager.UnregisterMetrics()
 }
 
 func (m *Monitor) ProcessBlockRange(ctx context.Context, home bool, fromBlock, toBlock uint) error {
@@ -0,0 +1,77 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/logging"
+)
+
+// prefetchedData holds RPC responses requested for the logs batch before its transaction is started,
+// so that event handlers don't make slow RPC requests while the database transaction is open.
+type prefetchedData struct {
+	receipts map[common.Hash]*types.Receipt
+	// traces contains nil for the transactions which can't be traced.
+	traces map[common.Hash]*ethclient.CallFrame
+}
+
+type prefetchedDataCtxKey struct{}
+
+func withPrefetchedData(ctx context.Context) context.Context {
+	return context.WithValue(ctx, prefetchedDataCtxKey{}, &prefetchedData{
+		receipts: make(map[common.Hash]*types.Receipt),
+		traces:   make(map[common.Hash]*ethclient.CallFrame),
+	})
+}
+
+// prefetchedDataFromContext returns prefetched data of the current logs batch,
+// empty data is returned for handlers called outside the logs batch processing.
+func prefetchedDataFromContext(ctx context.Context) *prefetchedData {
+	if data, ok := ctx.Value(prefetchedDataCtxKey{}).(*prefetchedData); ok {
+		return data
+	}
+	return &prefetchedData{
+		receipts: make(map[common.Hash]*types.Receipt),
+		traces:   make(map[common.Hash]*ethclient.CallFrame),
+	}
+}
+
+func prefetchReceipt(ctx context.Context, client ethclient.Client, txHash common.Hash) error {
+	data := prefetchedDataFromContext(ctx)
+	if _, ok := data.receipts[txHash]; ok {
+		return nil
+	}
+	receipt, err := client.TransactionReceiptByHash(ctx, txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction receipt by hash %s: %w", txHash, err)
+	}
+	data.receipts[txHash] = receipt
+	return nil
+}
+
+// prefetchTrace is best-effort, since debug_traceTransaction is not available on most RPC nodes and for pruned transactions.
+func prefetchTrace(ctx context.Context, client ethclient.Client, txHash common.Hash) {
+	data := prefetchedDataFromContext(ctx)
+	if _, ok := data.traces[txHash]; ok {
+		return
+	}
+	trace, err := client.TraceTransaction(ctx, txHash)
+	if err != nil {
+		logging.LoggerFromContext(ctx).WithError(err).WithField("tx_hash", txHash).
+			Warn("can't trace failed message execution, revert reason is unknown")
+		trace = nil
+	}
+	data.traces[txHash] = trace
+}
+
+// transactionReceipt returns the prefetched receipt, or requests it if the receipt was not prefetched.
+func transactionReceipt(ctx context.Context, client ethclient.Client, txHash common.Hash) (*types.Receipt, error) {
+	if err := prefetchReceipt(ctx, client, txHash); err != nil {
+		return nil, err
+	}
+	return prefetchedDataFromContext(ctx).receipts[txHash], nil
+}
@@ -0,0 +1,160 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/utils"
+)
+
+// SignatureVerifier checks signatures of the collected home-to-foreign messages, as they are stored
+// in the home bridge contract. Signers are recovered and stored per message, together with
+// the result of the message payload check, invalid ones are reported by the alert manager.
+type SignatureVerifier struct {
+	logger   logging.Logger
+	repo     *repository.Repo
+	cfg      *config.BridgeConfig
+	contract *contract.BridgeContract
+}
+
+func NewSignatureVerifier(logger logging.Logger, repo *repository.Repo, cfg *config.BridgeConfig, homeClient ethclient.Client) *SignatureVerifier {
+	return &SignatureVerifier{
+		logger:   logger,
+		repo:     repo,
+		cfg:      cfg,
+		contract: contract.NewBridgeContract(homeClient, cfg.Home.Address, cfg.BridgeMode),
+	}
+}
+
+func (v *SignatureVerifier) Start(ctx context.Context) {
+	v.logger.Info("starting collected signatures verifier")
+	for {
+		n, err := v.VerifyBatch(ctx)
+		if err != nil {
+			v.logger.WithError(err).Error("can't verify collected signatures")
+		}
+		// full batch means there might be more unverified messages left, so the next one is verified right away
+		if err == nil && n == v.cfg.SignatureVerification.BatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		if utils.ContextSleep(ctx, v.cfg.SignatureVerification.Interval) == nil {
+			return
+		}
+	}
+}
+
+// maxVerifyRetryDelay limits the backoff of the repeatedly failing message verifications.
+const maxVerifyRetryDelay = 24 * time.Hour
+
+// VerifyBatch verifies signatures of the oldest unverified collected messages,
+// it returns the number of verified messages. Messages failing verification are logged and retried later with backoff,
+// so that they don't block verification of the newer messages.
+func (v *SignatureVerifier) VerifyBatch(ctx context.Context) (uint, error) {
+	msgs, err := v.repo.CollectedMessages.FindUnverified(ctx, v.cfg.ID, v.cfg.SignatureVerification.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	var n uint
+	for _, msg := range msgs {
+		if err = v.Verify(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return n, ctx.Err()
+			}
+			retryAfter := v.verifyRetryDelay(msg.VerifyAttempts)
+			v.logger.WithError(err).WithFields(logrus.Fields{
+				"msg_hash":    msg.MsgHash,
+				"attempts":    msg.VerifyAttempts + 1,
+				"retry_after": retryAfter,
+			}).Error("can't verify collected signatures of the message, retrying later")
+			if err = v.repo.CollectedMessages.SetVerifyFailed(ctx, msg.LogID, retryAfter); err != nil {
+				return n, err
+			}
+			continue
+		}
+		n++
+	}
+	return n, nil
+}
+
+// verifyRetryDelay doubles the verification interval on each failed attempt.
+func (v *SignatureVerifier) verifyRetryDelay(attempts uint) time.Duration {
+	delay := v.cfg.SignatureVerification.Interval
+	for i := uint(0); i < attempts && delay < maxVerifyRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxVerifyRetryDelay {
+		return maxVerifyRetryDelay
+	}
+	return delay
+}
+
+// Verify fetches the message and its collected signatures from the home bridge contract,
+// recovers and stores the signers and marks the collected message as verified.
+func (v *SignatureVerifier) Verify(ctx context.Context, msg *entity.CollectedMessage) error {
+	logger := v.logger.WithField("msg_hash", msg.MsgHash)
+
+	data, err := v.contract.Message(ctx, msg.MsgHash)
+	if err != nil {
+		return fmt.Errorf("can't get message %s: %w", msg.MsgHash, err)
+	}
+	valid := crypto.Keccak256Hash(data) == msg.MsgHash
+	if !valid {
+		logger.Warn("home bridge contract message does not match the collected message hash")
+	}
+
+	sigs := make([]*entity.CollectedSignature, msg.NumSignatures)
+	for i := range sigs {
+		sig, err2 := v.contract.Signature(ctx, msg.MsgHash, uint(i))
+		if err2 != nil {
+			return fmt.Errorf("can't get signature %d of message %s: %w", i, msg.MsgHash, err2)
+		}
+		sigs[i] = &entity.CollectedSignature{
+			LogID:     msg.LogID,
+			BridgeID:  v.cfg.ID,
+			MsgHash:   msg.MsgHash,
+			Index:     uint(i),
+			Signer:    v.recoverSigner(ctx, logger, data, sig),
+			Signature: sig,
+		}
+	}
+
+	return v.repo.InTransaction(ctx, func(ctx context.Context) error {
+		for _, sig := range sigs {
+			if err2 := v.repo.CollectedSignatures.Ensure(ctx, sig); err2 != nil {
+				return err2
+			}
+		}
+		return v.repo.CollectedMessages.SetMessageValid(ctx, msg.LogID, valid)
+	})
+}
+
+// recoverSigner returns the signer of the message, or a zero address if the signature is malformed.
+func (v *SignatureVerifier) recoverSigner(ctx context.Context, logger logging.Logger, data, sig []byte) common.Address {
+	// RestoreSignerAddress normalizes the recovery id in place, while the original signature is stored
+	signer, err := utils.RestoreSignerAddress(data, common.CopyBytes(sig))
+	if err != nil {
+		logger.WithError(err).Warn("can't recover signer of the collected signature")
+		return common.Address{}
+	}
+	_, err = v.repo.BridgeValidators.GetActiveValidator(ctx, v.cfg.ID, v.cfg.Home.Chain.ChainID, signer)
+	if errors.Is(err, db.ErrNotFound) {
+		logger.WithField("signer", signer).Warn("collected signature is not made by an active bridge validator")
+	}
+	return signer
+}
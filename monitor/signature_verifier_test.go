@@ -0,0 +1,133 @@
+package monitor_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract/bridgeabi"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/ethclient/fakechain"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/monitor"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
+)
+
+func TestSignatureVerifier_VerifyBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := newTestBridge(t, config.BridgeModeArbitraryMessage, repository.NewMemoryRepo(memory.NewStore()), nil)
+	b.cfg.SignatureVerification = &config.SignatureVerificationConfig{BatchSize: 10}
+	bridgeABI := bridgeabi.ArbitraryMessageABI
+
+	validatorKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	unknownKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	validator := crypto.PubkeyToAddress(validatorKey.PublicKey)
+	require.NoError(t, b.repo.BridgeValidators.Ensure(ctx, &entity.BridgeValidator{LogID: 1, BridgeID: b.cfg.ID, ChainID: b.home.ChainID(), Address: validator}))
+
+	setCallResult := func(method string, result []byte, args ...interface{}) {
+		data, err2 := bridgeABI.Pack(method, args...)
+		require.NoError(t, err2)
+		res, err2 := bridgeABI.Methods[method].Outputs.Pack(result)
+		require.NoError(t, err2)
+		b.home.SetCallResult(homeBridgeAddress, data, res)
+	}
+	sign := func(data []byte, key *ecdsa.PrivateKey) []byte {
+		sig, err2 := crypto.Sign(accounts.TextHash(data), key)
+		require.NoError(t, err2)
+		sig[64] += 27
+		return sig
+	}
+
+	// first message is signed by the validator and by the unknown signer
+	msg := []byte("collected message")
+	msgHash := crypto.Keccak256Hash(msg)
+	setCallResult("message", msg, msgHash)
+	setCallResult("signature", sign(msg, validatorKey), msgHash, big.NewInt(0))
+	setCallResult("signature", sign(msg, unknownKey), msgHash, big.NewInt(1))
+	require.NoError(t, b.repo.CollectedMessages.Ensure(ctx, &entity.CollectedMessage{LogID: 10, BridgeID: b.cfg.ID, MsgHash: msgHash, NumSignatures: 2}))
+
+	// second message payload in the contract doesn't match its hash
+	otherHash := common.HexToHash("0x01")
+	setCallResult("message", msg, otherHash)
+	setCallResult("signature", sign(msg, validatorKey), otherHash, big.NewInt(0))
+	require.NoError(t, b.repo.CollectedMessages.Ensure(ctx, &entity.CollectedMessage{LogID: 11, BridgeID: b.cfg.ID, MsgHash: otherHash, NumSignatures: 1}))
+
+	v := monitor.NewSignatureVerifier(logging.NullLogger(), b.repo, b.cfg, b.home)
+	n, err := v.VerifyBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(2), n)
+
+	sigs, err := b.repo.CollectedSignatures.FindByMsgHash(ctx, b.cfg.ID, msgHash)
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+	require.Equal(t, validator, sigs[0].Signer)
+	require.Equal(t, crypto.PubkeyToAddress(unknownKey.PublicKey), sigs[1].Signer)
+
+	collected, err := b.repo.CollectedMessages.GetByMsgHash(ctx, b.cfg.ID, msgHash)
+	require.NoError(t, err)
+	require.True(t, *collected.MessageValid)
+	collected, err = b.repo.CollectedMessages.GetByMsgHash(ctx, b.cfg.ID, otherHash)
+	require.NoError(t, err)
+	require.False(t, *collected.MessageValid)
+
+	n, err = v.VerifyBatch(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestSignatureVerifier_VerifyBatch_Failure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := newTestBridge(t, config.BridgeModeArbitraryMessage, repository.NewMemoryRepo(memory.NewStore()), nil)
+	b.cfg.SignatureVerification = &config.SignatureVerificationConfig{BatchSize: 10, Interval: time.Minute}
+	bridgeABI := bridgeabi.ArbitraryMessageABI
+
+	// message() call of the first message keeps failing
+	failingHash := common.HexToHash("0x01")
+	data, err := bridgeABI.Pack("message", failingHash)
+	require.NoError(t, err)
+	b.home.SetCallError(homeBridgeAddress, data, fakechain.NewRevertError("failure"))
+	require.NoError(t, b.repo.CollectedMessages.Ensure(ctx, &entity.CollectedMessage{LogID: 10, BridgeID: b.cfg.ID, MsgHash: failingHash, NumSignatures: 1}))
+
+	msg := []byte("collected message")
+	msgHash := crypto.Keccak256Hash(msg)
+	data, err = bridgeABI.Pack("message", msgHash)
+	require.NoError(t, err)
+	res, err := bridgeABI.Methods["message"].Outputs.Pack(msg)
+	require.NoError(t, err)
+	b.home.SetCallResult(homeBridgeAddress, data, res)
+	require.NoError(t, b.repo.CollectedMessages.Ensure(ctx, &entity.CollectedMessage{LogID: 11, BridgeID: b.cfg.ID, MsgHash: msgHash}))
+
+	v := monitor.NewSignatureVerifier(logging.NullLogger(), b.repo, b.cfg, b.home)
+	n, err := v.VerifyBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(1), n)
+
+	collected, err := b.repo.CollectedMessages.GetByMsgHash(ctx, b.cfg.ID, msgHash)
+	require.NoError(t, err)
+	require.True(t, *collected.MessageValid)
+	failing, err := b.repo.CollectedMessages.GetByMsgHash(ctx, b.cfg.ID, failingHash)
+	require.NoError(t, err)
+	require.Nil(t, failing.MessageValid)
+	require.Equal(t, uint(1), failing.VerifyAttempts)
+	require.True(t, failing.VerifyRetryAt.After(time.Now()))
+
+	// failing message is not retried until its retry time
+	unverified, err := b.repo.CollectedMessages.FindUnverified(ctx, b.cfg.ID, 10)
+	require.NoError(t, err)
+	require.Empty(t, unverified)
+}
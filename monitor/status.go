@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractStatus describes indexing progress of a single bridge contract.
+type ContractStatus struct {
+	ChainID        string         `json:"chain_id"`
+	Address        common.Address `json:"address"`
+	HeadBlock      uint           `json:"head_block"`
+	HeadUpdatedAt  *time.Time     `json:"head_updated_at"`
+	HeadFresh      bool           `json:"head_fresh"`
+	FetchedBlock   uint           `json:"fetched_block"`
+	ProcessedBlock uint           `json:"processed_block"`
+	LagBlocks      uint           `json:"lag_blocks"`
+	// LagSeconds is the age of the last processed block, reported only while processing is behind the head.
+	LagSeconds     uint       `json:"lag_seconds"`
+	Synced         bool       `json:"synced"`
+	LastRPCError   string     `json:"last_rpc_error,omitempty"`
+	LastRPCErrorAt *time.Time `json:"last_rpc_error_at,omitempty"`
+}
+
+// BridgeStatus describes indexing progress of both bridge sides.
+type BridgeStatus struct {
+	BridgeID string          `json:"bridge_id"`
+	Synced   bool            `json:"synced"`
+	Home     *ContractStatus `json:"home"`
+	Foreign  *ContractStatus `json:"foreign"`
+}
+
+// IsReady checks that both bridge sides are synced and their chain heads are regularly updated.
+func (s *BridgeStatus) IsReady() bool {
+	return s.Home.Synced && s.Home.HeadFresh && s.Foreign.Synced && s.Foreign.HeadFresh
+}
+
+func (m *Monitor) Status() *BridgeStatus {
+	home := m.homeMonitor.Status()
+	foreign := m.foreignMonitor.Status()
+	return &BridgeStatus{
+		BridgeID: m.cfg.ID,
+		Synced:   home.Synced && foreign.Synced,
+		Home:     home,
+		Foreign:  foreign,
+	}
+}
@@ -0,0 +1,237 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/repository"
+)
+
+// defaultRestartDrainTimeout limits the time spent on waiting for the current logs batches,
+// when monitor of the removed or modified bridge is stopped.
+const defaultRestartDrainTimeout = 30 * time.Second
+
+var ErrReloadFailed = errors.New("failed to apply some bridge configs")
+
+type supervisedMonitor struct {
+	cfg          *config.BridgeConfig
+	monitor      *Monitor
+	ctx          context.Context //nolint:containedctx
+	cancel       context.CancelFunc
+	closeClients func()
+}
+
+// Supervisor runs bridge monitors for the active bridges of the config.
+// On config reload, only monitors of the added, removed or modified bridges are affected.
+type Supervisor struct {
+	logger logging.Logger
+	dbConn *db.DB
+	repo   *repository.Repo
+
+	// applyMu serializes Apply and Shutdown, so that a restarted monitor is started only after the old one is stopped.
+	applyMu sync.Mutex
+	// mu guards monitors map only, it is never held while monitors are drained.
+	mu       sync.Mutex
+	monitors map[string]*supervisedMonitor
+}
+
+func NewSupervisor(logger logging.Logger, dbConn *db.DB, repo *repository.Repo) *Supervisor {
+	return &Supervisor{
+		logger:   logger,
+		dbConn:   dbConn,
+		repo:     repo,
+		monitors: make(map[string]*supervisedMonitor),
+	}
+}
+
+// Monitors returns currently running bridge monitors, ordered by bridge id.
+func (s *Supervisor) Monitors() []*Monitor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.monitors))
+	for id := range s.monitors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	res := make([]*Monitor, len(ids))
+	for i, id := range ids {
+		res[i] = s.monitors[id].monitor
+	}
+	return res
+}
+
+// Apply diffs the given config with the currently running monitors:
+// monitors of the new bridges are started, monitors of the removed bridges are stopped,
+// monitors of the bridges with modified chain or contract settings are restarted,
+// and alert jobs are rebuilt for the bridges with modified alerts only.
+// Bridges, which failed to be started or updated, are reported in the returned error.
+//
+//nolint:cyclop
+func (s *Supervisor) Apply(ctx context.Context, cfg *config.Config) error {
+	s.applyMu.Lock()
+	defer s.applyMu.Unlock()
+
+	bridges := cfg.ActiveBridges()
+	var failed []string
+	stopped := make(map[string]*supervisedMonitor)
+	for id, running := range s.snapshot() {
+		bridgeCfg, ok := bridges[id]
+		switch {
+		case !ok:
+			s.logger.WithField("bridge_id", id).Info("bridge was removed from config, stopping bridge monitor")
+			stopped[id] = running
+		case !isSameBridgeConfig(running.cfg, bridgeCfg):
+			s.logger.WithField("bridge_id", id).Info("bridge config was modified, restarting bridge monitor")
+			stopped[id] = running
+		case !reflect.DeepEqual(running.cfg.Alerts, bridgeCfg.Alerts):
+			s.logger.WithField("bridge_id", id).Info("bridge alerts config was modified, rebuilding alert jobs")
+			if err := running.monitor.UpdateAlerts(running.ctx, bridgeCfg.Alerts); err != nil {
+				s.logger.WithError(err).WithField("bridge_id", id).Error("can't update bridge alerts")
+				failed = append(failed, id)
+				continue
+			}
+			running.cfg = bridgeCfg
+		}
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), defaultRestartDrainTimeout)
+	defer cancel()
+	for id, err := range s.stop(drainCtx, stopped) {
+		s.logger.WithError(err).WithField("bridge_id", id).Warn("bridge monitor was not stopped gracefully")
+	}
+
+	running := s.snapshot()
+	for id, bridgeCfg := range bridges {
+		if _, ok := running[id]; ok {
+			continue
+		}
+		if err := s.start(ctx, bridgeCfg); err != nil {
+			s.logger.WithError(err).WithField("bridge_id", id).Error("can't start bridge monitor")
+			failed = append(failed, id)
+		}
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("%w: %v", ErrReloadFailed, failed)
+	}
+	return nil
+}
+
+// Shutdown stops all running monitors and waits for them to finish processing of their current logs batches.
+// If the given context expires first, remaining batches are aborted.
+func (s *Supervisor) Shutdown(ctx context.Context) error {
+	s.applyMu.Lock()
+	defer s.applyMu.Unlock()
+
+	errs := s.stop(ctx, s.snapshot())
+	ids := make([]string, 0, len(errs))
+	for id := range errs {
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	sort.Strings(ids)
+	return fmt.Errorf("bridge monitor %s: %w", ids[0], errs[ids[0]])
+}
+
+// snapshot returns a copy of the running monitors map.
+func (s *Supervisor) snapshot() map[string]*supervisedMonitor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make(map[string]*supervisedMonitor, len(s.monitors))
+	for id, running := range s.monitors {
+		res[id] = running
+	}
+	return res
+}
+
+// start should be called with s.applyMu held.
+func (s *Supervisor) start(ctx context.Context, cfg *config.BridgeConfig) error {
+	bridgeLogger := s.logger.WithField("bridge_id", cfg.ID)
+	homeClient, err := ethclient.NewClient(cfg.Home.Chain.RPC.Host.Value(), cfg.Home.Chain.RPC.Timeout, cfg.Home.Chain.ChainID)
+	if err != nil {
+		return fmt.Errorf("can't dial home rpc client: %w", err)
+	}
+	foreignClient, err := ethclient.NewClient(cfg.Foreign.Chain.RPC.Host.Value(), cfg.Foreign.Chain.RPC.Timeout, cfg.Foreign.Chain.ChainID)
+	if err != nil {
+		homeClient.Close()
+		return fmt.Errorf("can't dial foreign rpc client: %w", err)
+	}
+
+	bridgeCtx, cancel := context.WithCancel(ctx)
+	m, err := NewMonitor(bridgeCtx, bridgeLogger, s.dbConn, s.repo, cfg, homeClient, foreignClient)
+	if err != nil {
+		cancel()
+		homeClient.Close()
+		foreignClient.Close()
+		return fmt.Errorf("can't initialize bridge monitor: %w", err)
+	}
+	m.Start(bridgeCtx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.monitors[cfg.ID] = &supervisedMonitor{
+		cfg:     cfg,
+		monitor: m,
+		ctx:     bridgeCtx,
+		cancel:  cancel,
+		closeClients: func() {
+			homeClient.Close()
+			foreignClient.Close()
+		},
+	}
+	return nil
+}
+
+// stop removes the given monitors from the running ones and drains them concurrently, without holding s.mu,
+// so that Monitors is not blocked by slow logs batches. It returns shutdown errors by bridge id.
+// stop should be called with s.applyMu held.
+func (s *Supervisor) stop(ctx context.Context, monitors map[string]*supervisedMonitor) map[string]error {
+	s.mu.Lock()
+	for id, running := range monitors {
+		running.cancel()
+		delete(s.monitors, id)
+	}
+	s.mu.Unlock()
+
+	type result struct {
+		id  string
+		err error
+	}
+	results := make(chan result, len(monitors))
+	for id, running := range monitors {
+		go func(id string, running *supervisedMonitor) {
+			results <- result{id: id, err: running.monitor.Shutdown(ctx)}
+		}(id, running)
+	}
+	errs := make(map[string]error)
+	for range monitors {
+		if res := <-results; res.err != nil {
+			errs[res.id] = res.err
+		}
+	}
+	for _, running := range monitors {
+		running.closeClients()
+		running.monitor.UnregisterMetrics()
+	}
+	return errs
+}
+
+// isSameBridgeConfig compares bridge configs, ignoring alerts config.
+func isSameBridgeConfig(a, b *config.BridgeConfig) bool {
+	x, y := *a, *b
+	x.Alerts, y.Alerts = nil, nil
+	return reflect.DeepEqual(&x, &y)
+}
@@ -1,5 +1,16 @@
 package monitor
 
+import "github.com/ethereum/go-ethereum/common"
+
 func uintPtr(v uint) *uint {
 	return &v
 }
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, addr := range addresses {
+		if addr == address {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/presenter"
+)
+
+var ErrUnexpectedStatus = errors.New("unexpected response status")
+
+// Client is a typed client for the presenter HTTP API described in presenter/openapi.json.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	apiKey     string
+}
+
+type SearchFilter struct {
+	ChainID   string
+	FromBlock *uint
+	ToBlock   *uint
+	TxHash    *common.Hash
+}
+
+type SearchResult struct {
+	Event         *presenter.EventInfo
+	Message       json.RawMessage
+	RelatedEvents []*presenter.EventInfo
+}
+
+type UnsignedMessagesInfo struct {
+	RequiredSignatures    uint
+	ActiveValidators      []common.Address
+	TotalPendingMessages  uint
+	TotalUnsignedMessages uint
+	UnsignedMessages      []*UnsignedMessageInfo
+}
+
+type UnsignedMessageInfo struct {
+	Message        json.RawMessage
+	Link           string
+	Signers        []common.Address
+	MissingSigners []common.Address
+}
+
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// WithAPIKey returns a copy of the client, which passes the given API key in all requests.
+func (c *Client) WithAPIKey(apiKey string) *Client {
+	res := *c
+	res.apiKey = apiKey
+	return &res
+}
+
+// DecodeMessage decodes raw bridge message returned by the API into one of
+// presenter.MessageInfo, presenter.ErcToNativeMessageInfo or presenter.InformationRequestInfo.
+func DecodeMessage[T presenter.MessageInfo | presenter.ErcToNativeMessageInfo | presenter.InformationRequestInfo](raw json.RawMessage) (*T, error) {
+	res := new(T)
+	if err := json.Unmarshal(raw, res); err != nil {
+		return nil, fmt.Errorf("can't decode message: %w", err)
+	}
+	return res, nil
+}
+
+func (c *Client) GetBridgeInfo(ctx context.Context, bridgeID string) (*presenter.BridgeInfo, error) {
+	res := new(presenter.BridgeInfo)
+	err := c.get(ctx, "/bridge/"+url.PathEscape(bridgeID)+"/info", nil, res)
+	return res, err
+}
+
+func (c *Client) GetBridgeConfig(ctx context.Context, bridgeID string) (*config.BridgeConfig, error) {
+	res := new(config.BridgeConfig)
+	err := c.get(ctx, "/bridge/"+url.PathEscape(bridgeID)+"/config", nil, res)
+	return res, err
+}
+
+func (c *Client) GetBridgeValidators(ctx context.Context, bridgeID string) (*presenter.ValidatorsInfo, error) {
+	res := new(presenter.ValidatorsInfo)
+	err := c.get(ctx, "/bridge/"+url.PathEscape(bridgeID)+"/validators", nil, res)
+	return res, err
+}
+
+func (c *Client) GetPendingMessages(ctx context.Context, bridgeID string) ([]json.RawMessage, error) {
+	var res []json.RawMessage
+	err := c.get(ctx, "/bridge/"+url.PathEscape(bridgeID)+"/pending", nil, &res)
+	return res, err
+}
+
+func (c *Client) GetMessageStats(ctx context.Context, bridgeID string) (*presenter.MessageStatsInfo, error) {
+	res := new(presenter.MessageStatsInfo)
+	err := c.get(ctx, "/bridge/"+url.PathEscape(bridgeID)+"/stats", nil, res)
+	return res, err
+}
+
+// GetTokenBalances returns locked, invested and claimed amounts of the bridged tokens of the ERC_TO_NATIVE bridge.
+func (c *Client) GetTokenBalances(ctx context.Context, bridgeID string) (*presenter.TokenBalancesInfo, error) {
+	res := new(presenter.TokenBalancesInfo)
+	err := c.get(ctx, "/bridge/"+url.PathEscape(bridgeID)+"/balances", nil, res)
+	return res, err
+}
+
+// GetExecuteSignaturesTx returns unsigned executeSignatures transaction of the collected home-to-foreign message.
+// If simulate is set, the transaction is also executed with eth_call on behalf of the given sender.
+func (c *Client) GetExecuteSignaturesTx(ctx context.Context, bridgeID string, msgHash common.Hash, simulate bool, from common.Address) (*presenter.ExecuteSignaturesTxInfo, error) {
+	var q url.Values
+	if simulate {
+		q = url.Values{"simulate": {"true"}, "from": {from.String()}}
+	}
+	res := new(presenter.ExecuteSignaturesTxInfo)
+	err := c.get(ctx, "/bridge/"+url.PathEscape(bridgeID)+"/execute/"+msgHash.String(), q, res)
+	return res, err
+}
+
+// GetMessagesWithMissingSignatures returns pending messages lacking enough signatures.
+// Each item of manualSignatures is a msgHash to signature mapping, collected from the validators manually.
+func (c *Client) GetMessagesWithMissingSignatures(ctx context.Context, bridgeID string, manualSignatures ...map[common.Hash]hexutil.Bytes) (*UnsignedMessagesInfo, error) {
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+	for i, sigs := range manualSignatures {
+		fw, err := mw.CreateFormFile("signatures", fmt.Sprintf("signatures-%d.json", i))
+		if err != nil {
+			return nil, fmt.Errorf("can't create form file: %w", err)
+		}
+		if err = json.NewEncoder(fw).Encode(sigs); err != nil {
+			return nil, fmt.Errorf("can't encode signatures: %w", err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("can't finalize multipart form: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/bridge/"+url.PathEscape(bridgeID)+"/unsigned", body)
+	if err != nil {
+		return nil, fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	res := new(UnsignedMessagesInfo)
+	err = c.do(req, res)
+	return res, err
+}
+
+func (c *Client) SearchMessages(ctx context.Context, filter SearchFilter) ([]*SearchResult, error) {
+	var res []*SearchResult
+	err := c.get(ctx, "/messages", filter.query(), &res)
+	return res, err
+}
+
+func (c *Client) SearchLogs(ctx context.Context, filter SearchFilter) ([]*presenter.LogInfo, error) {
+	var res []*presenter.LogInfo
+	err := c.get(ctx, "/logs", filter.query(), &res)
+	return res, err
+}
+
+func (f SearchFilter) query() url.Values {
+	q := make(url.Values, 4)
+	if f.ChainID != "" {
+		q.Set("chainId", f.ChainID)
+	}
+	if f.FromBlock != nil {
+		q.Set("fromBlock", strconv.FormatUint(uint64(*f.FromBlock), 10))
+	}
+	if f.ToBlock != nil {
+		q.Set("toBlock", strconv.FormatUint(uint64(*f.ToBlock), 10))
+	}
+	if f.TxHash != nil {
+		q.Set("txHash", f.TxHash.String())
+	}
+	return q
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, res interface{}) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+	return c.do(req, res)
+}
+
+func (c *Client) do(req *http.Request, res interface{}) error {
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't make %s request to %s: %w", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("%s %s returned %d (%s): %w", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(msg)), ErrUnexpectedStatus)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return fmt.Errorf("can't decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/presenter"
+	"github.com/omni/tokenbridge-monitor/presenter/client"
+)
+
+func TestClient_SearchMessages(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/messages", r.URL.Path)
+		require.Equal(t, "100", r.URL.Query().Get("chainId"))
+		require.Equal(t, "10", r.URL.Query().Get("fromBlock"))
+		require.Equal(t, "20", r.URL.Query().Get("toBlock"))
+		_, _ = w.Write([]byte(`[{
+			"Event": {"Action": "SENT_MESSAGE", "BlockNumber": 15, "Link": "link"},
+			"Message": {"BridgeID": "xdai", "Direction": "foreign_to_home", "Sender": "0x0000000000000000000000000000000000000001", "Value": "100"},
+			"RelatedEvents": [{"Action": "SENT_MESSAGE", "BlockNumber": 15, "Link": "link"}]
+		}]`))
+	}))
+	defer srv.Close()
+
+	from, to := uint(10), uint(20)
+	res, err := client.NewClient(srv.URL, nil).SearchMessages(context.Background(), client.SearchFilter{
+		ChainID:   "100",
+		FromBlock: &from,
+		ToBlock:   &to,
+	})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "SENT_MESSAGE", res[0].Event.Action)
+	require.Equal(t, uint(15), res[0].Event.BlockNumber)
+
+	msg, err := client.DecodeMessage[presenter.ErcToNativeMessageInfo](res[0].Message)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x01"), msg.Sender)
+	require.Equal(t, "100", msg.Value)
+}
+
+func TestClient_UnexpectedStatus(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bridge with id unknown not found", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	_, err := client.NewClient(srv.URL, nil).GetBridgeInfo(context.Background(), "unknown")
+	require.ErrorIs(t, err, client.ErrUnexpectedStatus)
+}
+
+func TestClient_WithAPIKey(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secret", r.Header.Get("X-API-Key"))
+		_, _ = w.Write([]byte(`{"BridgeID": "xdai", "Mode": "ERC_TO_NATIVE"}`))
+	}))
+	defer srv.Close()
+
+	res, err := client.NewClient(srv.URL, nil).WithAPIKey("secret").GetBridgeInfo(context.Background(), "xdai")
+	require.NoError(t, err)
+	require.Equal(t, "xdai", res.BridgeID)
+}
+
+type specSchema struct {
+	Ref        string                     `json:"$ref"`
+	Items      *specSchema                `json:"items"`
+	OneOf      []*specSchema              `json:"oneOf"`
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+type openAPISpec struct {
+	Paths map[string]map[string]struct {
+		Responses map[string]struct {
+			Content map[string]struct {
+				Schema *specSchema `json:"schema"`
+			} `json:"content"`
+		} `json:"responses"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]*specSchema `json:"schemas"`
+	} `json:"components"`
+}
+
+func (s *openAPISpec) findPath(method, path string) (string, bool) {
+	segments := strings.Split(path, "/")
+	for specPath, methods := range s.Paths {
+		if _, ok := methods[strings.ToLower(method)]; !ok {
+			continue
+		}
+		specSegments := strings.Split(specPath, "/")
+		if len(specSegments) != len(segments) {
+			continue
+		}
+		matches := true
+		for i, seg := range specSegments {
+			if seg != segments[i] && !(strings.HasPrefix(seg, "{") && seg != "" && segments[i] != "") {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return specPath, true
+		}
+	}
+	return "", false
+}
+
+func (s *openAPISpec) schema(ref string) *specSchema {
+	return s.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+}
+
+// requireTypeMatchesSchema checks that the Go type decodes the referenced schema.
+// Raw JSON messages are used for oneOf schemas, client-defined types are checked field by field.
+// Inline schemas of scalar values are not checked.
+func requireTypeMatchesSchema(t *testing.T, spec *openAPISpec, typ reflect.Type, schema *specSchema) {
+	t.Helper()
+
+	if schema.Ref == "" && schema.Items == nil {
+		return
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == reflect.TypeOf(json.RawMessage{}) {
+		require.NotEmpty(t, spec.schema(schema.Ref).OneOf, "raw message is used for %s, which is not a oneOf schema", schema.Ref)
+		return
+	}
+	if typ.Kind() == reflect.Slice {
+		require.NotNil(t, schema.Items, "%s is not an array schema", typ)
+		requireTypeMatchesSchema(t, spec, typ.Elem(), schema.Items)
+		return
+	}
+	require.Equal(t, "#/components/schemas/"+typ.Name(), schema.Ref)
+	if typ.PkgPath() != reflect.TypeOf(client.Client{}).PkgPath() {
+		// types of other packages are checked against the spec in their own tests
+		return
+	}
+
+	properties := spec.schema(schema.Ref).Properties
+	documented := make([]string, 0, len(properties))
+	for name := range properties {
+		documented = append(documented, name)
+	}
+	fields := make([]string, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fields = append(fields, field.Name)
+		if fieldSchema := new(specSchema); json.Unmarshal(properties[field.Name], fieldSchema) == nil {
+			requireTypeMatchesSchema(t, spec, field.Type, fieldSchema)
+		}
+	}
+	sort.Strings(documented)
+	sort.Strings(fields)
+	require.Equal(t, documented, fields, "schema for %s is out of sync", typ.Name())
+}
+
+func TestClientMatchesOpenAPISpec(t *testing.T) {
+	t.Parallel()
+
+	spec := new(openAPISpec)
+	require.NoError(t, json.Unmarshal(presenter.OpenAPISpec(), spec))
+
+	var method, path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		_, _ = w.Write([]byte("null"))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := client.NewClient(srv.URL, nil)
+	msgHash := common.HexToHash("0x01")
+	calls := map[string]func() (interface{}, error){
+		"GetBridgeInfo":       func() (interface{}, error) { return c.GetBridgeInfo(ctx, "xdai") },
+		"GetBridgeConfig":     func() (interface{}, error) { return c.GetBridgeConfig(ctx, "xdai") },
+		"GetBridgeValidators": func() (interface{}, error) { return c.GetBridgeValidators(ctx, "xdai") },
+		"GetPendingMessages":  func() (interface{}, error) { return c.GetPendingMessages(ctx, "xdai") },
+		"GetMessageStats":     func() (interface{}, error) { return c.GetMessageStats(ctx, "xdai") },
+		"GetTokenBalances":    func() (interface{}, error) { return c.GetTokenBalances(ctx, "xdai") },
+		"GetExecuteSignaturesTx": func() (interface{}, error) {
+			return c.GetExecuteSignaturesTx(ctx, "xdai", msgHash, true, common.HexToAddress("0x02"))
+		},
+		"GetMessagesWithMissingSignatures": func() (interface{}, error) {
+			return c.GetMessagesWithMissingSignatures(ctx, "xdai", map[common.Hash]hexutil.Bytes{msgHash: {1}})
+		},
+		"SearchMessages": func() (interface{}, error) { return c.SearchMessages(ctx, client.SearchFilter{ChainID: "100"}) },
+		"SearchLogs":     func() (interface{}, error) { return c.SearchLogs(ctx, client.SearchFilter{ChainID: "100"}) },
+	}
+
+	// every API method of the client should be checked
+	clientType := reflect.TypeOf(c)
+	for i := 0; i < clientType.NumMethod(); i++ {
+		name := clientType.Method(i).Name
+		if name == "WithAPIKey" {
+			continue
+		}
+		require.Contains(t, calls, name, "client method %s is not checked against the spec", name)
+	}
+
+	for name, call := range calls {
+		res, err := call()
+		require.NoError(t, err, name)
+
+		specPath, ok := spec.findPath(method, path)
+		require.True(t, ok, "%s requests %s %s, which is not documented", name, method, path)
+		content := spec.Paths[specPath][strings.ToLower(method)].Responses["200"].Content["application/json"]
+		require.NotNil(t, content.Schema, "%s %s has no JSON response", method, specPath)
+		requireTypeMatchesSchema(t, spec, reflect.TypeOf(res), content.Schema)
+	}
+}
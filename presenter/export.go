@@ -0,0 +1,200 @@
+package presenter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract"
+	"github.com/omni/tokenbridge-monitor/contract/abi"
+	"github.com/omni/tokenbridge-monitor/contract/bridgeabi"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/presenter/http/middleware"
+	"github.com/omni/tokenbridge-monitor/presenter/http/render"
+)
+
+var (
+	logExportColumns = []string{
+		"log_id", "chain_id", "block_number", "timestamp", "tx_hash", "link", "log_index", "address",
+		"topic0", "topic1", "topic2", "topic3", "data", "event", "decoded",
+	}
+	messageExportColumns = []string{
+		"bridge_id", "action", "chain_id", "block_number", "timestamp", "tx_hash", "link",
+		"message_type", "msg_hash", "message_id", "direction", "sender", "executor", "receiver", "value", "method", "data",
+		"signer", "signatures_count", "status", "callback_status",
+	}
+)
+
+// blockTimeCache remembers timestamp of the last requested block,
+// exported logs are ordered by block number, so most lookups hit the cache.
+type blockTimeCache struct {
+	p           *Presenter
+	chainID     string
+	blockNumber uint
+	timestamp   time.Time
+}
+
+// get returns nil, if block timestamp is not yet indexed.
+func (c *blockTimeCache) get(ctx context.Context, chainID string, blockNumber uint) (*time.Time, error) {
+	if c.chainID != chainID || c.blockNumber != blockNumber {
+		ts, err := c.p.getBlockTimeOrDefault(ctx, chainID, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		c.chainID, c.blockNumber, c.timestamp = chainID, blockNumber, ts
+	}
+	if c.timestamp.IsZero() {
+		return nil, nil
+	}
+	return &c.timestamp, nil
+}
+
+func (p *Presenter) iterateFilteredLogs(w http.ResponseWriter, r *http.Request, format render.Format, name string, columns []string, fn func(*render.RecordWriter, *entity.Log) error) {
+	ctx := r.Context()
+	logsFilter, err := newLogsFilter(middleware.GetFilterContext(ctx))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("can't filter logs: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	rw, err := render.NewRecordWriter(w, format, name, columns)
+	if err != nil {
+		render.Error(w, r, err)
+		return
+	}
+
+	// response status is already sent at this point, so errors can only be logged
+	err = p.repo.Logs.Iterate(ctx, logsFilter, func(log *entity.Log) error {
+		return fn(rw, log)
+	})
+	if err != nil {
+		p.logger.WithError(err).Error("failed to export records")
+		return
+	}
+	if err = rw.Flush(); err != nil {
+		p.logger.WithError(err).Error("failed to flush exported records")
+	}
+}
+
+func (p *Presenter) exportLogs(w http.ResponseWriter, r *http.Request, format render.Format) {
+	timestamps := &blockTimeCache{p: p}
+	p.iterateFilteredLogs(w, r, format, "logs", logExportColumns, func(rw *render.RecordWriter, log *entity.Log) error {
+		ts, err := timestamps.get(r.Context(), log.ChainID, log.BlockNumber)
+		if err != nil {
+			return err
+		}
+		event, decoded := p.decodeLog(log)
+		return rw.Write(
+			log.ID, log.ChainID, log.BlockNumber, ts, log.TransactionHash,
+			p.cfg.GetChainConfig(log.ChainID).FormatTxLink(log.TransactionHash),
+			log.LogIndex, log.Address, log.Topic0, log.Topic1, log.Topic2, log.Topic3,
+			hexutil.Bytes(log.Data), event, decoded,
+		)
+	})
+}
+
+func (p *Presenter) exportMessages(w http.ResponseWriter, r *http.Request, format render.Format) {
+	timestamps := &blockTimeCache{p: p}
+	p.iterateFilteredLogs(w, r, format, "messages", messageExportColumns, func(rw *render.RecordWriter, log *entity.Log) error {
+		res := p.searchForMessageInLog(r.Context(), log)
+		if res == nil {
+			return nil
+		}
+		ts, err := timestamps.get(r.Context(), log.ChainID, log.BlockNumber)
+		if err != nil {
+			return err
+		}
+
+		var messageType, method string
+		var bridgeID string
+		var msgHash, messageID *common.Hash
+		var direction entity.Direction
+		var sender, executor, receiver *common.Address
+		var value string
+		var data hexutil.Bytes
+		switch msg := res.Message.(type) {
+		case *MessageInfo:
+			messageType, bridgeID, direction = "AMB", msg.BridgeID, msg.Direction
+			msgHash, messageID, sender, executor, data = &msg.MsgHash, &msg.MessageID, &msg.Sender, &msg.Executor, msg.Data
+			if msg.Call != nil {
+				method = msg.Call.Method
+			}
+		case *ErcToNativeMessageInfo:
+			messageType, bridgeID, direction = "ERC_TO_NATIVE", msg.BridgeID, msg.Direction
+			msgHash, sender, receiver, value = &msg.MsgHash, &msg.Sender, &msg.Receiver, msg.Value
+		case *InformationRequestInfo:
+			messageType, bridgeID, direction = "INFORMATION_REQUEST", msg.BridgeID, msg.Direction
+			messageID, sender, executor, method, data = &msg.MessageID, &msg.Sender, &msg.Executor, msg.Method, msg.Data
+		}
+
+		var action string
+		var signer *common.Address
+		var count uint
+		var status, callbackStatus bool
+		if e := res.Event; e != nil {
+			action, signer, count, status, callbackStatus = e.Action, e.Signer, e.Count, e.Status, e.CallbackStatus
+		}
+
+		return rw.Write(
+			bridgeID, action, log.ChainID, log.BlockNumber, ts, log.TransactionHash,
+			p.cfg.GetChainConfig(log.ChainID).FormatTxLink(log.TransactionHash),
+			messageType, msgHash, messageID, direction, sender, executor, receiver, value, method, data,
+			signer, count, status, callbackStatus,
+		)
+	})
+}
+
+// findLogABI returns ABI of the bridge contract or the ERC_TO_NATIVE token which emitted the given log.
+func (p *Presenter) findLogABI(log *entity.Log) *abi.ABI {
+	for _, bridgeCfg := range p.cfg.Bridges {
+		for _, side := range []*config.BridgeSideConfig{bridgeCfg.Home, bridgeCfg.Foreign} {
+			if side == nil || side.Chain == nil || side.Chain.ChainID != log.ChainID {
+				continue
+			}
+			if side.Address == log.Address {
+				bridgeABI := contract.BridgeABI(bridgeCfg.BridgeMode)
+				return &bridgeABI
+			}
+			for _, token := range side.ErcToNativeTokens {
+				if token.Address == log.Address {
+					return &bridgeabi.ErcToNativeABI
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// decodeLog returns signature of the event and its decoded parameters as a JSON object.
+func (p *Presenter) decodeLog(log *entity.Log) (string, json.RawMessage) {
+	logABI := p.findLogABI(log)
+	if logABI == nil {
+		return "", nil
+	}
+	event, values, err := logABI.ParseLog(log)
+	if err != nil || event == "" {
+		return "", nil
+	}
+	for k, v := range values {
+		switch value := v.(type) {
+		case *big.Int:
+			values[k] = value.String()
+		case [32]byte:
+			values[k] = common.Hash(value)
+		case []byte:
+			values[k] = hexutil.Bytes(value)
+		}
+	}
+	decoded, err := json.Marshal(values)
+	if err != nil {
+		return event, nil
+	}
+	return event, decoded
+}
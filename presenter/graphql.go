@@ -0,0 +1,528 @@
+package presenter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/presenter/http/middleware"
+	"github.com/omni/tokenbridge-monitor/presenter/http/render"
+)
+
+var ErrInvalidGraphQLArgument = errors.New("invalid graphql argument")
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (p *Presenter) GraphQL(w http.ResponseWriter, r *http.Request) {
+	req := new(graphQLRequest)
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if vars := query.Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				http.Error(w, fmt.Sprintf("can't decode graphql variables: %s", err), http.StatusBadRequest)
+				return
+			}
+		}
+	} else if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(req); err != nil {
+		http.Error(w, fmt.Sprintf("can't decode graphql request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := checkGraphQLQueryLimits(p.graphQLSchema, req.Query, req.OperationName, p.cfg.Presenter.GraphQL); err != nil {
+		render.JSON(w, r, http.StatusOK, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	res := graphql.Do(graphql.Params{
+		Schema:         *p.graphQLSchema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        r.Context(),
+	})
+	render.JSON(w, r, http.StatusOK, res)
+}
+
+func hashArg(args map[string]interface{}, name string) (*common.Hash, error) {
+	str, ok := args[name].(string)
+	if !ok {
+		return nil, nil
+	}
+	b, err := hexutil.Decode(str)
+	if err != nil || len(b) != common.HashLength {
+		return nil, fmt.Errorf("%s should be a 32 bytes hex string: %w", name, ErrInvalidGraphQLArgument)
+	}
+	hash := common.BytesToHash(b)
+	return &hash, nil
+}
+
+func (p *Presenter) graphQLLogsFilter(args map[string]interface{}) (*middleware.FilterContext, error) {
+	filter := new(middleware.FilterContext)
+	if chainID, ok := args["chainId"].(string); ok {
+		if p.cfg.GetChainConfig(chainID) == nil {
+			return nil, fmt.Errorf("chain with id %s not found: %w", chainID, ErrInvalidGraphQLArgument)
+		}
+		filter.ChainID = &chainID
+	}
+	fromBlock, hasFromBlock := args["fromBlock"].(int)
+	toBlock, hasToBlock := args["toBlock"].(int)
+	if hasFromBlock && hasToBlock {
+		if fromBlock < 0 || fromBlock > toBlock {
+			return nil, fmt.Errorf("fromBlock should be less than toBlock: %w", middleware.ErrInvalidBlockNumber)
+		}
+		if toBlock-fromBlock > middleware.MaxBlockRangeSize {
+			return nil, fmt.Errorf("cannot request more than %d blocks in range: %w", middleware.MaxBlockRangeSize, middleware.ErrInvalidBlockNumber)
+		}
+		from, to := uint(fromBlock), uint(toBlock)
+		filter.FromBlock, filter.ToBlock = &from, &to
+	}
+	txHash, err := hashArg(args, "txHash")
+	if err != nil {
+		return nil, err
+	}
+	filter.TxHash = txHash
+	return filter, nil
+}
+
+// graphQLSigner is the Validator object of the event signer, its last confirmation is resolved only when requested.
+type graphQLSigner struct {
+	bridgeID string
+	address  common.Address
+}
+
+func nonNullList(typ graphql.Type) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(typ)))
+}
+
+//nolint:funlen
+func (p *Presenter) newGraphQLSchema() (*graphql.Schema, error) {
+	txType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Tx",
+		Fields: graphql.Fields{
+			"blockNumber": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"timestamp":   &graphql.Field{Type: graphql.DateTime},
+			"link":        &graphql.Field{Type: graphql.String},
+		},
+	})
+	validatorType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Validator",
+		Fields: graphql.Fields{
+			"address": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					switch val := rp.Source.(type) {
+					case *ValidatorInfo:
+						return val.Address, nil
+					case *graphQLSigner:
+						return val.address, nil
+					}
+					return nil, nil
+				},
+			},
+			"lastConfirmation": &graphql.Field{
+				Type: txType,
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					switch val := rp.Source.(type) {
+					case *ValidatorInfo:
+						return val.LastConfirmation, nil
+					case *graphQLSigner:
+						cfg, ok := p.cfg.Bridges[val.bridgeID]
+						if !ok || cfg == nil {
+							return nil, nil
+						}
+						return p.getLastConfirmation(rp.Context, cfg, val.address)
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+	eventType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Event",
+		Fields: graphql.Fields{
+			"action": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"signer": &graphql.Field{
+				Type:        validatorType,
+				Description: "Validator which signed the message, it may be no longer active.",
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					if event, ok := rp.Source.(*EventInfo); ok && event.Signer != nil {
+						return &graphQLSigner{bridgeID: event.BridgeID, address: *event.Signer}, nil
+					}
+					return nil, nil
+				},
+			},
+			"data":             &graphql.Field{Type: graphql.String},
+			"count":            &graphql.Field{Type: graphql.Int},
+			"status":           &graphql.Field{Type: graphql.Boolean},
+			"callbackStatus":   &graphql.Field{Type: graphql.Boolean},
+			"gasUsed":          &graphql.Field{Type: graphql.Int},
+			"revertReason":     &graphql.Field{Type: graphql.String},
+			"failedCallTarget": &graphql.Field{Type: graphql.String},
+			"tx": &graphql.Field{
+				Type: txType,
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					event, ok := rp.Source.(*EventInfo)
+					if !ok {
+						return nil, nil
+					}
+					if event.TxInfo != nil {
+						return event.TxInfo, nil
+					}
+					// events of the message relationship fields are loaded without transaction details
+					return p.getTxInfo(rp.Context, event.LogID)
+				},
+			},
+		},
+	})
+	callArgType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CallArg",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+			"type": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"value": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "JSON encoded argument value",
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					arg, _ := rp.Source.(*CallArgInfo)
+					value, err := json.Marshal(arg.Value)
+					if err != nil {
+						return nil, fmt.Errorf("can't encode argument value: %w", err)
+					}
+					return string(value), nil
+				},
+			},
+		},
+	})
+	callType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Call",
+		Fields: graphql.Fields{
+			"method": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"args":   &graphql.Field{Type: nonNullList(callArgType)},
+		},
+	})
+	messageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Message",
+		Fields: graphql.Fields{
+			"bridgeId":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"msgHash":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"messageId": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"direction": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"sender":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"executor":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"dataType":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"data":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"call":      &graphql.Field{Type: callType},
+			"signatures": &graphql.Field{
+				Type: nonNullList(eventType),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					msg, _ := rp.Source.(*MessageInfo)
+					return p.findSignedMessageEvents(rp.Context, msg.BridgeID, msg.MsgHash)
+				},
+			},
+			"collected": &graphql.Field{
+				Type: nonNullList(eventType),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					msg, _ := rp.Source.(*MessageInfo)
+					return p.findCollectedMessageEvents(rp.Context, msg.BridgeID, msg.MsgHash)
+				},
+			},
+			"executions": &graphql.Field{
+				Type: nonNullList(eventType),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					msg, _ := rp.Source.(*MessageInfo)
+					return p.findExecutedMessageEvents(rp.Context, msg.BridgeID, msg.MessageID)
+				},
+			},
+		},
+	})
+	ercToNativeMessageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ErcToNativeMessage",
+		Fields: graphql.Fields{
+			"bridgeId":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"msgHash":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"direction": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"sender":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"receiver":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"value":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"signatures": &graphql.Field{
+				Type: nonNullList(eventType),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					msg, _ := rp.Source.(*ErcToNativeMessageInfo)
+					return p.findSignedMessageEvents(rp.Context, msg.BridgeID, msg.MsgHash)
+				},
+			},
+			"collected": &graphql.Field{
+				Type: nonNullList(eventType),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					msg, _ := rp.Source.(*ErcToNativeMessageInfo)
+					return p.findCollectedMessageEvents(rp.Context, msg.BridgeID, msg.MsgHash)
+				},
+			},
+			"executions": &graphql.Field{
+				Type: nonNullList(eventType),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					msg, _ := rp.Source.(*ErcToNativeMessageInfo)
+					return p.findExecutedMessageEvents(rp.Context, msg.BridgeID, msg.MsgHash)
+				},
+			},
+		},
+	})
+	informationRequestType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "InformationRequest",
+		Fields: graphql.Fields{
+			"bridgeId":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"messageId": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"direction": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"sender":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"executor":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"method":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"data":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"args":      &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(callArgType))},
+		},
+	})
+	bridgeMessageType := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "BridgeMessage",
+		Types: []*graphql.Object{messageType, ercToNativeMessageType, informationRequestType},
+		ResolveType: func(rp graphql.ResolveTypeParams) *graphql.Object {
+			switch rp.Value.(type) {
+			case *MessageInfo:
+				return messageType
+			case *ErcToNativeMessageInfo:
+				return ercToNativeMessageType
+			case *InformationRequestInfo:
+				return informationRequestType
+			default:
+				return nil
+			}
+		},
+	})
+	searchResultType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SearchResult",
+		Fields: graphql.Fields{
+			"event":   &graphql.Field{Type: eventType},
+			"message": &graphql.Field{Type: graphql.NewNonNull(bridgeMessageType)},
+			"relatedEvents": &graphql.Field{
+				Type: nonNullList(eventType),
+			},
+		},
+	})
+	logType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Log",
+		Fields: graphql.Fields{
+			"logId":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"chainId":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"address":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"topic0":      &graphql.Field{Type: graphql.String},
+			"topic1":      &graphql.Field{Type: graphql.String},
+			"topic2":      &graphql.Field{Type: graphql.String},
+			"topic3":      &graphql.Field{Type: graphql.String},
+			"data":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"txHash":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"blockNumber": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"link": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					log, _ := rp.Source.(*LogInfo)
+					return p.cfg.GetChainConfig(log.ChainID).FormatTxLink(log.TxHash), nil
+				},
+			},
+		},
+	})
+	bridgeSideType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BridgeSide",
+		Fields: graphql.Fields{
+			"chain":                  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"chainId":                &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"bridgeAddress":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"lastFetchedBlock":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"lastFetchBlockTime":     &graphql.Field{Type: graphql.DateTime},
+			"lastProcessedBlock":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"lastProcessedBlockTime": &graphql.Field{Type: graphql.DateTime},
+			"validators":             &graphql.Field{Type: nonNullList(graphql.String)},
+		},
+	})
+	bridgeSideField := func(side func(*config.BridgeConfig) *config.BridgeSideConfig) *graphql.Field {
+		return &graphql.Field{
+			Type: graphql.NewNonNull(bridgeSideType),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				cfg, _ := rp.Source.(*config.BridgeConfig)
+				return p.getBridgeSideInfo(rp.Context, cfg.ID, side(cfg))
+			},
+		}
+	}
+	bridgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Bridge",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"mode": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				cfg, _ := rp.Source.(*config.BridgeConfig)
+				return cfg.BridgeMode, nil
+			}},
+			"home": bridgeSideField(func(cfg *config.BridgeConfig) *config.BridgeSideConfig {
+				return cfg.Home
+			}),
+			"foreign": bridgeSideField(func(cfg *config.BridgeConfig) *config.BridgeSideConfig {
+				return cfg.Foreign
+			}),
+			"validators": &graphql.Field{
+				Type: nonNullList(validatorType),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					cfg, _ := rp.Source.(*config.BridgeConfig)
+					return p.getValidatorsInfo(rp.Context, cfg, false)
+				},
+			},
+			"pendingMessages": &graphql.Field{
+				Type: nonNullList(bridgeMessageType),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					cfg, _ := rp.Source.(*config.BridgeConfig)
+					msgs, err := p.repo.FindPendingMessages(rp.Context, cfg.ID, cfg.BridgeMode)
+					if err != nil {
+						return nil, fmt.Errorf("can't find pending messages: %w", err)
+					}
+					res := make([]interface{}, len(msgs))
+					for i, m := range msgs {
+						res[i] = NewBridgeMessageInfo(m, p.abiRegistry)
+					}
+					return res, nil
+				},
+			},
+		},
+	})
+
+	logsFilterArgs := graphql.FieldConfigArgument{
+		"chainId":   &graphql.ArgumentConfig{Type: graphql.String},
+		"fromBlock": &graphql.ArgumentConfig{Type: graphql.Int},
+		"toBlock":   &graphql.ArgumentConfig{Type: graphql.Int},
+		"txHash":    &graphql.ArgumentConfig{Type: graphql.String},
+	}
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"bridges": &graphql.Field{
+				Type: nonNullList(bridgeType),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					res := make([]*config.BridgeConfig, 0, len(p.cfg.Bridges))
+					for _, cfg := range p.cfg.Bridges {
+						res = append(res, cfg)
+					}
+					sort.Slice(res, func(i, j int) bool {
+						return res[i].ID < res[j].ID
+					})
+					return res, nil
+				},
+			},
+			"bridge": &graphql.Field{
+				Type: bridgeType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					if cfg, ok := p.cfg.Bridges[rp.Args["id"].(string)]; ok && cfg != nil {
+						return cfg, nil
+					}
+					return nil, nil
+				},
+			},
+			"message": &graphql.Field{
+				Type:        searchResultType,
+				Description: "Finds AMB or ERC_TO_NATIVE message by its msgHash or messageId.",
+				Args: graphql.FieldConfigArgument{
+					"bridgeId":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"msgHash":   &graphql.ArgumentConfig{Type: graphql.String},
+					"messageId": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					msgHash, err := hashArg(rp.Args, "msgHash")
+					if err != nil {
+						return nil, err
+					}
+					messageID, err := hashArg(rp.Args, "messageId")
+					if err != nil {
+						return nil, err
+					}
+					res, err := p.buildSearchResultForMessage(rp.Context, rp.Args["bridgeId"].(string), msgHash, messageID)
+					if errors.Is(err, db.ErrNotFound) {
+						return nil, nil
+					}
+					return res, err
+				},
+			},
+			"informationRequest": &graphql.Field{
+				Type: searchResultType,
+				Args: graphql.FieldConfigArgument{
+					"bridgeId":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"messageId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					messageID, err := hashArg(rp.Args, "messageId")
+					if err != nil {
+						return nil, err
+					}
+					res, err := p.buildSearchResultForInformationRequest(rp.Context, rp.Args["bridgeId"].(string), *messageID)
+					if errors.Is(err, db.ErrNotFound) {
+						return nil, nil
+					}
+					return res, err
+				},
+			},
+			"logs": &graphql.Field{
+				Type:        nonNullList(logType),
+				Description: "Finds logs either by txHash or by chainId and block range.",
+				Args:        logsFilterArgs,
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					filter, err := p.graphQLLogsFilter(rp.Args)
+					if err != nil {
+						return nil, err
+					}
+					logs, err := p.findLogs(rp.Context, filter)
+					if err != nil {
+						return nil, fmt.Errorf("can't filter logs: %w", err)
+					}
+					res := make([]*LogInfo, len(logs))
+					for i, log := range logs {
+						res[i] = NewLogInfo(log)
+					}
+					return res, nil
+				},
+			},
+			"messages": &graphql.Field{
+				Type:        nonNullList(searchResultType),
+				Description: "Finds messages related to the logs either by txHash or by chainId and block range.",
+				Args:        logsFilterArgs,
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					filter, err := p.graphQLLogsFilter(rp.Args)
+					if err != nil {
+						return nil, err
+					}
+					logs, err := p.findLogs(rp.Context, filter)
+					if err != nil {
+						return nil, fmt.Errorf("can't filter logs: %w", err)
+					}
+					return p.searchForMessagesInLogs(rp.Context, logs), nil
+				},
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+	if err != nil {
+		return nil, fmt.Errorf("can't build graphql schema: %w", err)
+	}
+	return &schema, nil
+}
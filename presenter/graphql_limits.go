@@ -0,0 +1,139 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/omni/tokenbridge-monitor/config"
+)
+
+// listFieldCostFactor is an estimated number of items returned by a single list field.
+// Cost of the list field selection set is multiplied by this factor.
+const listFieldCostFactor = 10
+
+var (
+	ErrGraphQLQueryTooDeep   = errors.New("graphql query depth limit exceeded")
+	ErrGraphQLQueryTooCostly = errors.New("graphql query cost limit exceeded")
+)
+
+type graphQLQueryAnalyzer struct {
+	schema    *graphql.Schema
+	cfg       *config.GraphQLConfig
+	fragments map[string]*ast.FragmentDefinition
+	visiting  map[string]bool
+}
+
+// checkGraphQLQueryLimits statically estimates depth and cost of the requested operation
+// before it gets executed. Each field costs 1, list fields multiply cost of their
+// selection set by listFieldCostFactor. Introspection fields are not taken into account.
+// Invalid queries are ignored, since they are rejected later during validation.
+func checkGraphQLQueryLimits(schema *graphql.Schema, query, operationName string, cfg *config.GraphQLConfig) error {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return nil
+	}
+
+	a := &graphQLQueryAnalyzer{
+		schema:    schema,
+		cfg:       cfg,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		visiting:  make(map[string]bool),
+	}
+	for _, def := range doc.Definitions {
+		if frag, ok := def.(*ast.FragmentDefinition); ok && frag.Name != nil {
+			a.fragments[frag.Name.Value] = frag
+		}
+	}
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok || op.Operation != ast.OperationTypeQuery {
+			continue
+		}
+		if operationName != "" && (op.Name == nil || op.Name.Value != operationName) {
+			continue
+		}
+		if _, err = a.selectionSet(op.SelectionSet, schema.QueryType(), 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *graphQLQueryAnalyzer) selectionSet(set *ast.SelectionSet, parent graphql.Type, depth uint) (uint, error) {
+	if set == nil {
+		return 0, nil
+	}
+	totalCost := uint(0)
+	for _, sel := range set.Selections {
+		var cost uint
+		var err error
+		switch s := sel.(type) {
+		case *ast.Field:
+			cost, err = a.field(s, parent, depth)
+		case *ast.InlineFragment:
+			typ := parent
+			if s.TypeCondition != nil {
+				typ = a.schema.Type(s.TypeCondition.Name.Value)
+			}
+			cost, err = a.selectionSet(s.SelectionSet, typ, depth)
+		case *ast.FragmentSpread:
+			frag, ok := a.fragments[s.Name.Value]
+			if !ok || a.visiting[s.Name.Value] {
+				continue
+			}
+			a.visiting[s.Name.Value] = true
+			cost, err = a.selectionSet(frag.SelectionSet, a.schema.Type(frag.TypeCondition.Name.Value), depth)
+			a.visiting[s.Name.Value] = false
+		}
+		if err != nil {
+			return 0, err
+		}
+		totalCost += cost
+		if totalCost > a.cfg.MaxCost {
+			return 0, fmt.Errorf("query cost is greater than %d: %w", a.cfg.MaxCost, ErrGraphQLQueryTooCostly)
+		}
+	}
+	return totalCost, nil
+}
+
+func (a *graphQLQueryAnalyzer) field(field *ast.Field, parent graphql.Type, depth uint) (uint, error) {
+	if depth > a.cfg.MaxDepth {
+		return 0, fmt.Errorf("query depth is greater than %d: %w", a.cfg.MaxDepth, ErrGraphQLQueryTooDeep)
+	}
+	if field.SelectionSet == nil {
+		return 1, nil
+	}
+	var def *graphql.FieldDefinition
+	if obj, ok := parent.(*graphql.Object); ok {
+		def = obj.Fields()[field.Name.Value]
+	}
+	if def == nil {
+		// introspection or unknown field
+		return 1, nil
+	}
+
+	typ, multiplier := graphql.Type(def.Type), uint(1)
+	for {
+		if nonNull, ok := typ.(*graphql.NonNull); ok {
+			typ = nonNull.OfType
+		} else if list, ok2 := typ.(*graphql.List); ok2 {
+			typ = list.OfType
+			multiplier *= listFieldCostFactor
+		} else {
+			break
+		}
+	}
+
+	cost, err := a.selectionSet(field.SelectionSet, typ, depth+1)
+	if err != nil {
+		return 0, err
+	}
+	return 1 + multiplier*cost, nil
+}
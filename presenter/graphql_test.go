@@ -0,0 +1,143 @@
+package presenter_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/presenter"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
+)
+
+type graphQLResult struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func execGraphQL(t *testing.T, cfg *config.GraphQLConfig, query string) *graphQLResult {
+	t.Helper()
+
+	p, err := presenter.NewPresenter(logging.NullLogger(), nil, &config.Config{
+		Bridges: map[string]*config.BridgeConfig{
+			"xdai-amb": {ID: "xdai-amb", BridgeMode: config.BridgeModeArbitraryMessage},
+			"xdai":     {ID: "xdai", BridgeMode: config.BridgeModeErcToNative},
+		},
+		Presenter: &config.PresenterConfig{GraphQL: cfg},
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(query), nil)
+	p.GraphQL(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	res := new(graphQLResult)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(res))
+	return res
+}
+
+func TestGraphQL(t *testing.T) {
+	t.Parallel()
+
+	res := execGraphQL(t, &config.GraphQLConfig{MaxDepth: 10, MaxCost: 100}, `{ bridges { id mode } }`)
+	require.Empty(t, res.Errors)
+	require.JSONEq(t, `{"bridges":[{"id":"xdai","mode":"ERC_TO_NATIVE"},{"id":"xdai-amb","mode":"AMB"}]}`, string(res.Data))
+}
+
+func TestGraphQLLimits(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		cfg   *config.GraphQLConfig
+		query string
+		err   string
+	}{
+		"depth": {
+			cfg:   &config.GraphQLConfig{MaxDepth: 2, MaxCost: 5000},
+			query: `{ bridges { home { chain } } }`,
+			err:   presenter.ErrGraphQLQueryTooDeep.Error(),
+		},
+		"depth via fragment": {
+			cfg:   &config.GraphQLConfig{MaxDepth: 2, MaxCost: 5000},
+			query: `{ bridges { ...F } } fragment F on Bridge { home { chain } }`,
+			err:   presenter.ErrGraphQLQueryTooDeep.Error(),
+		},
+		"cost": {
+			cfg:   &config.GraphQLConfig{MaxDepth: 10, MaxCost: 50},
+			query: `{ bridges { validators { address } } }`,
+			err:   presenter.ErrGraphQLQueryTooCostly.Error(),
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := execGraphQL(t, tc.cfg, tc.query)
+			require.Len(t, res.Errors, 1)
+			require.True(t, strings.HasSuffix(res.Errors[0].Message, tc.err), res.Errors[0].Message)
+		})
+	}
+}
+
+func TestGraphQL_MessageRelationships(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo(memory.NewStore())
+	msgHash := common.HexToHash("0x01")
+	signer := common.HexToAddress("0x02")
+	require.NoError(t, repo.Messages.Ensure(ctx, &entity.Message{BridgeID: "xdai-amb", MsgHash: msgHash, MessageID: common.HexToHash("0x03")}))
+	signedLog := &entity.Log{ChainID: "100", BlockNumber: 10}
+	require.NoError(t, repo.Logs.Ensure(ctx, signedLog))
+	for _, block := range []uint{10, 11} {
+		require.NoError(t, repo.BlockTimestamps.Ensure(ctx, &entity.BlockTimestamp{ChainID: "100", BlockNumber: block, Timestamp: time.Unix(1e9, 0)}))
+	}
+	require.NoError(t, repo.SignedMessages.Ensure(ctx, &entity.SignedMessage{LogID: signedLog.ID, BridgeID: "xdai-amb", MsgHash: msgHash, Signer: signer}))
+	collectedLog := &entity.Log{ChainID: "100", BlockNumber: 11}
+	require.NoError(t, repo.Logs.Ensure(ctx, collectedLog))
+	require.NoError(t, repo.CollectedMessages.Ensure(ctx, &entity.CollectedMessage{LogID: collectedLog.ID, BridgeID: "xdai-amb", MsgHash: msgHash, NumSignatures: 1}))
+
+	p, err := presenter.NewPresenter(logging.NullLogger(), repo, &config.Config{
+		Bridges: map[string]*config.BridgeConfig{
+			"xdai-amb": {
+				ID:         "xdai-amb",
+				BridgeMode: config.BridgeModeArbitraryMessage,
+				Home:       &config.BridgeSideConfig{Chain: &config.ChainConfig{ChainID: "100"}},
+				Foreign:    &config.BridgeSideConfig{Chain: &config.ChainConfig{ChainID: "1"}},
+			},
+		},
+		Presenter: &config.PresenterConfig{GraphQL: &config.GraphQLConfig{MaxDepth: 10, MaxCost: 5000}},
+	})
+	require.NoError(t, err)
+
+	query := `{ message(bridgeId: "xdai-amb", msgHash: "` + msgHash.String() + `") { message { ... on Message {
+		signatures { signer { address lastConfirmation { blockNumber } } tx { blockNumber } }
+		collected { count tx { blockNumber } }
+		executions { status }
+	} } } }`
+	w := httptest.NewRecorder()
+	p.GraphQL(w, httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(query), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	res := new(graphQLResult)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(res))
+	require.Empty(t, res.Errors)
+	require.JSONEq(t, `{"message":{"message":{
+		"signatures":[{"signer":{"address":"`+signer.String()+`","lastConfirmation":{"blockNumber":10}},"tx":{"blockNumber":10}}],
+		"collected":[{"count":1,"tx":{"blockNumber":11}}],
+		"executions":[]
+	}}}`, string(res.Data))
+}
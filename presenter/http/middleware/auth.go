@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/presenter/http/render"
+)
+
+const (
+	// apiKeysCacheTTL is the duration for which API keys loaded from the database are cached.
+	apiKeysCacheTTL = time.Minute
+	// apiKeysCacheSize is the maximum number of cached database lookups of the existing keys,
+	// unknown keys are cached separately, so that requests with random keys don't evict the existing ones.
+	apiKeysCacheSize = 1000
+)
+
+// APIClient describes the owner of the API key used in the request.
+type APIClient struct {
+	// ID uniquely identifies the API key, unlike the Name, which is not required to be unique.
+	ID     string
+	Name   string
+	Scopes []config.APIKeyScope
+	RPS    float64
+	Burst  int
+}
+
+type cachedAPIClient struct {
+	keyHash   string
+	client    *APIClient
+	expiresAt time.Time
+}
+
+// apiClientsCache is a LRU cache of the database lookups, expired entries are evicted on access.
+type apiClientsCache struct {
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func newAPIClientsCache(size int) *apiClientsCache {
+	return &apiClientsCache{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+func (c *apiClientsCache) get(keyHash string) (*APIClient, bool) {
+	elem, ok := c.entries[keyHash]
+	if !ok {
+		return nil, false
+	}
+	cached, _ := elem.Value.(*cachedAPIClient)
+	if time.Now().After(cached.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, keyHash)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return cached.client, true
+}
+
+func (c *apiClientsCache) put(keyHash string, client *APIClient) {
+	cached := &cachedAPIClient{keyHash: keyHash, client: client, expiresAt: time.Now().Add(apiKeysCacheTTL)}
+	if elem, ok := c.entries[keyHash]; ok {
+		elem.Value = cached
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[keyHash] = c.order.PushFront(cached)
+	if c.order.Len() > c.size {
+		oldest, _ := c.order.Remove(c.order.Back()).(*cachedAPIClient)
+		delete(c.entries, oldest.keyHash)
+	}
+}
+
+// Authenticator resolves API keys passed either in the X-API-Key header or
+// in the Authorization header as a bearer token.
+// Keys are looked up in the config first, and then in the database.
+type Authenticator struct {
+	cfg        *config.AuthConfig
+	repo       entity.APIKeysRepo
+	staticKeys map[string]*APIClient
+
+	mu           sync.Mutex
+	cache        *apiClientsCache
+	unknownCache *apiClientsCache
+}
+
+// HashAPIKey returns hex encoded sha256 hash of the API key, as it is stored in the database.
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func HasScope(scopes []config.APIKeyScope, scope config.APIKeyScope) bool {
+	for _, s := range scopes {
+		if s == scope || s == config.APIKeyScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+func NewAuthenticator(cfg *config.AuthConfig, repo entity.APIKeysRepo) *Authenticator {
+	staticKeys := make(map[string]*APIClient, len(cfg.APIKeys))
+	for i, key := range cfg.APIKeys {
+		staticKeys[HashAPIKey(key.Key.Value())] = &APIClient{
+			ID:     "config:" + strconv.Itoa(i),
+			Name:   key.Name,
+			Scopes: key.Scopes,
+			RPS:    key.RPS,
+			Burst:  key.Burst,
+		}
+	}
+	return &Authenticator{
+		cfg:          cfg,
+		repo:         repo,
+		staticKeys:   staticKeys,
+		cache:        newAPIClientsCache(apiKeysCacheSize),
+		unknownCache: newAPIClientsCache(apiKeysCacheSize),
+	}
+}
+
+func requestAPIKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return ""
+}
+
+func (a *Authenticator) findAPIClient(ctx context.Context, keyHash string) (*APIClient, error) {
+	if client, ok := a.staticKeys[keyHash]; ok {
+		return client, nil
+	}
+	if a.repo == nil {
+		return nil, nil
+	}
+
+	a.mu.Lock()
+	client, ok := a.cache.get(keyHash)
+	if !ok {
+		client, ok = a.unknownCache.get(keyHash)
+	}
+	a.mu.Unlock()
+	if ok {
+		return client, nil
+	}
+
+	key, err := a.repo.GetByKeyHash(ctx, keyHash)
+	if err != nil {
+		if !errors.Is(err, db.ErrNotFound) {
+			return nil, err
+		}
+	} else {
+		client = &APIClient{
+			ID:     "db:" + strconv.FormatUint(uint64(key.ID), 10),
+			Name:   key.Name,
+			Scopes: make([]config.APIKeyScope, len(key.Scopes)),
+		}
+		for i, scope := range key.Scopes {
+			client.Scopes[i] = config.APIKeyScope(scope)
+		}
+		if key.RPS != nil {
+			client.RPS = *key.RPS
+		}
+		if key.Burst != nil {
+			client.Burst = *key.Burst
+		}
+	}
+
+	a.mu.Lock()
+	if client != nil {
+		a.cache.put(keyHash, client)
+	} else {
+		a.unknownCache.put(keyHash, nil)
+	}
+	a.mu.Unlock()
+	return client, nil
+}
+
+// Authenticate resolves API client of the request, requests with unknown API keys are rejected.
+// Requests without API key are passed as anonymous.
+func (a *Authenticator) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := requestAPIKey(r)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client, err := a.findAPIClient(r.Context(), HashAPIKey(key))
+		if err != nil {
+			render.Error(w, r, fmt.Errorf("can't find api key: %w", err))
+			return
+		}
+		if client == nil {
+			render.JSON(w, r, http.StatusUnauthorized, "invalid api key")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), apiClientCtxKey, client)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireScope rejects requests, which API key does not have the given scope.
+// Anonymous requests are allowed only if the scope is listed in the anonymous scopes.
+func (a *Authenticator) RequireScope(scope config.APIKeyScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client := APIClientFromContext(r.Context())
+			switch {
+			case client == nil && !HasScope(a.cfg.AnonymousScopes, scope):
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				render.JSON(w, r, http.StatusUnauthorized, fmt.Sprintf("api key with %s scope is required", scope))
+			case client != nil && !HasScope(client.Scopes, scope):
+				render.JSON(w, r, http.StatusForbidden, fmt.Sprintf("api key does not have %s scope", scope))
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// APIClientFromContext returns API client of the request, or nil for anonymous requests.
+func APIClientFromContext(ctx context.Context) *APIClient {
+	if client, ok := ctx.Value(apiClientCtxKey).(*APIClient); ok {
+		return client
+	}
+	return nil
+}
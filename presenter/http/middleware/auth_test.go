@@ -0,0 +1,150 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/presenter/http/middleware"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	auth := middleware.NewAuthenticator(&config.AuthConfig{
+		AnonymousScopes: []config.APIKeyScope{config.APIKeyScopeRead},
+		APIKeys: []*config.APIKeyConfig{
+			{Name: "reader", Key: "reader-key", Scopes: []config.APIKeyScope{config.APIKeyScopeRead}},
+			{Name: "admin", Key: "admin-key", Scopes: []config.APIKeyScope{config.APIKeyScopeAdmin}},
+		},
+	}, nil)
+
+	for name, tc := range map[string]struct {
+		scope  config.APIKeyScope
+		header string
+		value  string
+		status int
+	}{
+		"anonymous read":   {config.APIKeyScopeRead, "", "", http.StatusOK},
+		"anonymous admin":  {config.APIKeyScopeAdmin, "", "", http.StatusUnauthorized},
+		"invalid key":      {config.APIKeyScopeRead, "X-API-Key", "unknown", http.StatusUnauthorized},
+		"reader read":      {config.APIKeyScopeRead, "X-API-Key", "reader-key", http.StatusOK},
+		"reader admin":     {config.APIKeyScopeAdmin, "X-API-Key", "reader-key", http.StatusForbidden},
+		"admin read":       {config.APIKeyScopeRead, "Authorization", "Bearer admin-key", http.StatusOK},
+		"admin admin":      {config.APIKeyScopeAdmin, "Authorization", "Bearer admin-key", http.StatusOK},
+		"malformed bearer": {config.APIKeyScopeAdmin, "Authorization", "admin-key", http.StatusUnauthorized},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				r.Header.Set(tc.header, tc.value)
+			}
+			w := httptest.NewRecorder()
+			auth.Authenticate(auth.RequireScope(tc.scope)(okHandler)).ServeHTTP(w, r)
+			require.Equal(t, tc.status, w.Code)
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	auth := middleware.NewAuthenticator(&config.AuthConfig{
+		APIKeys: []*config.APIKeyConfig{
+			{Name: "client", Key: "client-key", Scopes: []config.APIKeyScope{config.APIKeyScopeRead}},
+			{Name: "client", Key: "other-client-key", Scopes: []config.APIKeyScope{config.APIKeyScopeRead}},
+		},
+	}, nil)
+	limiter := middleware.NewRateLimiter(&config.RateLimitConfig{
+		PerIPRPS:    0.001,
+		PerIPBurst:  1,
+		PerKeyRPS:   0.001,
+		PerKeyBurst: 2,
+	})
+	handler := limiter.LimitIP(auth.Authenticate(limiter.LimitKey(okHandler)))
+
+	request := func(remoteAddr, key string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = remoteAddr
+		if key != "" {
+			r.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, request("10.0.0.1:1234", "").Code)
+	w := request("10.0.0.1:4321", "")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.NotEmpty(t, w.Header().Get("Retry-After"))
+	require.Equal(t, http.StatusOK, request("10.0.0.2:1234", "").Code)
+
+	// IP limit is applied to all requests before the authentication
+	require.Equal(t, http.StatusTooManyRequests, request("10.0.0.1:1234", "client-key").Code)
+	require.Equal(t, http.StatusTooManyRequests, request("10.0.0.2:1234", "unknown-key").Code)
+
+	require.Equal(t, http.StatusOK, request("10.0.0.3:1234", "client-key").Code)
+	require.Equal(t, http.StatusOK, request("10.0.0.4:1234", "client-key").Code)
+	require.Equal(t, http.StatusTooManyRequests, request("10.0.0.5:1234", "client-key").Code)
+	// keys with the same name are limited separately
+	require.Equal(t, http.StatusOK, request("10.0.0.6:1234", "other-client-key").Code)
+}
+
+type countingAPIKeysRepo struct {
+	mu      sync.Mutex
+	lookups int
+}
+
+func (r *countingAPIKeysRepo) GetByKeyHash(_ context.Context, keyHash string) (*entity.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lookups++
+	if keyHash == middleware.HashAPIKey("db-key") {
+		return &entity.APIKey{ID: 1, Name: "db", Scopes: []string{string(config.APIKeyScopeRead)}}, nil
+	}
+	return nil, db.ErrNotFound
+}
+
+func TestAuthenticator_Cache(t *testing.T) {
+	t.Parallel()
+
+	repo := new(countingAPIKeysRepo)
+	auth := middleware.NewAuthenticator(&config.AuthConfig{}, repo)
+	handler := auth.Authenticate(auth.RequireScope(config.APIKeyScopeRead)(okHandler))
+	request := func(key string) int {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-API-Key", key)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, request("db-key"))
+	require.Equal(t, http.StatusUnauthorized, request("unknown-key"))
+	require.Equal(t, 2, repo.lookups)
+
+	// both existing and unknown keys are cached, and unknown keys don't evict the existing ones
+	for i := 0; i < 2000; i++ {
+		require.Equal(t, http.StatusUnauthorized, request(fmt.Sprintf("random-key-%d", i)))
+	}
+	require.Equal(t, http.StatusOK, request("db-key"))
+	require.Equal(t, http.StatusUnauthorized, request("random-key-1999"))
+	require.Equal(t, 2002, repo.lookups)
+}
@@ -23,6 +23,15 @@ const (
 	toBlockNumberCtxKey
 	txHashCtxKey
 	filterCtxKey
+	apiClientCtxKey
+)
+
+const (
+	// MaxBlockRangeSize is the maximum number of blocks that can be requested in a single search.
+	MaxBlockRangeSize = 10000
+	// MaxExportBlockRangeSize is the maximum number of blocks that can be requested in a single CSV or NDJSON export.
+	// Exported records are streamed to the client, so larger ranges are allowed.
+	MaxExportBlockRangeSize = 1000000
 )
 
 var ErrInvalidBlockNumber = errors.New("invalid block number parameter")
@@ -120,8 +129,12 @@ func GetBlockNumberMiddleware(next http.Handler) http.Handler {
 			render.Error(w, r, fmt.Errorf("fromBlock should be less than toBlock: %w", ErrInvalidBlockNumber))
 			return
 		}
-		if toBlock-fromBlock > 10000 {
-			render.Error(w, r, fmt.Errorf("cannot request more than 10000 blocks in range: %w", ErrInvalidBlockNumber))
+		maxRangeSize := uint64(MaxBlockRangeSize)
+		if format, err2 := render.RequestFormat(r); err2 == nil && format != render.FormatJSON {
+			maxRangeSize = MaxExportBlockRangeSize
+		}
+		if toBlock-fromBlock > maxRangeSize {
+			render.Error(w, r, fmt.Errorf("cannot request more than %d blocks in range: %w", maxRangeSize, ErrInvalidBlockNumber))
 			return
 		}
 
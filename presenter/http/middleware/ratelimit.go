@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/presenter/http/render"
+)
+
+const (
+	// rateLimiterCleanupInterval is the interval between removals of the idle limiters.
+	rateLimiterCleanupInterval = time.Minute
+	// rateLimiterIdleTimeout is the duration after which unused limiter is removed.
+	rateLimiterIdleTimeout = 10 * time.Minute
+)
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter limits request rate of each client IP address, and of each API key.
+type RateLimiter struct {
+	cfg *config.RateLimitConfig
+
+	mu          sync.Mutex
+	limiters    map[string]*clientLimiter
+	lastCleanup time.Time
+}
+
+func NewRateLimiter(cfg *config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		cfg:         cfg,
+		limiters:    make(map[string]*clientLimiter, 100),
+		lastCleanup: time.Now(),
+	}
+}
+
+func (l *RateLimiter) getLimiter(id string, rps float64, burst int) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastCleanup) > rateLimiterCleanupInterval {
+		for k, v := range l.limiters {
+			if now.Sub(v.lastSeen) > rateLimiterIdleTimeout {
+				delete(l.limiters, k)
+			}
+		}
+		l.lastCleanup = now
+	}
+
+	cl, ok := l.limiters[id]
+	if !ok {
+		cl = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+		l.limiters[id] = cl
+	}
+	cl.lastSeen = now
+	return cl.limiter
+}
+
+func remoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+func (l *RateLimiter) keyLimit(client *APIClient) (float64, int) {
+	if client.RPS > 0 {
+		burst := client.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(client.RPS))
+		}
+		return client.RPS, burst
+	}
+	return l.cfg.PerKeyRPS, l.cfg.PerKeyBurst
+}
+
+// LimitIP limits request rate of each client IP address, regardless of the API key.
+// It should be applied before the authentication, so that requests with unknown keys are limited as well.
+func (l *RateLimiter) LimitIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.limit(w, r, next, "ip:"+remoteIP(r), l.cfg.PerIPRPS, l.cfg.PerIPBurst)
+	})
+}
+
+// LimitKey limits request rate of each API key, anonymous requests are passed as is.
+func (l *RateLimiter) LimitKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := APIClientFromContext(r.Context())
+		if client == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rps, burst := l.keyLimit(client)
+		l.limit(w, r, next, "key:"+client.ID, rps, burst)
+	})
+}
+
+// limit rejects requests exceeding the rate limit with 429 status and Retry-After header.
+func (l *RateLimiter) limit(w http.ResponseWriter, r *http.Request, next http.Handler, id string, rps float64, burst int) {
+	if rps <= 0 {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	reservation := l.getLimiter(id, rps, burst).Reserve()
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+		render.JSON(w, r, http.StatusTooManyRequests, "rate limit exceeded")
+		return
+	}
+	next.ServeHTTP(w, r)
+}
@@ -0,0 +1,161 @@
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// flushInterval is the number of records written between response flushes.
+const flushInterval = 100
+
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+// RequestFormat returns response format requested in the format query parameter.
+func RequestFormat(r *http.Request) (Format, error) {
+	switch format := Format(r.URL.Query().Get("format")); format {
+	case "", FormatJSON:
+		return FormatJSON, nil
+	case FormatCSV, FormatNDJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// RecordWriter streams flat records into the response in CSV or NDJSON format,
+// without buffering the whole result in memory.
+type RecordWriter struct {
+	w       http.ResponseWriter
+	format  Format
+	columns []string
+	csv     *csv.Writer
+	json    *json.Encoder
+	count   int
+}
+
+func NewRecordWriter(w http.ResponseWriter, format Format, name string, columns []string) (*RecordWriter, error) {
+	rw := &RecordWriter{
+		w:       w,
+		format:  format,
+		columns: columns,
+	}
+	switch format {
+	case FormatCSV:
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".csv"))
+		w.WriteHeader(http.StatusOK)
+		rw.csv = csv.NewWriter(w)
+		if err := rw.csv.Write(columns); err != nil {
+			return nil, fmt.Errorf("can't write csv header: %w", err)
+		}
+	case FormatNDJSON:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		rw.json = json.NewEncoder(w)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	return rw, nil
+}
+
+// Write writes a single record, values should be given in the same order as columns.
+func (rw *RecordWriter) Write(values ...interface{}) error {
+	if rw.csv != nil {
+		record := make([]string, len(values))
+		for i, v := range values {
+			record[i] = csvValue(v)
+		}
+		if err := rw.csv.Write(record); err != nil {
+			return fmt.Errorf("can't write csv record: %w", err)
+		}
+	} else {
+		if err := rw.json.Encode(orderedRecord{columns: rw.columns, values: values}); err != nil {
+			return fmt.Errorf("can't write json record: %w", err)
+		}
+	}
+
+	rw.count++
+	if rw.count%flushInterval == 0 {
+		return rw.Flush()
+	}
+	return nil
+}
+
+// Flush sends all written records to the client.
+func (rw *RecordWriter) Flush() error {
+	if rw.csv != nil {
+		rw.csv.Flush()
+		if err := rw.csv.Error(); err != nil {
+			return fmt.Errorf("can't flush csv records: %w", err)
+		}
+	}
+	if f, ok := rw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// orderedRecord is encoded as a JSON object with fields in the columns order, same as in CSV.
+type orderedRecord struct {
+	columns []string
+	values  []interface{}
+}
+
+func (r orderedRecord) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString("{")
+	for i, column := range r.columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, fmt.Errorf("can't marshal column name: %w", err)
+		}
+		value, err := json.Marshal(r.values[i])
+		if err != nil {
+			return nil, fmt.Errorf("can't marshal %s value: %w", column, err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func csvValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if val := reflect.ValueOf(v); val.Kind() == reflect.Pointer && val.IsNil() {
+		return ""
+	}
+	switch value := v.(type) {
+	case string:
+		return value
+	case time.Time:
+		return value.Format(time.RFC3339)
+	case *time.Time:
+		return value.Format(time.RFC3339)
+	case json.RawMessage:
+		return string(value)
+	case fmt.Stringer:
+		return value.String()
+	default:
+		return fmt.Sprint(value)
+	}
+}
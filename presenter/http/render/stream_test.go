@@ -0,0 +1,52 @@
+package render_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/presenter/http/render"
+)
+
+func TestRequestFormat(t *testing.T) {
+	t.Parallel()
+
+	for query, expected := range map[string]render.Format{
+		"":               render.FormatJSON,
+		"?format=json":   render.FormatJSON,
+		"?format=csv":    render.FormatCSV,
+		"?format=ndjson": render.FormatNDJSON,
+	} {
+		format, err := render.RequestFormat(httptest.NewRequest(http.MethodGet, "/logs"+query, nil))
+		require.NoError(t, err)
+		require.Equal(t, expected, format)
+	}
+
+	_, err := render.RequestFormat(httptest.NewRequest(http.MethodGet, "/logs?format=xml", nil))
+	require.ErrorIs(t, err, render.ErrUnsupportedFormat)
+}
+
+func TestRecordWriter(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	hash := common.HexToHash("0x01")
+	var missingHash *common.Hash
+
+	for format, expected := range map[render.Format]string{
+		render.FormatCSV: "block,timestamp,hash,topic\n" +
+			"1,2022-01-02T03:04:05Z,0x0000000000000000000000000000000000000000000000000000000000000001,\n",
+		render.FormatNDJSON: `{"block":1,"timestamp":"2022-01-02T03:04:05Z","hash":"0x0000000000000000000000000000000000000000000000000000000000000001","topic":null}` + "\n",
+	} {
+		w := httptest.NewRecorder()
+		rw, err := render.NewRecordWriter(w, format, "test", []string{"block", "timestamp", "hash", "topic"})
+		require.NoError(t, err)
+		require.NoError(t, rw.Write(1, &ts, hash, missingHash))
+		require.NoError(t, rw.Flush())
+		require.Equal(t, expected, w.Body.String())
+	}
+}
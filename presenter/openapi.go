@@ -0,0 +1,22 @@
+package presenter
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+// OpenAPISpec returns raw OpenAPI 3 specification of the presenter HTTP API.
+func OpenAPISpec() []byte {
+	return openAPISpec
+}
+
+func (p *Presenter) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(openAPISpec); err != nil {
+		p.logger.WithError(err).Error("failed to write OpenAPI spec")
+	}
+}
@@ -0,0 +1,144 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/presenter"
+)
+
+type openAPISpec struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+var routeParamRegexp = regexp.MustCompile(`{([^:}]+):[^/]+}`)
+
+func normalizeRoute(route string) string {
+	route = routeParamRegexp.ReplaceAllString(route, "{$1}")
+	route = strings.ReplaceAll(route, "/*/", "/")
+	if len(route) > 1 {
+		route = strings.TrimSuffix(route, "/")
+	}
+	return route
+}
+
+func readSpec(t *testing.T) *openAPISpec {
+	t.Helper()
+
+	spec := new(openAPISpec)
+	require.NoError(t, json.Unmarshal(presenter.OpenAPISpec(), spec))
+	return spec
+}
+
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	t.Parallel()
+
+	p, err := presenter.NewPresenter(logging.NullLogger(), nil, &config.Config{
+		Presenter: &config.PresenterConfig{GraphQL: &config.GraphQLConfig{}},
+	})
+	require.NoError(t, err)
+
+	var registered []string
+	err = chi.Walk(p.Routes(), func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		registered = append(registered, method+" "+normalizeRoute(route))
+		return nil
+	})
+	require.NoError(t, err)
+
+	var documented []string
+	for path, methods := range readSpec(t).Paths {
+		for method := range methods {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(registered)
+	sort.Strings(documented)
+	require.Equal(t, documented, registered, "presenter routes and openapi.json paths are out of sync")
+}
+
+func jsonFieldNames(typ reflect.Type) []string {
+	var names []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			names = append(names, jsonFieldNames(embedded)...)
+			continue
+		}
+		name := field.Name
+		if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+			name = tagName
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestOpenAPISpecMatchesTypes(t *testing.T) {
+	t.Parallel()
+
+	schemas := readSpec(t).Components.Schemas
+
+	for _, v := range []interface{}{
+		presenter.MessageInfo{},
+		presenter.InformationRequestInfo{},
+		presenter.CallInfo{},
+		presenter.CallArgInfo{},
+		presenter.ErcToNativeMessageInfo{},
+		presenter.EventInfo{},
+		presenter.SearchResult{},
+		presenter.LogInfo{},
+		presenter.BridgeInfo{},
+		presenter.BridgeSideInfo{},
+		presenter.LimitsInfo{},
+		presenter.ValidatorsInfo{},
+		presenter.MessageStatsInfo{},
+		presenter.TokenBalancesInfo{},
+		presenter.TokenBalanceInfo{},
+		presenter.ExecuteSignaturesTxInfo{},
+		presenter.SimulationInfo{},
+		presenter.ValidatorInfo{},
+		presenter.RelayerInfo{},
+		presenter.BalanceInfo{},
+		presenter.TxInfo{},
+		presenter.UnsignedMessagesInfo{},
+		presenter.UnsignedMessageInfo{},
+	} {
+		typ := reflect.TypeOf(v)
+		schema, ok := schemas[typ.Name()]
+		require.True(t, ok, "schema for %s is missing", typ.Name())
+
+		var documented []string
+		for name := range schema.Properties {
+			documented = append(documented, name)
+		}
+		fields := jsonFieldNames(typ)
+
+		sort.Strings(documented)
+		sort.Strings(fields)
+		require.Equal(t, documented, fields, "schema for %s is out of sync", typ.Name())
+	}
+}
@@ -5,30 +5,40 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/graphql-go/graphql"
 
 	"github.com/omni/tokenbridge-monitor/config"
 	"github.com/omni/tokenbridge-monitor/contract"
+	"github.com/omni/tokenbridge-monitor/contract/abi"
 	"github.com/omni/tokenbridge-monitor/db"
 	"github.com/omni/tokenbridge-monitor/entity"
 	"github.com/omni/tokenbridge-monitor/ethclient"
 	"github.com/omni/tokenbridge-monitor/logging"
 	"github.com/omni/tokenbridge-monitor/presenter/http/middleware"
 	"github.com/omni/tokenbridge-monitor/presenter/http/render"
+	"github.com/omni/tokenbridge-monitor/relay"
 	"github.com/omni/tokenbridge-monitor/repository"
 	"github.com/omni/tokenbridge-monitor/utils"
 )
 
+const defaultReadHeaderTimeout = 10 * time.Second
+
 var (
 	ErrMissingChainID             = errors.New("chainId query parameter is missing")
 	ErrMissingBlockQueryParams    = errors.New("block query parameters are missing")
 	ErrMissingMsgHashAndMessageID = errors.New("msgHash and messageID can't be both nil")
+	ErrInvalidFromAddress         = errors.New("from query parameter is not a valid address")
+	ErrNotErcToNativeBridge       = errors.New("bridge is not in ERC_TO_NATIVE mode")
 )
 
 type Presenter struct {
@@ -36,62 +46,217 @@ type Presenter struct {
 	repo   *repository.Repo
 	cfg    *config.Config
 	root   chi.Router
+	server *http.Server
+
+	graphQLSchema *graphql.Schema
+	abiRegistry   *abi.Registry
+
+	clientsMu sync.Mutex
+	clients   map[string]ethclient.Client
+
+	monitorsMu sync.RWMutex
+	monitors   map[string]BridgeMonitor
 }
 
-func NewPresenter(logger logging.Logger, repo *repository.Repo, cfg *config.Config) *Presenter {
-	return &Presenter{
-		logger: logger,
-		repo:   repo,
-		cfg:    cfg,
-		root:   chi.NewMux(),
+// BridgeMonitor provides the data periodically collected by the bridge monitor, running in the same process.
+type BridgeMonitor interface {
+	// Balance returns the last checked native balance of the bridge validator or relayer.
+	Balance(chainID string, addr common.Address) (*big.Int, bool)
+	// Limits returns the last checked daily limits of the bridge contract on the given chain.
+	Limits(chainID string, token *common.Address) (*contract.BridgeLimits, bool)
+}
+
+// SetMonitors replaces the bridge monitors, used as the source of the validator balances and bridge limits, by bridge ID.
+func (p *Presenter) SetMonitors(monitors map[string]BridgeMonitor) {
+	p.monitorsMu.Lock()
+	defer p.monitorsMu.Unlock()
+
+	p.monitors = monitors
+}
+
+func (p *Presenter) getMonitor(bridgeID string) (BridgeMonitor, bool) {
+	p.monitorsMu.RLock()
+	defer p.monitorsMu.RUnlock()
+
+	m, ok := p.monitors[bridgeID]
+	return m, ok
+}
+
+func NewPresenter(logger logging.Logger, repo *repository.Repo, cfg *config.Config) (*Presenter, error) {
+	p := &Presenter{
+		logger:  logger,
+		repo:    repo,
+		cfg:     cfg,
+		root:    chi.NewMux(),
+		clients: make(map[string]ethclient.Client),
+	}
+	var registryCfg *config.ABIRegistryConfig
+	if cfg.Presenter != nil {
+		registryCfg = cfg.Presenter.ABIRegistry
+	}
+	registry, err := abi.LoadRegistry(registryCfg)
+	if err != nil {
+		return nil, fmt.Errorf("can't load abi registry: %w", err)
 	}
+	p.abiRegistry = registry
+	if cfg.Presenter != nil && cfg.Presenter.GraphQL != nil {
+		schema, err := p.newGraphQLSchema()
+		if err != nil {
+			return nil, err
+		}
+		p.graphQLSchema = schema
+	}
+	p.registerRoutes()
+	p.server = &http.Server{
+		Handler:           p.root,
+		ReadHeaderTimeout: defaultReadHeaderTimeout,
+	}
+	return p, nil
 }
 
+// Serve accepts incoming connections until Shutdown is called.
 func (p *Presenter) Serve(addr string) error {
 	p.logger.WithField("addr", addr).Info("starting presenter service")
-	p.root.Use(chimiddleware.Throttle(5))
-	p.root.Use(chimiddleware.RequestID)
-	p.root.Use(middleware.NewLoggerMiddleware(p.logger))
-	p.root.Use(middleware.Recoverer)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("can't listen on %s: %w", addr, err)
+	}
+	err = p.server.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops accepting new requests and waits for in-flight requests to complete,
+// until the given context expires.
+func (p *Presenter) Shutdown(ctx context.Context) error {
+	p.logger.Info("shutting down presenter service")
+	err := p.server.Shutdown(ctx)
+
+	p.clientsMu.Lock()
+	defer p.clientsMu.Unlock()
+	for chainID, client := range p.clients {
+		client.Close()
+		delete(p.clients, chainID)
+	}
+	return err
+}
+
+// Routes returns all routes registered in the presenter router.
+func (p *Presenter) Routes() chi.Routes {
+	return p.root
+}
+
+func (p *Presenter) registerRoutes() {
+	requireRead, requireAdmin := p.registerMiddlewares()
 	registerSearchRoutes := func(r chi.Router) {
 		r.Use(middleware.GetFilterMiddleware)
 		r.Get("/", p.GetMessages)
 		r.Get("/logs", p.GetLogs)
 		r.Get("/messages", p.GetMessages)
 	}
-	p.root.Route("/bridge/{bridgeID:[0-9a-zA-Z_\\-]+}", func(r chi.Router) {
-		r.Use(middleware.GetBridgeConfigMiddleware(p.cfg))
-		r.Get("/", p.GetBridgeInfo)
-		r.Get("/info", p.GetBridgeInfo)
-		r.Get("/config", p.GetBridgeConfig)
-		r.Get("/validators", p.GetBridgeValidators)
-		r.Get("/pending", p.GetPendingMessages)
-		r.Post("/unsigned", p.GetMessagesWithMissingSignatures)
-	})
-	p.root.Route("/chain/{chainID:[0-9]+}", func(r chi.Router) {
-		r.Use(middleware.GetChainConfigMiddleware(p.cfg))
-		r.Route("/block/{blockNumber:[0-9]+}", func(r2 chi.Router) {
-			r2.Use(middleware.GetBlockNumberMiddleware)
-			r2.Group(registerSearchRoutes)
+	p.root.Get("/openapi.json", p.GetOpenAPISpec)
+	p.root.Group(func(r chi.Router) {
+		r.Use(requireRead)
+		if p.graphQLSchema != nil {
+			r.Get("/graphql", p.GraphQL)
+			r.Post("/graphql", p.GraphQL)
+		}
+		r.Route("/bridge/{bridgeID:[0-9a-zA-Z_\\-]+}", func(r2 chi.Router) {
+			r2.Use(middleware.GetBridgeConfigMiddleware(p.cfg))
+			r2.Get("/", p.GetBridgeInfo)
+			r2.Get("/info", p.GetBridgeInfo)
+			r2.Get("/config", p.GetBridgeConfig)
+			r2.Get("/validators", p.GetBridgeValidators)
+			r2.Get("/pending", p.GetPendingMessages)
+			r2.Get("/stats", p.GetMessageStats)
+			r2.Get("/balances", p.GetTokenBalances)
+			r2.Get("/execute/{msgHash:0x[0-9a-fA-F]{64}}", p.GetExecuteSignaturesTx)
+			r2.With(requireAdmin).Post("/unsigned", p.GetMessagesWithMissingSignatures)
+		})
+		r.Route("/chain/{chainID:[0-9]+}", func(r2 chi.Router) {
+			r2.Use(middleware.GetChainConfigMiddleware(p.cfg))
+			r2.Route("/block/{blockNumber:[0-9]+}", func(r3 chi.Router) {
+				r3.Use(middleware.GetBlockNumberMiddleware)
+				r3.Group(registerSearchRoutes)
+			})
+			r2.Route("/tx/{txHash:0x[0-9a-fA-F]{64}}", func(r3 chi.Router) {
+				r3.Use(middleware.GetTxHashMiddleware)
+				r3.Group(registerSearchRoutes)
+			})
 		})
 		r.Route("/tx/{txHash:0x[0-9a-fA-F]{64}}", func(r2 chi.Router) {
 			r2.Use(middleware.GetTxHashMiddleware)
 			r2.Group(registerSearchRoutes)
 		})
+		r.Group(func(r2 chi.Router) {
+			r2.Use(middleware.GetChainConfigMiddleware(p.cfg))
+			r2.Use(middleware.GetBlockNumberMiddleware)
+			r2.Use(middleware.GetTxHashMiddleware)
+			r2.Use(middleware.GetFilterMiddleware)
+			r2.Get("/logs", p.GetLogs)
+			r2.Get("/messages", p.GetMessages)
+		})
 	})
-	p.root.Route("/tx/{txHash:0x[0-9a-fA-F]{64}}", func(r chi.Router) {
-		r.Use(middleware.GetTxHashMiddleware)
-		r.Group(registerSearchRoutes)
-	})
-	p.root.Group(func(r chi.Router) {
-		r.Use(middleware.GetChainConfigMiddleware(p.cfg))
-		r.Use(middleware.GetBlockNumberMiddleware)
-		r.Use(middleware.GetTxHashMiddleware)
-		r.Use(middleware.GetFilterMiddleware)
-		r.Get("/logs", p.GetLogs)
-		r.Get("/messages", p.GetMessages)
-	})
-	return http.ListenAndServe(addr, p.root)
+}
+
+// registerMiddlewares registers common middlewares and returns middlewares
+// for checking read and admin API key scopes.
+func (p *Presenter) registerMiddlewares() (func(http.Handler) http.Handler, func(http.Handler) http.Handler) {
+	var presenterCfg config.PresenterConfig
+	if p.cfg.Presenter != nil {
+		presenterCfg = *p.cfg.Presenter
+	}
+
+	if presenterCfg.RateLimit != nil && presenterCfg.RateLimit.TrustForwardedFor {
+		p.root.Use(chimiddleware.RealIP)
+	}
+	p.root.Use(chimiddleware.RequestID)
+	p.root.Use(middleware.NewLoggerMiddleware(p.logger))
+	p.root.Use(middleware.Recoverer)
+
+	var limiter *middleware.RateLimiter
+	if presenterCfg.RateLimit != nil {
+		limiter = middleware.NewRateLimiter(presenterCfg.RateLimit)
+		p.root.Use(limiter.LimitIP)
+	}
+	requireRead, requireAdmin := passThrough, passThrough
+	if presenterCfg.Auth != nil {
+		var repo entity.APIKeysRepo
+		if p.repo != nil {
+			repo = p.repo.APIKeys
+		}
+		auth := middleware.NewAuthenticator(presenterCfg.Auth, repo)
+		p.root.Use(auth.Authenticate)
+		requireRead = auth.RequireScope(config.APIKeyScopeRead)
+		requireAdmin = auth.RequireScope(config.APIKeyScopeAdmin)
+	}
+	if limiter != nil {
+		p.root.Use(limiter.LimitKey)
+	}
+	p.root.Use(chimiddleware.Throttle(5))
+	return requireRead, requireAdmin
+}
+
+func passThrough(next http.Handler) http.Handler {
+	return next
+}
+
+// getClient returns RPC client for the given chain, clients are reused between requests.
+func (p *Presenter) getClient(cfg *config.ChainConfig) (ethclient.Client, error) {
+	p.clientsMu.Lock()
+	defer p.clientsMu.Unlock()
+
+	if client, ok := p.clients[cfg.ChainID]; ok {
+		return client, nil
+	}
+	client, err := ethclient.NewClient(cfg.RPC.Host.Value(), cfg.RPC.Timeout, cfg.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[cfg.ChainID] = client
+	return client, nil
 }
 
 func (p *Presenter) findActiveValidatorAddresses(ctx context.Context, bridgeID, chainID string) ([]common.Address, error) {
@@ -142,6 +307,30 @@ func (p *Presenter) getBridgeSideInfo(ctx context.Context, bridgeID string, cfg
 	}, nil
 }
 
+// getLimitsInfo returns the limits collected by the limits monitor, instead of requesting them on each API request.
+// Limits are left empty if they were not checked yet.
+func (p *Presenter) getLimitsInfo(cfg *config.BridgeConfig, side *config.BridgeSideConfig) []*LimitsInfo {
+	m, hasMonitor := p.getMonitor(cfg.ID)
+	var res []*LimitsInfo
+	for _, token := range cfg.LimitTokens(side) {
+		info := &LimitsInfo{Token: token}
+		var limits *contract.BridgeLimits
+		if hasMonitor {
+			limits, _ = m.Limits(side.Chain.ChainID, token)
+		}
+		if limits == nil {
+			info.Error = "limits are not checked yet"
+		} else {
+			info.DailyLimit = utils.FormatEther(limits.DailyLimit)
+			info.TotalSpentPerDay = utils.FormatEther(limits.TotalSpentPerDay)
+			info.ExecutionDailyLimit = utils.FormatEther(limits.ExecutionDailyLimit)
+			info.TotalExecutedPerDay = utils.FormatEther(limits.TotalExecutedPerDay)
+		}
+		res = append(res, info)
+	}
+	return res
+}
+
 func (p *Presenter) getBlockTimeOrDefault(ctx context.Context, chainID string, blockNumber uint) (time.Time, error) {
 	bt, err := p.repo.BlockTimestamps.GetByBlockNumber(ctx, chainID, blockNumber)
 	if err != nil {
@@ -170,6 +359,10 @@ func (p *Presenter) GetBridgeInfo(w http.ResponseWriter, r *http.Request) {
 		render.Error(w, r, fmt.Errorf("failed to get foreign bridge info: %w", err))
 		return
 	}
+	if cfg.Limits != nil {
+		homeInfo.Limits = p.getLimitsInfo(cfg, cfg.Home)
+		foreignInfo.Limits = p.getLimitsInfo(cfg, cfg.Foreign)
+	}
 
 	render.JSON(w, r, http.StatusOK, &BridgeInfo{
 		BridgeID: cfg.ID,
@@ -183,25 +376,39 @@ func (p *Presenter) GetBridgeValidators(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 	cfg := middleware.BridgeConfig(ctx)
 
-	homeValidators, err := p.findActiveValidatorAddresses(ctx, cfg.ID, cfg.Home.Chain.ChainID)
+	validators, err := p.getValidatorsInfo(ctx, cfg, true)
 	if err != nil {
-		render.Error(w, r, fmt.Errorf("failed to find home validators: %w", err))
+		render.Error(w, r, err)
 		return
 	}
+	render.JSON(w, r, http.StatusOK, &ValidatorsInfo{
+		BridgeID:   cfg.ID,
+		Mode:       cfg.BridgeMode,
+		Validators: validators,
+		Relayers:   p.getRelayersInfo(cfg),
+	})
+}
 
-	foreignValidators, err := p.findActiveValidatorAddresses(ctx, cfg.ID, cfg.Home.Chain.ChainID)
+//nolint:cyclop
+func (p *Presenter) getValidatorsInfo(ctx context.Context, cfg *config.BridgeConfig, withBalances bool) ([]*ValidatorInfo, error) {
+	homeValidators, err := p.findActiveValidatorAddresses(ctx, cfg.ID, cfg.Home.Chain.ChainID)
 	if err != nil {
-		render.Error(w, r, fmt.Errorf("failed to find home validators: %w", err))
-		return
+		return nil, fmt.Errorf("failed to find home validators: %w", err)
 	}
 
-	//nolint:gocritic
-	validators := append(homeValidators, foreignValidators...)
-	res := &ValidatorsInfo{
-		BridgeID: cfg.ID,
-		Mode:     cfg.BridgeMode,
+	foreignValidators, err := p.findActiveValidatorAddresses(ctx, cfg.ID, cfg.Foreign.Chain.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find foreign validators: %w", err)
 	}
 
+	sides := []struct {
+		cfg        *config.BridgeSideConfig
+		validators []common.Address
+	}{{cfg.Home, homeValidators}, {cfg.Foreign, foreignValidators}}
+
+	//nolint:gocritic
+	validators := append(homeValidators, foreignValidators...)
+	var res []*ValidatorInfo
 	seenValidators := make(map[common.Address]bool, len(validators))
 	for _, val := range validators {
 		if seenValidators[val] {
@@ -212,23 +419,76 @@ func (p *Presenter) GetBridgeValidators(w http.ResponseWriter, r *http.Request)
 		valInfo := &ValidatorInfo{
 			Address: val,
 		}
-		confirmation, err2 := p.repo.SignedMessages.GetLatest(ctx, cfg.ID, cfg.Home.Chain.ChainID, val)
-		if err2 != nil {
-			if !errors.Is(err2, db.ErrNotFound) {
-				render.Error(w, r, fmt.Errorf("failed to find latest validator confirmation: %w", err2))
-				return
-			}
-		} else {
-			valInfo.LastConfirmation, err = p.getTxInfo(ctx, confirmation.LogID)
-			if err != nil {
-				render.Error(w, r, fmt.Errorf("failed to get tx info: %w", err))
-				return
+		valInfo.LastConfirmation, err = p.getLastConfirmation(ctx, cfg, val)
+		if err != nil {
+			return nil, err
+		}
+		if withBalances {
+			for _, side := range sides {
+				if !containsAddress(side.validators, val) {
+					continue
+				}
+				valInfo.Balances = append(valInfo.Balances, p.getBalanceInfo(cfg.ID, side.cfg.Chain, val))
 			}
 		}
-		res.Validators = append(res.Validators, valInfo)
+		res = append(res, valInfo)
 	}
+	return res, nil
+}
 
-	render.JSON(w, r, http.StatusOK, res)
+// getLastConfirmation returns the transaction of the latest message signed by the validator, or nil if there is none.
+func (p *Presenter) getLastConfirmation(ctx context.Context, cfg *config.BridgeConfig, val common.Address) (*TxInfo, error) {
+	confirmation, err := p.repo.SignedMessages.GetLatest(ctx, cfg.ID, cfg.Home.Chain.ChainID, val)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find latest validator confirmation: %w", err)
+	}
+	txInfo, err := p.getTxInfo(ctx, confirmation.LogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tx info: %w", err)
+	}
+	return txInfo, nil
+}
+
+func (p *Presenter) getRelayersInfo(cfg *config.BridgeConfig) []*RelayerInfo {
+	var res []*RelayerInfo
+	for _, side := range []*config.BridgeSideConfig{cfg.Home, cfg.Foreign} {
+		for _, addr := range side.RelayerAddresses {
+			res = append(res, &RelayerInfo{
+				Address: addr,
+				Balance: p.getBalanceInfo(cfg.ID, side.Chain, addr),
+			})
+		}
+	}
+	return res
+}
+
+// getBalanceInfo returns the balance collected by the bridge monitor, instead of requesting it on each API request.
+// Balance is left empty if it was not checked yet.
+func (p *Presenter) getBalanceInfo(bridgeID string, cfg *config.ChainConfig, addr common.Address) *BalanceInfo {
+	res := &BalanceInfo{ChainID: cfg.ChainID}
+	var balance *big.Int
+	if m, ok := p.getMonitor(bridgeID); ok {
+		balance, _ = m.Balance(cfg.ChainID, addr)
+	}
+	if balance == nil {
+		res.Error = "balance is not checked yet"
+		return res
+	}
+	res.Balance = utils.FormatEther(balance)
+	res.BelowThreshold = cfg.ValidatorBalanceThreshold > 0 && utils.WeiToEther(balance) < cfg.ValidatorBalanceThreshold
+	return res
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, addr := range addresses {
+		if addr == address {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *Presenter) GetPendingMessages(w http.ResponseWriter, r *http.Request) {
@@ -242,7 +502,102 @@ func (p *Presenter) GetPendingMessages(w http.ResponseWriter, r *http.Request) {
 	}
 	res := make([]interface{}, len(msgs))
 	for i, m := range msgs {
-		res[i] = NewBridgeMessageInfo(m)
+		res[i] = NewBridgeMessageInfo(m, p.abiRegistry)
+	}
+	render.JSON(w, r, http.StatusOK, res)
+}
+
+func (p *Presenter) GetMessageStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := middleware.BridgeConfig(ctx)
+
+	counts, err := p.repo.MessageStatuses.CountByState(ctx, cfg.ID)
+	if err != nil {
+		render.Error(w, r, fmt.Errorf("can't count message statuses: %w", err))
+		return
+	}
+	render.JSON(w, r, http.StatusOK, &MessageStatsInfo{
+		BridgeID:  cfg.ID,
+		Pending:   counts[entity.MessageStatePending],
+		Signed:    counts[entity.MessageStateSigned],
+		Collected: counts[entity.MessageStateCollected],
+		Executed:  counts[entity.MessageStateExecuted],
+		Failed:    counts[entity.MessageStateFailed],
+	})
+}
+
+// GetTokenBalances returns locked, invested and claimed amounts of each bridged token of the ERC_TO_NATIVE bridge.
+func (p *Presenter) GetTokenBalances(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := middleware.BridgeConfig(ctx)
+
+	if cfg.BridgeMode != config.BridgeModeErcToNative {
+		http.Error(w, ErrNotErcToNativeBridge.Error(), http.StatusBadRequest)
+		return
+	}
+	balances, err := p.repo.ErcToNativeTokenMovements.FindBalances(ctx, cfg.ID)
+	if err != nil {
+		render.Error(w, r, fmt.Errorf("can't find token balances: %w", err))
+		return
+	}
+	res := &TokenBalancesInfo{BridgeID: cfg.ID, Tokens: make([]*TokenBalanceInfo, len(balances))}
+	for i, b := range balances {
+		res.Tokens[i] = &TokenBalanceInfo{Token: b.Token, Locked: b.Locked, Invested: b.Invested, Claimed: b.Claimed}
+	}
+	render.JSON(w, r, http.StatusOK, res)
+}
+
+// GetExecuteSignaturesTx returns unsigned executeSignatures transaction for the collected home-to-foreign message.
+// With simulate=true query parameter, the transaction is also executed with eth_call on the foreign chain.
+//
+//nolint:cyclop
+func (p *Presenter) GetExecuteSignaturesTx(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := middleware.BridgeConfig(ctx)
+	msgHash := common.HexToHash(chi.URLParam(r, "msgHash"))
+	simulate := r.URL.Query().Get("simulate") == "true"
+	var from common.Address
+	if s := r.URL.Query().Get("from"); s != "" {
+		if !common.IsHexAddress(s) {
+			http.Error(w, ErrInvalidFromAddress.Error(), http.StatusBadRequest)
+			return
+		}
+		from = common.HexToAddress(s)
+	}
+
+	homeClient, err := p.getClient(cfg.Home.Chain)
+	if err != nil {
+		render.Error(w, r, fmt.Errorf("can't create home client: %w", err))
+		return
+	}
+	foreignClient, err := p.getClient(cfg.Foreign.Chain)
+	if err != nil {
+		render.Error(w, r, fmt.Errorf("can't create foreign client: %w", err))
+		return
+	}
+	builder := relay.NewExecuteSignaturesBuilder(p.repo, cfg, homeClient, foreignClient)
+
+	tx, err := builder.Build(ctx, msgHash)
+	switch {
+	case errors.Is(err, db.ErrNotFound), errors.Is(err, relay.ErrNotCollected):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, relay.ErrNotHomeToForeign):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		render.Error(w, r, fmt.Errorf("can't build executeSignatures transaction: %w", err))
+		return
+	}
+
+	res := NewExecuteSignaturesTxInfo(tx)
+	if simulate {
+		sim, err2 := builder.Simulate(ctx, tx, from)
+		if err2 != nil {
+			render.Error(w, r, err2)
+			return
+		}
+		res.Simulation = &SimulationInfo{From: from, Success: sim.Success, Error: sim.Error}
 	}
 	render.JSON(w, r, http.StatusOK, res)
 }
@@ -252,12 +607,11 @@ func (p *Presenter) GetMessagesWithMissingSignatures(w http.ResponseWriter, r *h
 	ctx := r.Context()
 	cfg := middleware.BridgeConfig(ctx)
 
-	foreignClient, err := ethclient.NewClient(cfg.Foreign.Chain.RPC.Host, cfg.Foreign.Chain.RPC.Timeout, cfg.Foreign.Chain.ChainID)
+	foreignClient, err := p.getClient(cfg.Foreign.Chain)
 	if err != nil {
 		render.Error(w, r, fmt.Errorf("can't connect to foreign chain: %w", err))
 		return
 	}
-	defer foreignClient.Close()
 
 	bridgeContract := contract.NewBridgeContract(foreignClient, cfg.Foreign.Address, cfg.BridgeMode)
 	requiredSignatures, err := bridgeContract.RequiredSignatures(ctx)
@@ -338,7 +692,7 @@ func (p *Presenter) GetMessagesWithMissingSignatures(w http.ResponseWriter, r *h
 		}
 		if uint(len(signers)) < requiredSignatures {
 			res = append(res, &UnsignedMessageInfo{
-				Message:        NewBridgeMessageInfo(msg),
+				Message:        NewBridgeMessageInfo(msg, p.abiRegistry),
 				Link:           sentTxLinks[hash],
 				Signers:        signers,
 				MissingSigners: missingSigners,
@@ -401,25 +755,45 @@ func (p *Presenter) parseManualSignatures(r *http.Request, messages map[common.H
 }
 
 func (p *Presenter) getFilteredLogs(ctx context.Context) ([]*entity.Log, error) {
-	filter := middleware.GetFilterContext(ctx)
+	return p.findLogs(ctx, middleware.GetFilterContext(ctx))
+}
+
+func (p *Presenter) findLogs(ctx context.Context, filter *middleware.FilterContext) ([]*entity.Log, error) {
+	logsFilter, err := newLogsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return p.repo.Logs.Find(ctx, logsFilter)
+}
 
+func newLogsFilter(filter *middleware.FilterContext) (entity.LogsFilter, error) {
 	if filter.TxHash == nil {
 		if filter.ChainID == nil {
-			return nil, ErrMissingChainID
+			return entity.LogsFilter{}, ErrMissingChainID
 		}
 		if filter.FromBlock == nil || filter.ToBlock == nil {
-			return nil, ErrMissingBlockQueryParams
+			return entity.LogsFilter{}, ErrMissingBlockQueryParams
 		}
 	}
-	return p.repo.Logs.Find(ctx, entity.LogsFilter{
+	return entity.LogsFilter{
 		ChainID:   filter.ChainID,
 		FromBlock: filter.FromBlock,
 		ToBlock:   filter.ToBlock,
 		TxHash:    filter.TxHash,
-	})
+	}, nil
 }
 
 func (p *Presenter) GetMessages(w http.ResponseWriter, r *http.Request) {
+	format, err := render.RequestFormat(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if format != render.FormatJSON {
+		p.exportMessages(w, r, format)
+		return
+	}
+
 	logs, err := p.getFilteredLogs(r.Context())
 	if err != nil {
 		render.Error(w, r, fmt.Errorf("can't filter logs: %w", err))
@@ -431,6 +805,16 @@ func (p *Presenter) GetMessages(w http.ResponseWriter, r *http.Request) {
 }
 
 func (p *Presenter) GetLogs(w http.ResponseWriter, r *http.Request) {
+	format, err := render.RequestFormat(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if format != render.FormatJSON {
+		p.exportLogs(w, r, format)
+		return
+	}
+
 	logs, err := p.getFilteredLogs(r.Context())
 	if err != nil {
 		render.Error(w, r, fmt.Errorf("can't filter logs: %w", err))
@@ -448,32 +832,38 @@ func (p *Presenter) GetLogs(w http.ResponseWriter, r *http.Request) {
 func (p *Presenter) searchForMessagesInLogs(ctx context.Context, logs []*entity.Log) []*SearchResult {
 	results := make([]*SearchResult, 0, len(logs))
 	for _, log := range logs {
-		for _, task := range []func(context.Context, *entity.Log) (*SearchResult, error){
-			p.searchSentMessage,
-			p.searchSignedMessage,
-			p.searchExecutedMessage,
-			p.searchSentInformationRequest,
-			p.searchSignedInformationRequest,
-			p.searchExecutedInformationRequest,
-		} {
-			if res, err := task(ctx, log); err != nil && !errors.Is(err, db.ErrNotFound) {
-				p.logger.WithError(err).Error("failed to execute search task")
-			} else if res != nil {
-				for _, e := range res.RelatedEvents {
-					if e.LogID == log.ID {
-						res.Event = e
-						break
-					}
-				}
-				if res.Event == nil {
-					p.logger.Error("tx event not found in related events")
+		if res := p.searchForMessageInLog(ctx, log); res != nil {
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+func (p *Presenter) searchForMessageInLog(ctx context.Context, log *entity.Log) *SearchResult {
+	for _, task := range []func(context.Context, *entity.Log) (*SearchResult, error){
+		p.searchSentMessage,
+		p.searchSignedMessage,
+		p.searchExecutedMessage,
+		p.searchSentInformationRequest,
+		p.searchSignedInformationRequest,
+		p.searchExecutedInformationRequest,
+	} {
+		if res, err := task(ctx, log); err != nil && !errors.Is(err, db.ErrNotFound) {
+			p.logger.WithError(err).Error("failed to execute search task")
+		} else if res != nil {
+			for _, e := range res.RelatedEvents {
+				if e.LogID == log.ID {
+					res.Event = e
+					break
 				}
-				results = append(results, res)
-				break
 			}
+			if res.Event == nil {
+				p.logger.Error("tx event not found in related events")
+			}
+			return res
 		}
 	}
-	return results
+	return nil
 }
 
 func (p *Presenter) searchSentMessage(ctx context.Context, log *entity.Log) (*SearchResult, error) {
@@ -528,7 +918,7 @@ func (p *Presenter) buildSearchResultForMessage(ctx context.Context, bridgeID st
 		return nil, err
 	}
 	return &SearchResult{
-		Message:       NewBridgeMessageInfo(msg),
+		Message:       NewBridgeMessageInfo(msg, p.abiRegistry),
 		RelatedEvents: events,
 	}, nil
 }
@@ -580,48 +970,86 @@ func (p *Presenter) buildMessageEvents(ctx context.Context, bridgeID string, msg
 	if err = db.IgnoreErrNotFound(err); err != nil {
 		return nil, err
 	}
-	signed, err := p.repo.SignedMessages.FindByMsgHashes(ctx, bridgeID, []common.Hash{msgHash})
+	signed, err := p.findSignedMessageEvents(ctx, bridgeID, msgHash)
 	if err != nil {
 		return nil, err
 	}
-	collected, err := p.repo.CollectedMessages.GetByMsgHash(ctx, bridgeID, msgHash)
-	if err = db.IgnoreErrNotFound(err); err != nil {
+	collected, err := p.findCollectedMessageEvents(ctx, bridgeID, msgHash)
+	if err != nil {
 		return nil, err
 	}
-	executed, err := p.repo.ExecutedMessages.GetByMessageID(ctx, bridgeID, messageID)
-	if err = db.IgnoreErrNotFound(err); err != nil {
+	executed, err := p.findExecutedMessageEvents(ctx, bridgeID, messageID)
+	if err != nil {
 		return nil, err
 	}
 
 	events := make([]*EventInfo, 0, 5)
 	if sent != nil {
 		events = append(events, &EventInfo{
-			Action: "SENT_MESSAGE",
-			LogID:  sent.LogID,
+			Action:   "SENT_MESSAGE",
+			BridgeID: bridgeID,
+			LogID:    sent.LogID,
 		})
 	}
-	for _, s := range signed {
-		events = append(events, &EventInfo{
-			Action: "SIGNED_MESSAGE",
-			LogID:  s.LogID,
-			Signer: &s.Signer,
-		})
+	events = append(events, signed...)
+	events = append(events, collected...)
+	events = append(events, executed...)
+	return p.enrichEvents(ctx, events)
+}
+
+// findSignedMessageEvents returns signatures of the message, without transaction details.
+func (p *Presenter) findSignedMessageEvents(ctx context.Context, bridgeID string, msgHash common.Hash) ([]*EventInfo, error) {
+	signed, err := p.repo.SignedMessages.FindByMsgHashes(ctx, bridgeID, []common.Hash{msgHash})
+	if err != nil {
+		return nil, err
 	}
-	if collected != nil {
-		events = append(events, &EventInfo{
-			Action: "COLLECTED_SIGNATURES",
-			LogID:  collected.LogID,
-			Count:  collected.NumSignatures,
-		})
+	events := make([]*EventInfo, len(signed))
+	for i, s := range signed {
+		events[i] = &EventInfo{
+			Action:   "SIGNED_MESSAGE",
+			BridgeID: bridgeID,
+			LogID:    s.LogID,
+			Signer:   &signed[i].Signer,
+		}
 	}
-	if executed != nil {
-		events = append(events, &EventInfo{
-			Action: "EXECUTED_MESSAGE",
-			LogID:  executed.LogID,
-			Status: executed.Status,
-		})
+	return events, nil
+}
+
+// findCollectedMessageEvents returns the collected signatures event of the message, without transaction details.
+func (p *Presenter) findCollectedMessageEvents(ctx context.Context, bridgeID string, msgHash common.Hash) ([]*EventInfo, error) {
+	collected, err := p.repo.CollectedMessages.GetByMsgHash(ctx, bridgeID, msgHash)
+	if errors.Is(err, db.ErrNotFound) {
+		return []*EventInfo{}, nil
 	}
-	return p.enrichEvents(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	return []*EventInfo{{
+		Action:   "COLLECTED_SIGNATURES",
+		BridgeID: bridgeID,
+		LogID:    collected.LogID,
+		Count:    collected.NumSignatures,
+	}}, nil
+}
+
+// findExecutedMessageEvents returns the execution event of the message, without transaction details.
+func (p *Presenter) findExecutedMessageEvents(ctx context.Context, bridgeID string, messageID common.Hash) ([]*EventInfo, error) {
+	executed, err := p.repo.ExecutedMessages.GetByMessageID(ctx, bridgeID, messageID)
+	if errors.Is(err, db.ErrNotFound) {
+		return []*EventInfo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []*EventInfo{{
+		Action:           "EXECUTED_MESSAGE",
+		BridgeID:         bridgeID,
+		LogID:            executed.LogID,
+		Status:           executed.Status,
+		GasUsed:          executed.GasUsed,
+		RevertReason:     executed.RevertReason,
+		FailedCallTarget: executed.FailedCallTarget,
+	}}, nil
 }
 
 func (p *Presenter) buildInformationRequestEvents(ctx context.Context, req *entity.InformationRequest) ([]*EventInfo, error) {
@@ -641,21 +1069,24 @@ func (p *Presenter) buildInformationRequestEvents(ctx context.Context, req *enti
 	events := make([]*EventInfo, 0, 5)
 	if sent != nil {
 		events = append(events, &EventInfo{
-			Action: "SENT_INFORMATION_REQUEST",
-			LogID:  sent.LogID,
+			Action:   "SENT_INFORMATION_REQUEST",
+			BridgeID: req.BridgeID,
+			LogID:    sent.LogID,
 		})
 	}
 	for _, s := range signed {
 		events = append(events, &EventInfo{
-			Action: "SIGNED_INFORMATION_REQUEST",
-			LogID:  s.LogID,
-			Signer: &s.Signer,
-			Data:   s.Data,
+			Action:   "SIGNED_INFORMATION_REQUEST",
+			BridgeID: req.BridgeID,
+			LogID:    s.LogID,
+			Signer:   &s.Signer,
+			Data:     s.Data,
 		})
 	}
 	if executed != nil {
 		events = append(events, &EventInfo{
 			Action:         "EXECUTED_INFORMATION_REQUEST",
+			BridgeID:       req.BridgeID,
 			LogID:          executed.LogID,
 			Status:         executed.Status,
 			CallbackStatus: executed.CallbackStatus,
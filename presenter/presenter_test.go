@@ -0,0 +1,283 @@
+package presenter_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/presenter"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
+)
+
+const secretsCfg = `
+chains:
+  mainnet:
+    rpc:
+      host: https://mainnet.infura.io/v3/secret-rpc-key
+    chain_id: 1
+    block_time: 15s
+    block_index_interval: 60s
+  xdai:
+    rpc:
+      host_env: TEST_PRESENTER_XDAI_RPC_URL
+    chain_id: 100
+    block_time: 5s
+    block_index_interval: 30s
+bridges:
+  xdai-amb:
+    home:
+      chain: xdai
+      address: 0x75Df5AF045d91108662D8080fD1FEFAd6aA0bb59
+      start_block: 7408640
+    foreign:
+      chain: mainnet
+      address: 0x4C36d2919e407f0Cc2Ee3c993ccF8ac26d9CE64e
+      start_block: 9130277
+postgres:
+  user: user
+  password: secret-db-password
+  host: localhost
+  port: 5432
+  database: db
+presenter:
+  host: 0.0.0.0:3333
+  auth:
+    anonymous_scopes: [ read ]
+    api_keys:
+      - name: ops
+        key: secret-api-key
+        scopes: [ admin ]
+`
+
+//nolint:paralleltest
+func TestPresenter_GetBridgeConfig(t *testing.T) {
+	t.Setenv("TEST_PRESENTER_XDAI_RPC_URL", "https://rpc.gnosischain.com/secret-env-key")
+	cfg, err := config.ReadConfig([]byte(secretsCfg))
+	require.NoError(t, err)
+	p, err := presenter.NewPresenter(logging.NullLogger(), nil, cfg)
+	require.NoError(t, err)
+
+	handler, ok := p.Routes().(http.Handler)
+	require.True(t, ok)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bridge/xdai-amb/config", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	require.Contains(t, body, `"ChainName":"xdai"`)
+	for _, secret := range []string{"secret-rpc-key", "secret-env-key", "TEST_PRESENTER_XDAI_RPC_URL", "secret-db-password", "secret-api-key"} {
+		require.NotContains(t, body, secret)
+	}
+}
+
+//nolint:paralleltest
+func TestPresenter_GetMessageStats(t *testing.T) {
+	t.Setenv("TEST_PRESENTER_XDAI_RPC_URL", "https://rpc.gnosischain.com")
+	cfg, err := config.ReadConfig([]byte(secretsCfg))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo(memory.NewStore())
+	for i, hash := range []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")} {
+		msg := &entity.Message{BridgeID: "xdai-amb", MsgHash: hash, MessageID: hash, Direction: entity.DirectionHomeToForeign}
+		require.NoError(t, repo.Messages.Ensure(ctx, msg))
+		if i == 0 {
+			log := &entity.Log{ChainID: "100", BlockNumber: 1}
+			require.NoError(t, repo.Logs.Ensure(ctx, log))
+			require.NoError(t, repo.SignedMessages.Ensure(ctx, &entity.SignedMessage{LogID: log.ID, BridgeID: "xdai-amb", MsgHash: hash}))
+		}
+		require.NoError(t, repo.MessageStatuses.Refresh(ctx, "xdai-amb", hash))
+	}
+
+	p, err := presenter.NewPresenter(logging.NullLogger(), repo, cfg)
+	require.NoError(t, err)
+	handler, ok := p.Routes().(http.Handler)
+	require.True(t, ok)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bridge/xdai-amb/stats", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	res := new(presenter.MessageStatsInfo)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	require.Equal(t, &presenter.MessageStatsInfo{BridgeID: "xdai-amb", Pending: 1, Signed: 1}, res)
+}
+
+//nolint:paralleltest
+func TestPresenter_GetPendingMessages_DecodedCall(t *testing.T) {
+	t.Setenv("TEST_PRESENTER_XDAI_RPC_URL", "https://rpc.gnosischain.com")
+	cfg, err := config.ReadConfig([]byte(secretsCfg))
+	require.NoError(t, err)
+	signaturesFile := filepath.Join(t.TempDir(), "signatures.txt")
+	require.NoError(t, os.WriteFile(signaturesFile, []byte("transfer(address,uint256)\n"), 0o600))
+	cfg.Presenter.ABIRegistry = &config.ABIRegistryConfig{SignaturesFile: signaturesFile}
+
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo(memory.NewStore())
+	transfer := append(crypto.Keccak256([]byte("transfer(address,uint256)"))[:4], common.HexToHash("0x01").Bytes()...)
+	transfer = append(transfer, common.BigToHash(big.NewInt(100)).Bytes()...)
+	for i, data := range [][]byte{transfer, {0xde, 0xad, 0xbe, 0xef}} {
+		hash := common.BigToHash(big.NewInt(int64(i + 1)))
+		msg := &entity.Message{BridgeID: "xdai-amb", MsgHash: hash, MessageID: hash, Direction: entity.DirectionHomeToForeign, Data: data}
+		require.NoError(t, repo.Messages.Ensure(ctx, msg))
+		require.NoError(t, repo.MessageStatuses.Refresh(ctx, "xdai-amb", hash))
+	}
+
+	p, err := presenter.NewPresenter(logging.NullLogger(), repo, cfg)
+	require.NoError(t, err)
+	handler, ok := p.Routes().(http.Handler)
+	require.True(t, ok)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bridge/xdai-amb/pending", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res []*presenter.MessageInfo
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res, 2)
+	sort.Slice(res, func(i, j int) bool { return res[i].MsgHash.Big().Cmp(res[j].MsgHash.Big()) < 0 })
+	require.Equal(t, &presenter.CallInfo{
+		Method: "transfer(address,uint256)",
+		Args: []*presenter.CallArgInfo{
+			{Type: "address", Value: "0x0000000000000000000000000000000000000001"},
+			{Type: "uint256", Value: "100"},
+		},
+	}, res[0].Call)
+	require.Nil(t, res[1].Call)
+}
+
+func TestPresenter_GetTokenBalances(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo(memory.NewStore())
+	token := common.HexToAddress("0x01")
+	log := &entity.Log{ChainID: "1", BlockNumber: 1}
+	require.NoError(t, repo.Logs.Ensure(ctx, log))
+	require.NoError(t, repo.ErcToNativeTokenMovements.Ensure(ctx, &entity.ErcToNativeTokenMovement{
+		LogID: log.ID, BridgeID: "xdai", Token: token, Kind: entity.TokenMovementDeposit, Amount: "100",
+	}))
+
+	p, err := presenter.NewPresenter(logging.NullLogger(), repo, &config.Config{
+		Bridges: map[string]*config.BridgeConfig{
+			"xdai-amb": {ID: "xdai-amb", BridgeMode: config.BridgeModeArbitraryMessage},
+			"xdai":     {ID: "xdai", BridgeMode: config.BridgeModeErcToNative},
+		},
+	})
+	require.NoError(t, err)
+	handler, ok := p.Routes().(http.Handler)
+	require.True(t, ok)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bridge/xdai/balances", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	res := new(presenter.TokenBalancesInfo)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	require.Equal(t, &presenter.TokenBalancesInfo{
+		BridgeID: "xdai",
+		Tokens:   []*presenter.TokenBalanceInfo{{Token: token, Locked: "100", Invested: "0", Claimed: "0"}},
+	}, res)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bridge/xdai-amb/balances", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+type testBridgeMonitor struct {
+	balances map[common.Address]*big.Int
+	limits   map[string]*contract.BridgeLimits
+}
+
+func (m *testBridgeMonitor) Limits(chainID string, _ *common.Address) (*contract.BridgeLimits, bool) {
+	limits, ok := m.limits[chainID]
+	return limits, ok
+}
+
+func (m *testBridgeMonitor) Balance(_ string, addr common.Address) (*big.Int, bool) {
+	balance, ok := m.balances[addr]
+	return balance, ok
+}
+
+func TestPresenter_GetBridgeValidators(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo(memory.NewStore())
+	validator := common.HexToAddress("0x01")
+	relayer := common.HexToAddress("0x02")
+	require.NoError(t, repo.BridgeValidators.Ensure(ctx, &entity.BridgeValidator{LogID: 1, BridgeID: "xdai", ChainID: "100", Address: validator}))
+
+	home := &config.BridgeSideConfig{Chain: &config.ChainConfig{ChainID: "100", ValidatorBalanceThreshold: 1}}
+	foreign := &config.BridgeSideConfig{Chain: &config.ChainConfig{ChainID: "1"}, RelayerAddresses: []common.Address{relayer}}
+	p, err := presenter.NewPresenter(logging.NullLogger(), repo, &config.Config{
+		Bridges: map[string]*config.BridgeConfig{
+			"xdai": {ID: "xdai", BridgeMode: config.BridgeModeErcToNative, Home: home, Foreign: foreign},
+		},
+	})
+	require.NoError(t, err)
+	// no RPC requests are made, balances are taken from the bridge monitor
+	p.SetMonitors(map[string]presenter.BridgeMonitor{
+		"xdai": &testBridgeMonitor{balances: map[common.Address]*big.Int{validator: big.NewInt(5e17)}},
+	})
+	handler, ok := p.Routes().(http.Handler)
+	require.True(t, ok)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bridge/xdai/validators", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	res := new(presenter.ValidatorsInfo)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	require.Len(t, res.Validators, 1)
+	require.Equal(t, []*presenter.BalanceInfo{{ChainID: "100", Balance: "0.5", BelowThreshold: true}}, res.Validators[0].Balances)
+	require.Equal(t, []*presenter.RelayerInfo{{
+		Address: relayer,
+		Balance: &presenter.BalanceInfo{ChainID: "1", Error: "balance is not checked yet"},
+	}}, res.Relayers)
+}
+
+func TestPresenter_GetBridgeInfo_Limits(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo(memory.NewStore())
+	home := &config.BridgeSideConfig{Chain: &config.ChainConfig{ChainID: "100"}, Address: common.HexToAddress("0x01")}
+	foreign := &config.BridgeSideConfig{Chain: &config.ChainConfig{ChainID: "1"}, Address: common.HexToAddress("0x02")}
+	for _, side := range []*config.BridgeSideConfig{home, foreign} {
+		require.NoError(t, repo.LogsCursors.Ensure(ctx, &entity.LogsCursor{ChainID: side.Chain.ChainID, Address: side.Address}))
+	}
+	p, err := presenter.NewPresenter(logging.NullLogger(), repo, &config.Config{
+		Bridges: map[string]*config.BridgeConfig{
+			"xdai": {ID: "xdai", BridgeMode: config.BridgeModeErcToNative, Home: home, Foreign: foreign, Limits: &config.BridgeLimitsConfig{}},
+		},
+	})
+	require.NoError(t, err)
+	// no RPC requests are made, limits are taken from the bridge monitor
+	limit := big.NewInt(1e18)
+	p.SetMonitors(map[string]presenter.BridgeMonitor{
+		"xdai": &testBridgeMonitor{limits: map[string]*contract.BridgeLimits{
+			"100": {DailyLimit: limit, TotalSpentPerDay: new(big.Int), ExecutionDailyLimit: limit, TotalExecutedPerDay: limit},
+		}},
+	})
+	handler, ok := p.Routes().(http.Handler)
+	require.True(t, ok)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bridge/xdai/info", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	res := new(presenter.BridgeInfo)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	require.Equal(t, []*presenter.LimitsInfo{{DailyLimit: "1", TotalSpentPerDay: "0", ExecutionDailyLimit: "1", TotalExecutedPerDay: "1"}}, res.Home.Limits)
+	require.Equal(t, []*presenter.LimitsInfo{{Error: "limits are not checked yet"}}, res.Foreign.Limits)
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract/abi"
 	"github.com/omni/tokenbridge-monitor/contract/bridgeabi"
 	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/relay"
 )
 
 type MessageInfo struct {
@@ -20,6 +22,7 @@ type MessageInfo struct {
 	Executor  common.Address
 	DataType  uint
 	Data      hexutil.Bytes
+	Call      *CallInfo `json:",omitempty"`
 }
 
 type InformationRequestInfo struct {
@@ -30,6 +33,19 @@ type InformationRequestInfo struct {
 	Executor  common.Address
 	Method    string
 	Data      hexutil.Bytes
+	Args      []*CallArgInfo `json:",omitempty"`
+}
+
+// CallInfo is the message data, decoded as a call to the message executor.
+type CallInfo struct {
+	Method string
+	Args   []*CallArgInfo
+}
+
+type CallArgInfo struct {
+	Name  string `json:",omitempty"`
+	Type  string
+	Value interface{}
 }
 
 type ErcToNativeMessageInfo struct {
@@ -42,13 +58,17 @@ type ErcToNativeMessageInfo struct {
 }
 
 type EventInfo struct {
-	Action         string
-	LogID          uint            `json:"-"`
-	Signer         *common.Address `json:",omitempty"`
-	Data           hexutil.Bytes   `json:",omitempty"`
-	Count          uint            `json:",omitempty"`
-	Status         bool            `json:",omitempty"`
-	CallbackStatus bool            `json:",omitempty"`
+	Action           string
+	BridgeID         string          `json:"-"`
+	LogID            uint            `json:"-"`
+	Signer           *common.Address `json:",omitempty"`
+	Data             hexutil.Bytes   `json:",omitempty"`
+	Count            uint            `json:",omitempty"`
+	Status           bool            `json:",omitempty"`
+	CallbackStatus   bool            `json:",omitempty"`
+	GasUsed          *uint           `json:",omitempty"`
+	RevertReason     *string         `json:",omitempty"`
+	FailedCallTarget *common.Address `json:",omitempty"`
 	*TxInfo
 }
 
@@ -87,17 +107,77 @@ type BridgeSideInfo struct {
 	LastProcessedBlock     uint
 	LastProcessedBlockTime time.Time
 	Validators             []common.Address
+	Limits                 []*LimitsInfo
+}
+
+type LimitsInfo struct {
+	Token               *common.Address
+	DailyLimit          string
+	TotalSpentPerDay    string
+	ExecutionDailyLimit string
+	TotalExecutedPerDay string
+	Error               string `json:",omitempty"`
 }
 
 type ValidatorsInfo struct {
 	BridgeID   string
 	Mode       config.BridgeMode
 	Validators []*ValidatorInfo
+	Relayers   []*RelayerInfo
+}
+
+type MessageStatsInfo struct {
+	BridgeID  string
+	Pending   uint
+	Signed    uint
+	Collected uint
+	Executed  uint
+	Failed    uint
+}
+
+type TokenBalancesInfo struct {
+	BridgeID string
+	Tokens   []*TokenBalanceInfo
+}
+
+type TokenBalanceInfo struct {
+	Token    common.Address
+	Locked   string
+	Invested string
+	Claimed  string
+}
+
+type ExecuteSignaturesTxInfo struct {
+	MsgHash    common.Hash
+	To         common.Address
+	Message    hexutil.Bytes
+	Signatures []hexutil.Bytes
+	Data       hexutil.Bytes
+	Simulation *SimulationInfo `json:",omitempty"`
+}
+
+type SimulationInfo struct {
+	From    common.Address
+	Success bool
+	Error   string `json:",omitempty"`
 }
 
 type ValidatorInfo struct {
 	Address          common.Address
 	LastConfirmation *TxInfo
+	Balances         []*BalanceInfo
+}
+
+type RelayerInfo struct {
+	Address common.Address
+	Balance *BalanceInfo
+}
+
+type BalanceInfo struct {
+	ChainID        string
+	Balance        string
+	BelowThreshold bool
+	Error          string `json:",omitempty"`
 }
 
 type TxInfo struct {
@@ -136,7 +216,7 @@ func NewLogInfo(log *entity.Log) *LogInfo {
 	}
 }
 
-func NewMessageInfo(msg *entity.Message) *MessageInfo {
+func NewMessageInfo(msg *entity.Message, registry *abi.Registry) *MessageInfo {
 	return &MessageInfo{
 		BridgeID:  msg.BridgeID,
 		MsgHash:   msg.MsgHash,
@@ -146,6 +226,39 @@ func NewMessageInfo(msg *entity.Message) *MessageInfo {
 		Executor:  msg.Executor,
 		DataType:  msg.DataType,
 		Data:      msg.Data,
+		Call:      NewCallInfo(registry.DecodeCall(msg.Executor, msg.Data)),
+	}
+}
+
+func NewCallInfo(call *abi.DecodedCall) *CallInfo {
+	if call == nil {
+		return nil
+	}
+	return &CallInfo{
+		Method: call.Method,
+		Args:   newCallArgInfos(call.Args),
+	}
+}
+
+func newCallArgInfos(args []*abi.DecodedArg) []*CallArgInfo {
+	res := make([]*CallArgInfo, len(args))
+	for i, arg := range args {
+		res[i] = &CallArgInfo{Name: arg.Name, Type: arg.Type, Value: arg.Value}
+	}
+	return res
+}
+
+func NewExecuteSignaturesTxInfo(tx *relay.ExecuteSignaturesTx) *ExecuteSignaturesTxInfo {
+	sigs := make([]hexutil.Bytes, len(tx.Signatures))
+	for i, sig := range tx.Signatures {
+		sigs[i] = sig
+	}
+	return &ExecuteSignaturesTxInfo{
+		MsgHash:    tx.MsgHash,
+		To:         tx.To,
+		Message:    tx.Message,
+		Signatures: sigs,
+		Data:       tx.Data,
 	}
 }
 
@@ -157,7 +270,7 @@ func decodeRequestSelector(selector common.Hash) string {
 }
 
 func NewInformationRequestInfo(req *entity.InformationRequest) *InformationRequestInfo {
-	return &InformationRequestInfo{
+	info := &InformationRequestInfo{
 		BridgeID:  req.BridgeID,
 		MessageID: req.MessageID,
 		Direction: req.Direction,
@@ -166,6 +279,12 @@ func NewInformationRequestInfo(req *entity.InformationRequest) *InformationReque
 		Method:    decodeRequestSelector(req.RequestSelector),
 		Data:      req.Data,
 	}
+	if _, ok := bridgeabi.ArbitraryMessageSelectors[req.RequestSelector]; ok {
+		if call, err := abi.DecodeArguments(info.Method, req.Data); err == nil {
+			info.Args = newCallArgInfos(call.Args)
+		}
+	}
+	return info
 }
 
 func NewErcToNativeMessageInfo(req *entity.ErcToNativeMessage) *ErcToNativeMessageInfo {
@@ -179,10 +298,10 @@ func NewErcToNativeMessageInfo(req *entity.ErcToNativeMessage) *ErcToNativeMessa
 	}
 }
 
-func NewBridgeMessageInfo(req entity.BridgeMessage) interface{} {
+func NewBridgeMessageInfo(req entity.BridgeMessage, registry *abi.Registry) interface{} {
 	switch msg := req.(type) {
 	case *entity.Message:
-		return NewMessageInfo(msg)
+		return NewMessageInfo(msg, registry)
 	case *entity.ErcToNativeMessage:
 		return NewErcToNativeMessageInfo(msg)
 	default:
@@ -0,0 +1,154 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/ethclient"
+	"github.com/omni/tokenbridge-monitor/repository"
+)
+
+var (
+	ErrNotCollected     = errors.New("message signatures are not collected")
+	ErrNotHomeToForeign = errors.New("message is not a home-to-foreign message")
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// ExecuteSignaturesTx is an unsigned foreign bridge transaction, which executes the collected home-to-foreign message.
+type ExecuteSignaturesTx struct {
+	MsgHash    common.Hash
+	To         common.Address
+	Message    []byte
+	Signatures [][]byte
+	Data       []byte
+}
+
+// SimulationResult is an outcome of the eth_call of the transaction.
+type SimulationResult struct {
+	Success bool
+	Error   string
+}
+
+// ExecuteSignaturesBuilder builds executeSignatures transactions from the raw messages stored in the database
+// and from the signatures collected in the home bridge contract. Transactions are never signed or sent.
+type ExecuteSignaturesBuilder struct {
+	repo          *repository.Repo
+	cfg           *config.BridgeConfig
+	home          *contract.BridgeContract
+	foreignClient ethclient.Client
+}
+
+func NewExecuteSignaturesBuilder(repo *repository.Repo, cfg *config.BridgeConfig, homeClient, foreignClient ethclient.Client) *ExecuteSignaturesBuilder {
+	return &ExecuteSignaturesBuilder{
+		repo:          repo,
+		cfg:           cfg,
+		home:          contract.NewBridgeContract(homeClient, cfg.Home.Address, cfg.BridgeMode),
+		foreignClient: foreignClient,
+	}
+}
+
+// Build returns executeSignatures transaction of the home-to-foreign message with the given hash.
+func (b *ExecuteSignaturesBuilder) Build(ctx context.Context, msgHash common.Hash) (*ExecuteSignaturesTx, error) {
+	msg, err := b.findRawMessage(ctx, msgHash)
+	if err != nil {
+		return nil, err
+	}
+	collected, err := b.repo.CollectedMessages.GetByMsgHash(ctx, b.cfg.ID, msgHash)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, fmt.Errorf("message %s: %w", msgHash, ErrNotCollected)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sigs := make([][]byte, collected.NumSignatures)
+	for i := range sigs {
+		if sigs[i], err = b.home.Signature(ctx, msgHash, uint(i)); err != nil {
+			return nil, fmt.Errorf("can't get signature %d of message %s: %w", i, msgHash, err)
+		}
+	}
+	packed, err := PackSignatures(sigs)
+	if err != nil {
+		return nil, err
+	}
+	data, err := contract.BridgeABI(b.cfg.BridgeMode).Pack("executeSignatures", msg, packed)
+	if err != nil {
+		return nil, fmt.Errorf("can't encode executeSignatures calldata: %w", err)
+	}
+	return &ExecuteSignaturesTx{
+		MsgHash:    msgHash,
+		To:         b.cfg.Foreign.Address,
+		Message:    msg,
+		Signatures: sigs,
+		Data:       data,
+	}, nil
+}
+
+func (b *ExecuteSignaturesBuilder) findRawMessage(ctx context.Context, msgHash common.Hash) ([]byte, error) {
+	var msg entity.BridgeMessage
+	var err error
+	if b.cfg.BridgeMode == config.BridgeModeErcToNative {
+		msg, err = b.repo.ErcToNativeMessages.GetByMsgHash(ctx, b.cfg.ID, msgHash)
+	} else {
+		msg, err = b.repo.Messages.GetByMsgHash(ctx, b.cfg.ID, msgHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if msg.GetDirection() != entity.DirectionHomeToForeign {
+		return nil, fmt.Errorf("message %s: %w", msgHash, ErrNotHomeToForeign)
+	}
+	if len(msg.GetRawMessage()) == 0 {
+		return nil, fmt.Errorf("raw message %s is not known: %w", msgHash, db.ErrNotFound)
+	}
+	return msg.GetRawMessage(), nil
+}
+
+// Simulate executes the transaction with eth_call on the latest foreign chain block, on behalf of the given sender.
+// Failed execution is reported in the result, while RPC errors are returned.
+func (b *ExecuteSignaturesBuilder) Simulate(ctx context.Context, tx *ExecuteSignaturesTx, from common.Address) (*SimulationResult, error) {
+	_, err := b.foreignClient.CallContract(ctx, ethereum.CallMsg{
+		From: from,
+		To:   &tx.To,
+		Data: tx.Data,
+	})
+	if err == nil {
+		return &SimulationResult{Success: true}, nil
+	}
+	if ethclient.IsExecutionError(err) {
+		return &SimulationResult{Error: err.Error()}, nil
+	}
+	return nil, fmt.Errorf("can't simulate executeSignatures: %w", err)
+}
+
+// PackSignatures encodes signatures in the format accepted by executeSignatures:
+// number of signatures, followed by all v, all r and all s components.
+func PackSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) > 255 {
+		return nil, fmt.Errorf("too many signatures %d: %w", len(sigs), ErrInvalidSignature)
+	}
+	n := len(sigs)
+	res := make([]byte, 1+65*n)
+	res[0] = byte(n)
+	for i, sig := range sigs {
+		if len(sig) != 65 {
+			return nil, fmt.Errorf("signature %d has length %d: %w", i, len(sig), ErrInvalidSignature)
+		}
+		v := sig[64]
+		if v < 27 {
+			v += 27
+		}
+		res[1+i] = v
+		copy(res[1+n+32*i:], sig[:32])
+		copy(res[1+33*n+32*i:], sig[32:64])
+	}
+	return res, nil
+}
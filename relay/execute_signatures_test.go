@@ -0,0 +1,104 @@
+package relay_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/contract/bridgeabi"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/ethclient/fakechain"
+	"github.com/omni/tokenbridge-monitor/relay"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
+)
+
+var (
+	homeBridgeAddress    = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	foreignBridgeAddress = common.HexToAddress("0x2000000000000000000000000000000000000002")
+)
+
+func TestPackSignatures(t *testing.T) {
+	t.Parallel()
+
+	sig1 := append(bytes.Repeat([]byte{0x11}, 32), append(bytes.Repeat([]byte{0x12}, 32), 27)...)
+	sig2 := append(bytes.Repeat([]byte{0x21}, 32), append(bytes.Repeat([]byte{0x22}, 32), 1)...)
+	packed, err := relay.PackSignatures([][]byte{sig1, sig2})
+	require.NoError(t, err)
+
+	expected := []byte{2, 27, 28}
+	expected = append(expected, sig1[:32]...)
+	expected = append(expected, sig2[:32]...)
+	expected = append(expected, sig1[32:64]...)
+	expected = append(expected, sig2[32:64]...)
+	require.Equal(t, expected, packed)
+
+	_, err = relay.PackSignatures([][]byte{sig1[:64]})
+	require.ErrorIs(t, err, relay.ErrInvalidSignature)
+}
+
+func TestExecuteSignaturesBuilder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo(memory.NewStore())
+	home := fakechain.New("1")
+	foreign := fakechain.New("2")
+	cfg := &config.BridgeConfig{
+		ID:         "test-bridge",
+		BridgeMode: config.BridgeModeArbitraryMessage,
+		Home:       &config.BridgeSideConfig{Chain: &config.ChainConfig{ChainID: home.ChainID()}, Address: homeBridgeAddress},
+		Foreign:    &config.BridgeSideConfig{Chain: &config.ChainConfig{ChainID: foreign.ChainID()}, Address: foreignBridgeAddress},
+	}
+	bridgeABI := bridgeabi.ArbitraryMessageABI
+
+	msg := []byte("home to foreign message")
+	msgHash := crypto.Keccak256Hash(msg)
+	require.NoError(t, repo.Messages.Ensure(ctx, &entity.Message{
+		BridgeID:   cfg.ID,
+		MsgHash:    msgHash,
+		Direction:  entity.DirectionHomeToForeign,
+		RawMessage: msg,
+	}))
+
+	b := relay.NewExecuteSignaturesBuilder(repo, cfg, home, foreign)
+	_, err := b.Build(ctx, common.HexToHash("0x01"))
+	require.ErrorIs(t, err, db.ErrNotFound)
+	_, err = b.Build(ctx, msgHash)
+	require.ErrorIs(t, err, relay.ErrNotCollected)
+
+	require.NoError(t, repo.CollectedMessages.Ensure(ctx, &entity.CollectedMessage{LogID: 1, BridgeID: cfg.ID, MsgHash: msgHash, NumSignatures: 1}))
+	sig := append(bytes.Repeat([]byte{0x01}, 64), 28)
+	data, err := bridgeABI.Pack("signature", msgHash, big.NewInt(0))
+	require.NoError(t, err)
+	res, err := bridgeABI.Methods["signature"].Outputs.Pack(sig)
+	require.NoError(t, err)
+	home.SetCallResult(homeBridgeAddress, data, res)
+
+	tx, err := b.Build(ctx, msgHash)
+	require.NoError(t, err)
+	require.Equal(t, foreignBridgeAddress, tx.To)
+	require.Equal(t, [][]byte{sig}, tx.Signatures)
+	packed, err := relay.PackSignatures(tx.Signatures)
+	require.NoError(t, err)
+	expected, err := bridgeABI.Pack("executeSignatures", msg, packed)
+	require.NoError(t, err)
+	require.Equal(t, expected, tx.Data)
+
+	sim, err := b.Simulate(ctx, tx, common.Address{})
+	require.NoError(t, err)
+	require.False(t, sim.Success)
+	require.NotEmpty(t, sim.Error)
+
+	foreign.SetCallResult(foreignBridgeAddress, tx.Data, nil)
+	sim, err = b.Simulate(ctx, tx, common.Address{})
+	require.NoError(t, err)
+	require.True(t, sim.Success)
+}
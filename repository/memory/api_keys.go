@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type apiKeysRepo baseMemoryRepo
+
+func NewAPIKeysRepo(s *Store) entity.APIKeysRepo {
+	return (*apiKeysRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *apiKeysRepo) GetByKeyHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	defer r.s.lock(ctx)()
+
+	key, ok := r.s.apiKeys.get(keyHash)
+	if !ok || key.Revoked {
+		return nil, fmt.Errorf("can't get api key: %w", db.ErrNotFound)
+	}
+	return key, nil
+}
@@ -0,0 +1,11 @@
+package memory
+
+type baseMemoryRepo struct {
+	s *Store
+}
+
+func newBaseMemoryRepo(s *Store) *baseMemoryRepo {
+	return &baseMemoryRepo{
+		s: s,
+	}
+}
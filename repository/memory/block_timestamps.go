@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type blockTimestampsRepo baseMemoryRepo
+
+func NewBlockTimestampsRepo(s *Store) entity.BlockTimestampsRepo {
+	return (*blockTimestampsRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *blockTimestampsRepo) Ensure(ctx context.Context, ts *entity.BlockTimestamp) error {
+	defer r.s.lock(ctx)()
+
+	key := chainBlockKey{ts.ChainID, ts.BlockNumber}
+	row := *ts
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	if prev, ok := r.s.blockTimestamps.get(key); ok {
+		row.CreatedAt = prev.CreatedAt
+	}
+	r.s.blockTimestamps.put(key, &row)
+	return nil
+}
+
+func (r *blockTimestampsRepo) GetByBlockNumber(ctx context.Context, chainID string, blockNumber uint) (*entity.BlockTimestamp, error) {
+	defer r.s.lock(ctx)()
+
+	bt, ok := r.s.blockTimestamps.get(chainBlockKey{chainID, blockNumber})
+	if !ok {
+		return nil, fmt.Errorf("can't get block timestamp: %w", db.ErrNotFound)
+	}
+	return bt, nil
+}
+
+func (r *blockTimestampsRepo) GetLastBefore(ctx context.Context, chainID string, ts time.Time) (*entity.BlockTimestamp, error) {
+	defer r.s.lock(ctx)()
+
+	bt, ok := r.s.blockTimestamps.first(func(bt *entity.BlockTimestamp) bool {
+		return bt.ChainID == chainID && bt.Timestamp.Before(ts)
+	}, func(a, b *entity.BlockTimestamp) bool {
+		return a.BlockNumber > b.BlockNumber
+	})
+	if !ok {
+		return nil, fmt.Errorf("can't get last block timestamp: %w", db.ErrNotFound)
+	}
+	return bt, nil
+}
+
+func (r *blockTimestampsRepo) PruneOrphaned(ctx context.Context, chainID string, toBlock uint, limit uint) (uint, error) {
+	defer r.s.lock(ctx)()
+
+	if processed, ok := r.s.minProcessedBlock(chainID); !ok {
+		return 0, nil
+	} else if processed < toBlock {
+		toBlock = processed
+	}
+	used := make(map[uint]bool)
+	for _, log := range r.s.logs.rows {
+		if log.ChainID == chainID {
+			used[log.BlockNumber] = true
+		}
+	}
+	for _, cursor := range r.s.logsCursors.rows {
+		if cursor.ChainID == chainID {
+			used[cursor.LastProcessedBlock] = true
+			used[cursor.LastFetchedBlock] = true
+		}
+	}
+	timestamps := r.s.blockTimestamps.filter(func(bt *entity.BlockTimestamp) bool {
+		return bt.ChainID == chainID && bt.BlockNumber <= toBlock && !used[bt.BlockNumber]
+	}, func(a, b *entity.BlockTimestamp) bool {
+		return a.BlockNumber < b.BlockNumber
+	})
+	if uint(len(timestamps)) > limit {
+		timestamps = timestamps[:limit]
+	}
+	for _, bt := range timestamps {
+		r.s.blockTimestamps.delete(chainBlockKey{bt.ChainID, bt.BlockNumber})
+	}
+	return uint(len(timestamps)), nil
+}
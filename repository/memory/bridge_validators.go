@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type bridgeValidatorsRepo baseMemoryRepo
+
+func NewBridgeValidatorsRepo(s *Store) entity.BridgeValidatorsRepo {
+	return (*bridgeValidatorsRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *bridgeValidatorsRepo) Ensure(ctx context.Context, val *entity.BridgeValidator) error {
+	defer r.s.lock(ctx)()
+
+	if prev, ok := r.s.bridgeValidators.get(val.LogID); ok {
+		prev.UpdatedAt = now()
+		prev.RemovedLogID = val.RemovedLogID
+		r.s.bridgeValidators.put(val.LogID, prev)
+		return nil
+	}
+	row := *val
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.bridgeValidators.put(val.LogID, &row)
+	return nil
+}
+
+func lessBridgeValidator(a, b *entity.BridgeValidator) bool {
+	return a.LogID < b.LogID
+}
+
+func (r *bridgeValidatorsRepo) GetActiveValidator(ctx context.Context, bridgeID, chainID string, address common.Address) (*entity.BridgeValidator, error) {
+	defer r.s.lock(ctx)()
+
+	val, ok := r.s.bridgeValidators.first(func(val *entity.BridgeValidator) bool {
+		return val.BridgeID == bridgeID && val.ChainID == chainID && val.Address == address && val.RemovedLogID == nil
+	}, lessBridgeValidator)
+	if !ok {
+		return nil, fmt.Errorf("can't get bridge validator: %w", db.ErrNotFound)
+	}
+	return val, nil
+}
+
+func (r *bridgeValidatorsRepo) FindActiveValidators(ctx context.Context, bridgeID, chainID string) ([]*entity.BridgeValidator, error) {
+	defer r.s.lock(ctx)()
+
+	return r.s.bridgeValidators.filter(func(val *entity.BridgeValidator) bool {
+		return val.BridgeID == bridgeID && val.ChainID == chainID && val.RemovedLogID == nil
+	}, lessBridgeValidator), nil
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type collectedMessagesRepo baseMemoryRepo
+
+func NewCollectedMessagesRepo(s *Store) entity.CollectedMessagesRepo {
+	return (*collectedMessagesRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *collectedMessagesRepo) Ensure(ctx context.Context, msg *entity.CollectedMessage) error {
+	defer r.s.lock(ctx)()
+
+	if prev, ok := r.s.collectedMessages.get(msg.LogID); ok {
+		prev.UpdatedAt = now()
+		r.s.collectedMessages.put(msg.LogID, prev)
+		return nil
+	}
+	row := *msg
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.collectedMessages.put(msg.LogID, &row)
+	return nil
+}
+
+func (r *collectedMessagesRepo) GetByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) (*entity.CollectedMessage, error) {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.collectedMessages.first(func(msg *entity.CollectedMessage) bool {
+		return msg.BridgeID == bridgeID && msg.MsgHash == msgHash
+	}, func(a, b *entity.CollectedMessage) bool {
+		return a.LogID < b.LogID
+	})
+	if !ok {
+		return nil, fmt.Errorf("can't get collected message: %w", db.ErrNotFound)
+	}
+	return msg, nil
+}
+
+func (r *collectedMessagesRepo) FindUnverified(ctx context.Context, bridgeID string, limit uint) ([]*entity.CollectedMessage, error) {
+	defer r.s.lock(ctx)()
+
+	msgs := r.s.collectedMessages.filter(func(msg *entity.CollectedMessage) bool {
+		return msg.BridgeID == bridgeID && msg.MessageValid == nil && (msg.VerifyRetryAt == nil || !msg.VerifyRetryAt.After(time.Now()))
+	}, func(a, b *entity.CollectedMessage) bool {
+		return a.LogID < b.LogID
+	})
+	if uint(len(msgs)) > limit {
+		msgs = msgs[:limit]
+	}
+	return msgs, nil
+}
+
+func (r *collectedMessagesRepo) SetMessageValid(ctx context.Context, logID uint, valid bool) error {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.collectedMessages.get(logID)
+	if !ok {
+		return nil
+	}
+	msg.MessageValid = &valid
+	msg.UpdatedAt = now()
+	r.s.collectedMessages.put(logID, msg)
+	return nil
+}
+
+func (r *collectedMessagesRepo) SetVerifyFailed(ctx context.Context, logID uint, retryAfter time.Duration) error {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.collectedMessages.get(logID)
+	if !ok {
+		return nil
+	}
+	retryAt := time.Now().Add(retryAfter)
+	msg.VerifyAttempts++
+	msg.VerifyRetryAt = &retryAt
+	msg.UpdatedAt = now()
+	r.s.collectedMessages.put(logID, msg)
+	return nil
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type collectedSignaturesRepo baseMemoryRepo
+
+func NewCollectedSignaturesRepo(s *Store) entity.CollectedSignaturesRepo {
+	return (*collectedSignaturesRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *collectedSignaturesRepo) Ensure(ctx context.Context, sig *entity.CollectedSignature) error {
+	defer r.s.lock(ctx)()
+
+	key := collectedSignatureKey{sig.LogID, sig.Index}
+	row := *sig
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	if prev, ok := r.s.collectedSignatures.get(key); ok {
+		row.CreatedAt = prev.CreatedAt
+	}
+	r.s.collectedSignatures.put(key, &row)
+	return nil
+}
+
+func (r *collectedSignaturesRepo) FindByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) ([]*entity.CollectedSignature, error) {
+	defer r.s.lock(ctx)()
+
+	return r.s.collectedSignatures.filter(func(sig *entity.CollectedSignature) bool {
+		return sig.BridgeID == bridgeID && sig.MsgHash == msgHash
+	}, func(a, b *entity.CollectedSignature) bool {
+		if a.LogID != b.LogID {
+			return a.LogID < b.LogID
+		}
+		return a.Index < b.Index
+	}), nil
+}
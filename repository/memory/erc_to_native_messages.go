@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type ercToNativeMessagesRepo baseMemoryRepo
+
+func NewErcToNativeMessagesRepo(s *Store) entity.ErcToNativeMessagesRepo {
+	return (*ercToNativeMessagesRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *ercToNativeMessagesRepo) Ensure(ctx context.Context, msg *entity.ErcToNativeMessage) error {
+	defer r.s.lock(ctx)()
+
+	key := bridgeHashKey{msg.BridgeID, msg.MsgHash}
+	if prev, ok := r.s.ercToNativeMessages.get(key); ok {
+		prev.Sender = msg.Sender
+		prev.UpdatedAt = now()
+		r.s.ercToNativeMessages.put(key, prev)
+		return nil
+	}
+	row := *msg
+	row.ID = r.s.nextID("erc_to_native_messages")
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.ercToNativeMessages.put(key, &row)
+	return nil
+}
+
+func (r *ercToNativeMessagesRepo) GetByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) (*entity.ErcToNativeMessage, error) {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.ercToNativeMessages.get(bridgeHashKey{bridgeID, msgHash})
+	if !ok {
+		return nil, fmt.Errorf("can't get message: %w", db.ErrNotFound)
+	}
+	return msg, nil
+}
+
+// FindPendingMessages returns messages without execution, ordered by creation, same as in Postgres.
+func (r *ercToNativeMessagesRepo) FindPendingMessages(ctx context.Context, bridgeID string) ([]*entity.ErcToNativeMessage, error) {
+	defer r.s.lock(ctx)()
+
+	pending := r.s.pendingMessageHashes(bridgeID)
+	return r.s.ercToNativeMessages.filter(func(msg *entity.ErcToNativeMessage) bool {
+		return msg.BridgeID == bridgeID && pending[msg.MsgHash]
+	}, func(a, b *entity.ErcToNativeMessage) bool {
+		return a.ID < b.ID
+	}), nil
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+type ercToNativeTokenMovementsRepo baseMemoryRepo
+
+func NewErcToNativeTokenMovementsRepo(s *Store) entity.ErcToNativeTokenMovementsRepo {
+	return (*ercToNativeTokenMovementsRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *ercToNativeTokenMovementsRepo) Ensure(ctx context.Context, movement *entity.ErcToNativeTokenMovement) error {
+	defer r.s.lock(ctx)()
+
+	// Amount is stored as a non-negative decimal in Postgres.
+	if amount, ok := new(big.Int).SetString(movement.Amount, 10); !ok || amount.Sign() < 0 {
+		return fmt.Errorf("can't insert token movement with amount %q: %w", movement.Amount, ErrInvalidAmount)
+	}
+	key := tokenMovementKey{movement.LogID, movement.Kind}
+	if prev, ok := r.s.ercToNativeTokenMovements.get(key); ok {
+		prev.UpdatedAt = now()
+		r.s.ercToNativeTokenMovements.put(key, prev)
+		return nil
+	}
+	row := *movement
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.ercToNativeTokenMovements.put(key, &row)
+	return nil
+}
+
+// FindBalances sums token movements of the bridge by token, same as in Postgres.
+func (r *ercToNativeTokenMovementsRepo) FindBalances(ctx context.Context, bridgeID string) ([]*entity.ErcToNativeTokenBalance, error) {
+	defer r.s.lock(ctx)()
+
+	type sums struct{ locked, invested, claimed big.Int }
+	balances := make(map[common.Address]*sums)
+	for _, movement := range r.s.ercToNativeTokenMovements.rows {
+		if movement.BridgeID != bridgeID {
+			continue
+		}
+		amount, _ := new(big.Int).SetString(movement.Amount, 10)
+		b, ok := balances[movement.Token]
+		if !ok {
+			b = new(sums)
+			balances[movement.Token] = b
+		}
+		switch movement.Kind {
+		case entity.TokenMovementDeposit, entity.TokenMovementSwapIn:
+			b.locked.Add(&b.locked, amount)
+		case entity.TokenMovementRelease, entity.TokenMovementSwapOut:
+			b.locked.Sub(&b.locked, amount)
+		case entity.TokenMovementInvest:
+			b.invested.Add(&b.invested, amount)
+		case entity.TokenMovementWithdraw:
+			b.invested.Sub(&b.invested, amount)
+		case entity.TokenMovementInterest:
+			b.claimed.Add(&b.claimed, amount)
+		}
+	}
+	res := make([]*entity.ErcToNativeTokenBalance, 0, len(balances))
+	for token, b := range balances {
+		res = append(res, &entity.ErcToNativeTokenBalance{
+			Token:    token,
+			Locked:   b.locked.String(),
+			Invested: b.invested.String(),
+			Claimed:  b.claimed.String(),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i].Token[:], res[j].Token[:]) < 0
+	})
+	return res, nil
+}
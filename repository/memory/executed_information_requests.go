@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type executedInformationRequestsRepo baseMemoryRepo
+
+func NewExecutedInformationRequestsRepo(s *Store) entity.ExecutedInformationRequestsRepo {
+	return (*executedInformationRequestsRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *executedInformationRequestsRepo) Ensure(ctx context.Context, msg *entity.ExecutedInformationRequest) error {
+	defer r.s.lock(ctx)()
+
+	if prev, ok := r.s.executedInformationRequests.get(msg.LogID); ok {
+		prev.UpdatedAt = now()
+		r.s.executedInformationRequests.put(msg.LogID, prev)
+		return nil
+	}
+	row := *msg
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.executedInformationRequests.put(msg.LogID, &row)
+	return nil
+}
+
+func (r *executedInformationRequestsRepo) GetByLogID(ctx context.Context, logID uint) (*entity.ExecutedInformationRequest, error) {
+	defer r.s.lock(ctx)()
+
+	req, ok := r.s.executedInformationRequests.get(logID)
+	if !ok {
+		return nil, fmt.Errorf("can't get executed information request: %w", db.ErrNotFound)
+	}
+	return req, nil
+}
+
+func (r *executedInformationRequestsRepo) GetByMessageID(ctx context.Context, bridgeID string, messageID common.Hash) (*entity.ExecutedInformationRequest, error) {
+	defer r.s.lock(ctx)()
+
+	req, ok := r.s.executedInformationRequests.first(func(req *entity.ExecutedInformationRequest) bool {
+		return req.BridgeID == bridgeID && req.MessageID == messageID
+	}, func(a, b *entity.ExecutedInformationRequest) bool {
+		return a.LogID < b.LogID
+	})
+	if !ok {
+		return nil, fmt.Errorf("can't get executed information request: %w", db.ErrNotFound)
+	}
+	return req, nil
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type executedMessagesRepo baseMemoryRepo
+
+func NewExecutedMessagesRepo(s *Store) entity.ExecutedMessagesRepo {
+	return (*executedMessagesRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *executedMessagesRepo) Ensure(ctx context.Context, msg *entity.ExecutedMessage) error {
+	defer r.s.lock(ctx)()
+
+	if prev, ok := r.s.executedMessages.get(msg.LogID); ok {
+		// failure details are kept, unless the new ones are known, same as in Postgres
+		if msg.GasUsed != nil {
+			prev.GasUsed = msg.GasUsed
+		}
+		if msg.RevertReason != nil {
+			prev.RevertReason = msg.RevertReason
+		}
+		if msg.FailedCallTarget != nil {
+			prev.FailedCallTarget = msg.FailedCallTarget
+		}
+		prev.UpdatedAt = now()
+		r.s.executedMessages.put(msg.LogID, prev)
+		return nil
+	}
+	row := *msg
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.executedMessages.put(msg.LogID, &row)
+	return nil
+}
+
+func (r *executedMessagesRepo) GetByLogID(ctx context.Context, logID uint) (*entity.ExecutedMessage, error) {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.executedMessages.get(logID)
+	if !ok {
+		return nil, fmt.Errorf("can't get executed message: %w", db.ErrNotFound)
+	}
+	return msg, nil
+}
+
+func (r *executedMessagesRepo) GetByMessageID(ctx context.Context, bridgeID string, messageID common.Hash) (*entity.ExecutedMessage, error) {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.executedMessages.first(func(msg *entity.ExecutedMessage) bool {
+		return msg.BridgeID == bridgeID && msg.MessageID == messageID
+	}, func(a, b *entity.ExecutedMessage) bool {
+		return a.LogID < b.LogID
+	})
+	if !ok {
+		return nil, fmt.Errorf("can't get executed message: %w", db.ErrNotFound)
+	}
+	return msg, nil
+}
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type informationRequestsRepo baseMemoryRepo
+
+func NewInformationRequestsRepo(s *Store) entity.InformationRequestsRepo {
+	return (*informationRequestsRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *informationRequestsRepo) Ensure(ctx context.Context, msg *entity.InformationRequest) error {
+	defer r.s.lock(ctx)()
+
+	key := bridgeHashKey{msg.BridgeID, msg.MessageID}
+	if prev, ok := r.s.informationRequests.get(key); ok {
+		prev.UpdatedAt = now()
+		r.s.informationRequests.put(key, prev)
+		return nil
+	}
+	row := *msg
+	row.ID = r.s.nextID("information_requests")
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.informationRequests.put(key, &row)
+	return nil
+}
+
+func (r *informationRequestsRepo) GetByMessageID(ctx context.Context, bridgeID string, messageID common.Hash) (*entity.InformationRequest, error) {
+	defer r.s.lock(ctx)()
+
+	req, ok := r.s.informationRequests.get(bridgeHashKey{bridgeID, messageID})
+	if !ok {
+		return nil, fmt.Errorf("can't get information request: %w", db.ErrNotFound)
+	}
+	return req, nil
+}
@@ -0,0 +1,201 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type logsRepo baseMemoryRepo
+
+func NewLogsRepo(s *Store) entity.LogsRepo {
+	return (*logsRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *logsRepo) Ensure(ctx context.Context, logs ...*entity.Log) error {
+	defer r.s.lock(ctx)()
+
+	for _, log := range logs {
+		key := logKey{log.ChainID, log.BlockNumber, log.LogIndex}
+		if id, ok := r.s.logIDs[key]; ok {
+			row, _ := r.s.logs.get(id)
+			row.UpdatedAt = now()
+			r.s.logs.put(id, row)
+			log.ID = id
+			continue
+		}
+		row := *log
+		row.ID = r.s.nextID("logs")
+		row.CreatedAt, row.UpdatedAt = now(), now()
+		r.s.logs.put(row.ID, &row)
+		r.s.logIDs[key] = row.ID
+		r.s.addUndo(func() {
+			delete(r.s.logIDs, key)
+		})
+		log.ID = row.ID
+	}
+	return nil
+}
+
+func (r *logsRepo) GetByID(ctx context.Context, id uint) (*entity.Log, error) {
+	defer r.s.lock(ctx)()
+
+	log, ok := r.s.logs.get(id)
+	if !ok {
+		return nil, fmt.Errorf("can't get log by id: %w", db.ErrNotFound)
+	}
+	return log, nil
+}
+
+func containsHash(hashes []common.Hash, hash *common.Hash) bool {
+	if hash == nil {
+		return false
+	}
+	for _, h := range hashes {
+		if h == *hash {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+//nolint:cyclop
+func matchLogsFilter(filter entity.LogsFilter, log *entity.Log) bool {
+	switch {
+	case filter.ChainID != nil && log.ChainID != *filter.ChainID,
+		len(filter.Addresses) > 0 && !containsAddress(filter.Addresses, log.Address),
+		filter.FromBlock != nil && log.BlockNumber < *filter.FromBlock,
+		filter.ToBlock != nil && log.BlockNumber > *filter.ToBlock,
+		filter.TxHash != nil && log.TransactionHash != *filter.TxHash,
+		len(filter.Topic0) > 0 && !containsHash(filter.Topic0, log.Topic0),
+		len(filter.Topic1) > 0 && !containsHash(filter.Topic1, log.Topic1),
+		len(filter.Topic2) > 0 && !containsHash(filter.Topic2, log.Topic2),
+		len(filter.Topic3) > 0 && !containsHash(filter.Topic3, log.Topic3),
+		filter.DataLength != nil && uint(len(log.Data)) != *filter.DataLength:
+		return false
+	}
+	return true
+}
+
+func lessLog(a, b *entity.Log) bool {
+	if a.ChainID != b.ChainID {
+		// chain ids are decimal numbers, same as in Postgres, they are compared numerically
+		if len(a.ChainID) != len(b.ChainID) {
+			return len(a.ChainID) < len(b.ChainID)
+		}
+		return a.ChainID < b.ChainID
+	}
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber < b.BlockNumber
+	}
+	return a.LogIndex < b.LogIndex
+}
+
+func (r *logsRepo) Find(ctx context.Context, filter entity.LogsFilter) ([]*entity.Log, error) {
+	defer r.s.lock(ctx)()
+
+	return r.s.logs.filter(func(log *entity.Log) bool {
+		return matchLogsFilter(filter, log)
+	}, lessLog), nil
+}
+
+func (r *logsRepo) Iterate(ctx context.Context, filter entity.LogsFilter, fn func(*entity.Log) error) error {
+	// matching logs are copied first, so that fn is called without holding the store lock
+	logs, err := r.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	for _, log := range logs {
+		if err = fn(log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *logsRepo) FindByIDs(ctx context.Context, ids []uint) ([]*entity.Log, error) {
+	defer r.s.lock(ctx)()
+
+	set := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return r.s.logs.filter(func(log *entity.Log) bool {
+		return set[log.ID]
+	}, lessLog), nil
+}
+
+// isLogReferenced checks if the log is referenced by any other table, same as foreign keys in Postgres.
+func (s *Store) isLogReferenced(id uint) bool {
+	if _, ok := s.sentMessages.rows[id]; ok {
+		return true
+	}
+	if _, ok := s.signedMessages.rows[id]; ok {
+		return true
+	}
+	if _, ok := s.collectedMessages.rows[id]; ok {
+		return true
+	}
+	if _, ok := s.executedMessages.rows[id]; ok {
+		return true
+	}
+	if _, ok := s.sentInformationRequests.rows[id]; ok {
+		return true
+	}
+	if _, ok := s.signedInformationRequests.rows[id]; ok {
+		return true
+	}
+	if _, ok := s.executedInformationRequests.rows[id]; ok {
+		return true
+	}
+	for key := range s.ercToNativeTokenMovements.rows {
+		if key.LogID == id {
+			return true
+		}
+	}
+	for _, val := range s.bridgeValidators.rows {
+		if val.LogID == id || (val.RemovedLogID != nil && *val.RemovedLogID == id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *logsRepo) PruneUnreferenced(ctx context.Context, chainID string, toBlock uint, limit uint) (uint, error) {
+	defer r.s.lock(ctx)()
+
+	if processed, ok := r.s.minProcessedBlock(chainID); !ok {
+		return 0, nil
+	} else if processed < toBlock {
+		toBlock = processed
+	}
+	logs := r.s.logs.filter(func(log *entity.Log) bool {
+		return log.ChainID == chainID && log.BlockNumber <= toBlock && !r.s.isLogReferenced(log.ID)
+	}, lessLog)
+	if uint(len(logs)) > limit {
+		logs = logs[:limit]
+	}
+	for _, log := range logs {
+		key := logKey{log.ChainID, log.BlockNumber, log.LogIndex}
+		r.s.logs.delete(log.ID)
+		delete(r.s.logIDs, key)
+		id := log.ID
+		r.s.addUndo(func() {
+			r.s.logIDs[key] = id
+		})
+	}
+	return uint(len(logs)), nil
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type logsCursorsRepo baseMemoryRepo
+
+func NewLogsCursorRepo(s *Store) entity.LogsCursorsRepo {
+	return (*logsCursorsRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *logsCursorsRepo) Ensure(ctx context.Context, cursor *entity.LogsCursor) error {
+	defer r.s.lock(ctx)()
+
+	key := chainAddressKey{cursor.ChainID, cursor.Address}
+	row := *cursor
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	if prev, ok := r.s.logsCursors.get(key); ok {
+		row.CreatedAt = prev.CreatedAt
+	}
+	r.s.logsCursors.put(key, &row)
+	return nil
+}
+
+func (r *logsCursorsRepo) UpdateFetchedBlock(ctx context.Context, cursor *entity.LogsCursor) error {
+	return r.update(ctx, cursor, func(row *entity.LogsCursor) {
+		row.LastFetchedBlock = cursor.LastFetchedBlock
+	})
+}
+
+func (r *logsCursorsRepo) UpdateProcessedBlock(ctx context.Context, cursor *entity.LogsCursor) error {
+	return r.update(ctx, cursor, func(row *entity.LogsCursor) {
+		row.LastProcessedBlock = cursor.LastProcessedBlock
+	})
+}
+
+func (r *logsCursorsRepo) update(ctx context.Context, cursor *entity.LogsCursor, set func(row *entity.LogsCursor)) error {
+	defer r.s.lock(ctx)()
+
+	key := chainAddressKey{cursor.ChainID, cursor.Address}
+	prev, ok := r.s.logsCursors.get(key)
+	if !ok {
+		row := *cursor
+		row.CreatedAt, row.UpdatedAt = now(), now()
+		r.s.logsCursors.put(key, &row)
+		return nil
+	}
+	set(prev)
+	prev.UpdatedAt = now()
+	r.s.logsCursors.put(key, prev)
+	return nil
+}
+
+func (r *logsCursorsRepo) GetByChainIDAndAddress(ctx context.Context, chainID string, addr common.Address) (*entity.LogsCursor, error) {
+	defer r.s.lock(ctx)()
+
+	cursor, ok := r.s.logsCursors.get(chainAddressKey{chainID, addr})
+	if !ok {
+		return nil, fmt.Errorf("can't get logs cursor: %w", db.ErrNotFound)
+	}
+	return cursor, nil
+}
@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type messageStatusesRepo baseMemoryRepo
+
+func NewMessageStatusesRepo(s *Store) entity.MessageStatusesRepo {
+	return (*messageStatusesRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *messageStatusesRepo) Refresh(ctx context.Context, bridgeID string, msgHash common.Hash) error {
+	defer r.s.lock(ctx)()
+
+	key := bridgeHashKey{bridgeID, msgHash}
+	if msg, ok := r.s.messages.rows[key]; ok {
+		r.s.refreshMessageStatus(bridgeID, msg.MsgHash, msg.MessageID, msg.Direction)
+	}
+	if msg, ok := r.s.ercToNativeMessages.rows[key]; ok {
+		r.s.refreshMessageStatus(bridgeID, msg.MsgHash, msg.MsgHash, msg.Direction)
+	}
+	return nil
+}
+
+func (r *messageStatusesRepo) RefreshByMessageID(ctx context.Context, bridgeID string, messageID common.Hash) error {
+	defer r.s.lock(ctx)()
+
+	for _, msg := range r.s.messages.rows {
+		if msg.BridgeID == bridgeID && msg.MessageID == messageID {
+			r.s.refreshMessageStatus(bridgeID, msg.MsgHash, msg.MessageID, msg.Direction)
+		}
+	}
+	if msg, ok := r.s.ercToNativeMessages.rows[bridgeHashKey{bridgeID, messageID}]; ok {
+		r.s.refreshMessageStatus(bridgeID, msg.MsgHash, msg.MsgHash, msg.Direction)
+	}
+	return nil
+}
+
+// refreshMessageStatus recomputes message status from its events, same as in Postgres.
+// It should be called with the store lock held.
+func (s *Store) refreshMessageStatus(bridgeID string, msgHash, messageID common.Hash, direction entity.Direction) {
+	var firstEventAt, lastEventAt *time.Time
+	addEvent := func(logID uint) {
+		log, ok := s.logs.rows[logID]
+		if !ok {
+			return
+		}
+		bt, ok := s.blockTimestamps.rows[chainBlockKey{log.ChainID, log.BlockNumber}]
+		if !ok {
+			return
+		}
+		ts := bt.Timestamp
+		if firstEventAt == nil || ts.Before(*firstEventAt) {
+			firstEventAt = &ts
+		}
+		if lastEventAt == nil || ts.After(*lastEventAt) {
+			lastEventAt = &ts
+		}
+	}
+
+	for _, msg := range s.sentMessages.rows {
+		if msg.BridgeID == bridgeID && msg.MsgHash == msgHash {
+			addEvent(msg.LogID)
+		}
+	}
+	var signatures uint
+	for _, msg := range s.signedMessages.rows {
+		if msg.BridgeID == bridgeID && msg.MsgHash == msgHash {
+			signatures++
+			addEvent(msg.LogID)
+		}
+	}
+	collected := false
+	for _, msg := range s.collectedMessages.rows {
+		if msg.BridgeID == bridgeID && msg.MsgHash == msgHash {
+			collected = true
+			addEvent(msg.LogID)
+		}
+	}
+	var executionStatus *bool
+	for _, msg := range s.executedMessages.rows {
+		if msg.BridgeID == bridgeID && msg.MessageID == messageID {
+			status := msg.Status || (executionStatus != nil && *executionStatus)
+			executionStatus = &status
+			addEvent(msg.LogID)
+		}
+	}
+
+	key := bridgeHashKey{bridgeID, msgHash}
+	row := &entity.MessageStatus{
+		BridgeID:        bridgeID,
+		MsgHash:         msgHash,
+		MessageID:       messageID,
+		Direction:       direction,
+		State:           entity.NewMessageState(signatures, collected, executionStatus),
+		Signatures:      signatures,
+		Collected:       collected,
+		ExecutionStatus: executionStatus,
+		FirstEventAt:    firstEventAt,
+		LastEventAt:     lastEventAt,
+		CreatedAt:       now(),
+		UpdatedAt:       now(),
+	}
+	if prev, ok := s.messageStatuses.rows[key]; ok {
+		row.CreatedAt = prev.CreatedAt
+	}
+	s.messageStatuses.put(key, row)
+}
+
+func (r *messageStatusesRepo) GetByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) (*entity.MessageStatus, error) {
+	defer r.s.lock(ctx)()
+
+	status, ok := r.s.messageStatuses.get(bridgeHashKey{bridgeID, msgHash})
+	if !ok {
+		return nil, fmt.Errorf("can't get message status: %w", db.ErrNotFound)
+	}
+	return status, nil
+}
+
+func (r *messageStatusesRepo) CountByState(ctx context.Context, bridgeID string) (map[entity.MessageState]uint, error) {
+	defer r.s.lock(ctx)()
+
+	res := make(map[entity.MessageState]uint, 5)
+	for _, status := range r.s.messageStatuses.rows {
+		if status.BridgeID == bridgeID {
+			res[status.State]++
+		}
+	}
+	return res, nil
+}
+
+// pendingMessageHashes returns hashes of the messages without execution. It should be called with the store lock held.
+func (s *Store) pendingMessageHashes(bridgeID string) map[common.Hash]bool {
+	res := make(map[common.Hash]bool)
+	for _, status := range s.messageStatuses.rows {
+		if status.BridgeID == bridgeID && status.ExecutionStatus == nil {
+			res[status.MsgHash] = true
+		}
+	}
+	return res
+}
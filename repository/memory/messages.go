@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type messagesRepo baseMemoryRepo
+
+func NewMessagesRepo(s *Store) entity.MessagesRepo {
+	return (*messagesRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *messagesRepo) Ensure(ctx context.Context, msg *entity.Message) error {
+	defer r.s.lock(ctx)()
+
+	key := bridgeHashKey{msg.BridgeID, msg.MsgHash}
+	if prev, ok := r.s.messages.get(key); ok {
+		prev.UpdatedAt = now()
+		r.s.messages.put(key, prev)
+		return nil
+	}
+	row := *msg
+	row.ID = r.s.nextID("messages")
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.messages.put(key, &row)
+	return nil
+}
+
+func (r *messagesRepo) GetByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) (*entity.Message, error) {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.messages.get(bridgeHashKey{bridgeID, msgHash})
+	if !ok {
+		return nil, fmt.Errorf("can't get message: %w", db.ErrNotFound)
+	}
+	return msg, nil
+}
+
+func (r *messagesRepo) GetByMessageID(ctx context.Context, bridgeID string, messageID common.Hash) (*entity.Message, error) {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.messages.first(func(msg *entity.Message) bool {
+		return msg.BridgeID == bridgeID && msg.MessageID == messageID
+	}, lessMessage)
+	if !ok {
+		return nil, fmt.Errorf("can't get message: %w", db.ErrNotFound)
+	}
+	return msg, nil
+}
+
+func lessMessage(a, b *entity.Message) bool {
+	return a.ID < b.ID
+}
+
+// FindPendingMessages returns messages without execution, ordered by creation, same as in Postgres.
+func (r *messagesRepo) FindPendingMessages(ctx context.Context, bridgeID string) ([]*entity.Message, error) {
+	defer r.s.lock(ctx)()
+
+	pending := r.s.pendingMessageHashes(bridgeID)
+	return r.s.messages.filter(func(msg *entity.Message) bool {
+		return msg.BridgeID == bridgeID && pending[msg.MsgHash]
+	}, lessMessage), nil
+}
+
+// FindRecentPendingMessages returns messages without execution first seen after since, ordered by creation, same as in Postgres.
+func (r *messagesRepo) FindRecentPendingMessages(ctx context.Context, bridgeID string, since time.Time) ([]*entity.Message, error) {
+	defer r.s.lock(ctx)()
+
+	pending := r.s.pendingMessageHashes(bridgeID)
+	return r.s.messages.filter(func(msg *entity.Message) bool {
+		if msg.BridgeID != bridgeID || !pending[msg.MsgHash] {
+			return false
+		}
+		status, ok := r.s.messageStatuses.rows[bridgeHashKey{bridgeID, msg.MsgHash}]
+		return !ok || status.FirstEventAt == nil || !status.FirstEventAt.Before(since)
+	}, lessMessage), nil
+}
+
+func (r *messagesRepo) SetSimulationResult(ctx context.Context, bridgeID string, msgHash common.Hash, success bool, errMsg string) error {
+	defer r.s.lock(ctx)()
+
+	key := bridgeHashKey{bridgeID, msgHash}
+	msg, ok := r.s.messages.get(key)
+	if !ok {
+		return nil
+	}
+	row := *msg
+	row.SimulationSuccess = &success
+	row.SimulationError = nil
+	if !success {
+		row.SimulationError = &errMsg
+	}
+	row.SimulatedAt = now()
+	r.s.messages.put(key, &row)
+	return nil
+}
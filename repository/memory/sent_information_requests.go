@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type sentInformationRequestsRepo baseMemoryRepo
+
+func NewSentInformationRequestsRepo(s *Store) entity.SentInformationRequestsRepo {
+	return (*sentInformationRequestsRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *sentInformationRequestsRepo) Ensure(ctx context.Context, msg *entity.SentInformationRequest) error {
+	defer r.s.lock(ctx)()
+
+	if prev, ok := r.s.sentInformationRequests.get(msg.LogID); ok {
+		prev.UpdatedAt = now()
+		r.s.sentInformationRequests.put(msg.LogID, prev)
+		return nil
+	}
+	row := *msg
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.sentInformationRequests.put(msg.LogID, &row)
+	return nil
+}
+
+func (r *sentInformationRequestsRepo) GetByLogID(ctx context.Context, logID uint) (*entity.SentInformationRequest, error) {
+	defer r.s.lock(ctx)()
+
+	req, ok := r.s.sentInformationRequests.get(logID)
+	if !ok {
+		return nil, fmt.Errorf("can't get sent information request: %w", db.ErrNotFound)
+	}
+	return req, nil
+}
+
+func (r *sentInformationRequestsRepo) GetByMessageID(ctx context.Context, bridgeID string, messageID common.Hash) (*entity.SentInformationRequest, error) {
+	defer r.s.lock(ctx)()
+
+	req, ok := r.s.sentInformationRequests.first(func(req *entity.SentInformationRequest) bool {
+		return req.BridgeID == bridgeID && req.MessageID == messageID
+	}, func(a, b *entity.SentInformationRequest) bool {
+		return a.LogID < b.LogID
+	})
+	if !ok {
+		return nil, fmt.Errorf("can't get sent information request: %w", db.ErrNotFound)
+	}
+	return req, nil
+}
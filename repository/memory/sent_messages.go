@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type sentMessagesRepo baseMemoryRepo
+
+func NewSentMessagesRepo(s *Store) entity.SentMessagesRepo {
+	return (*sentMessagesRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *sentMessagesRepo) Ensure(ctx context.Context, msg *entity.SentMessage) error {
+	defer r.s.lock(ctx)()
+
+	if prev, ok := r.s.sentMessages.get(msg.LogID); ok {
+		prev.UpdatedAt = now()
+		r.s.sentMessages.put(msg.LogID, prev)
+		return nil
+	}
+	row := *msg
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.sentMessages.put(msg.LogID, &row)
+	return nil
+}
+
+func (r *sentMessagesRepo) GetByLogID(ctx context.Context, logID uint) (*entity.SentMessage, error) {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.sentMessages.get(logID)
+	if !ok {
+		return nil, fmt.Errorf("can't get sent message: %w", db.ErrNotFound)
+	}
+	return msg, nil
+}
+
+func lessSentMessage(a, b *entity.SentMessage) bool {
+	return a.LogID < b.LogID
+}
+
+func (r *sentMessagesRepo) GetByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) (*entity.SentMessage, error) {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.sentMessages.first(func(msg *entity.SentMessage) bool {
+		return msg.BridgeID == bridgeID && msg.MsgHash == msgHash
+	}, lessSentMessage)
+	if !ok {
+		return nil, fmt.Errorf("can't get sent message: %w", db.ErrNotFound)
+	}
+	return msg, nil
+}
+
+func (r *sentMessagesRepo) FindByMsgHashes(ctx context.Context, bridgeID string, msgHashes []common.Hash) ([]*entity.SentMessage, error) {
+	defer r.s.lock(ctx)()
+
+	return r.s.sentMessages.filter(func(msg *entity.SentMessage) bool {
+		return msg.BridgeID == bridgeID && containsHash(msgHashes, &msg.MsgHash)
+	}, lessSentMessage), nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type signedInformationRequestsRepo baseMemoryRepo
+
+func NewSignedInformationRequestsRepo(s *Store) entity.SignedInformationRequestsRepo {
+	return (*signedInformationRequestsRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *signedInformationRequestsRepo) Ensure(ctx context.Context, msg *entity.SignedInformationRequest) error {
+	defer r.s.lock(ctx)()
+
+	if prev, ok := r.s.signedInformationRequests.get(msg.LogID); ok {
+		prev.UpdatedAt = now()
+		r.s.signedInformationRequests.put(msg.LogID, prev)
+		return nil
+	}
+	row := *msg
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.signedInformationRequests.put(msg.LogID, &row)
+	return nil
+}
+
+func (r *signedInformationRequestsRepo) GetByLogID(ctx context.Context, logID uint) (*entity.SignedInformationRequest, error) {
+	defer r.s.lock(ctx)()
+
+	req, ok := r.s.signedInformationRequests.get(logID)
+	if !ok {
+		return nil, fmt.Errorf("can't get signed information request: %w", db.ErrNotFound)
+	}
+	return req, nil
+}
+
+func (r *signedInformationRequestsRepo) FindByMessageID(ctx context.Context, bridgeID string, messageID common.Hash) ([]*entity.SignedInformationRequest, error) {
+	defer r.s.lock(ctx)()
+
+	return r.s.signedInformationRequests.filter(func(req *entity.SignedInformationRequest) bool {
+		return req.BridgeID == bridgeID && req.MessageID == messageID
+	}, func(a, b *entity.SignedInformationRequest) bool {
+		if a.Signer != b.Signer {
+			return bytes.Compare(a.Signer[:], b.Signer[:]) < 0
+		}
+		return a.LogID < b.LogID
+	}), nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type signedMessagesRepo baseMemoryRepo
+
+func NewSignedMessagesRepo(s *Store) entity.SignedMessagesRepo {
+	return (*signedMessagesRepo)(newBaseMemoryRepo(s))
+}
+
+func (r *signedMessagesRepo) Ensure(ctx context.Context, msg *entity.SignedMessage) error {
+	defer r.s.lock(ctx)()
+
+	if prev, ok := r.s.signedMessages.get(msg.LogID); ok {
+		prev.UpdatedAt = now()
+		r.s.signedMessages.put(msg.LogID, prev)
+		return nil
+	}
+	row := *msg
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	r.s.signedMessages.put(msg.LogID, &row)
+	return nil
+}
+
+func (r *signedMessagesRepo) GetByLogID(ctx context.Context, logID uint) (*entity.SignedMessage, error) {
+	defer r.s.lock(ctx)()
+
+	msg, ok := r.s.signedMessages.get(logID)
+	if !ok {
+		return nil, fmt.Errorf("can't get signed messages: %w", db.ErrNotFound)
+	}
+	return msg, nil
+}
+
+func (r *signedMessagesRepo) FindByMsgHashes(ctx context.Context, bridgeID string, msgHashes []common.Hash) ([]*entity.SignedMessage, error) {
+	defer r.s.lock(ctx)()
+
+	return r.s.signedMessages.filter(func(msg *entity.SignedMessage) bool {
+		return msg.BridgeID == bridgeID && containsHash(msgHashes, &msg.MsgHash)
+	}, func(a, b *entity.SignedMessage) bool {
+		if a.Signer != b.Signer {
+			return bytes.Compare(a.Signer[:], b.Signer[:]) < 0
+		}
+		return a.LogID < b.LogID
+	}), nil
+}
+
+func (r *signedMessagesRepo) GetLatest(ctx context.Context, bridgeID, chainID string, signer common.Address) (*entity.SignedMessage, error) {
+	defer r.s.lock(ctx)()
+
+	var res *entity.SignedMessage
+	var resBlock uint
+	for _, msg := range r.s.signedMessages.rows {
+		if msg.BridgeID != bridgeID || msg.Signer != signer {
+			continue
+		}
+		log, ok := r.s.logs.rows[msg.LogID]
+		if !ok || log.ChainID != chainID {
+			continue
+		}
+		if res == nil || log.BlockNumber > resBlock || (log.BlockNumber == resBlock && msg.LogID > res.LogID) {
+			res, resBlock = msg, log.BlockNumber
+		}
+	}
+	if res == nil {
+		return nil, fmt.Errorf("can't get latest signed message: %w", db.ErrNotFound)
+	}
+	msg := *res
+	return &msg, nil
+}
@@ -0,0 +1,260 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type txCtxKey struct{}
+
+// table is an in-memory table of rows indexed by the primary key.
+// Rows are stored and returned as copies, so that callers can't modify the stored state.
+type table[K comparable, V any] struct {
+	s    *Store
+	rows map[K]*V
+}
+
+func newTable[K comparable, V any](s *Store) *table[K, V] {
+	return &table[K, V]{
+		s:    s,
+		rows: make(map[K]*V),
+	}
+}
+
+func (t *table[K, V]) get(key K) (*V, bool) {
+	row, ok := t.rows[key]
+	if !ok {
+		return nil, false
+	}
+	res := *row
+	return &res, true
+}
+
+// put inserts or replaces the row, previous state is restored if the current transaction is rolled back.
+func (t *table[K, V]) put(key K, row *V) {
+	prev, existed := t.rows[key]
+	value := *row
+	t.rows[key] = &value
+	t.s.addUndo(func() {
+		if existed {
+			t.rows[key] = prev
+		} else {
+			delete(t.rows, key)
+		}
+	})
+}
+
+// delete removes the row, it is restored if the current transaction is rolled back.
+func (t *table[K, V]) delete(key K) {
+	prev, existed := t.rows[key]
+	if !existed {
+		return
+	}
+	delete(t.rows, key)
+	t.s.addUndo(func() {
+		t.rows[key] = prev
+	})
+}
+
+// filter returns copies of the rows matching the predicate, ordered by the less function.
+func (t *table[K, V]) filter(match func(*V) bool, less func(a, b *V) bool) []*V {
+	res := make([]*V, 0, 10)
+	for _, row := range t.rows {
+		if match(row) {
+			value := *row
+			res = append(res, &value)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return less(res[i], res[j])
+	})
+	return res
+}
+
+// first returns a copy of the first row matching the predicate in the order given by the less function.
+func (t *table[K, V]) first(match func(*V) bool, less func(a, b *V) bool) (*V, bool) {
+	var res *V
+	for _, row := range t.rows {
+		if match(row) && (res == nil || less(row, res)) {
+			res = row
+		}
+	}
+	if res == nil {
+		return nil, false
+	}
+	value := *res
+	return &value, true
+}
+
+type chainAddressKey struct {
+	ChainID string
+	Address common.Address
+}
+
+type chainBlockKey struct {
+	ChainID     string
+	BlockNumber uint
+}
+
+type logKey struct {
+	ChainID     string
+	BlockNumber uint
+	LogIndex    uint
+}
+
+type collectedSignatureKey struct {
+	LogID uint
+	Index uint
+}
+
+type tokenMovementKey struct {
+	LogID uint
+	Kind  entity.TokenMovementKind
+}
+
+type bridgeHashKey struct {
+	BridgeID string
+	Hash     common.Hash
+}
+
+// Store keeps all in-memory tables. Operations are serialized with a single lock,
+// transactions hold the lock until they are committed or rolled back.
+type Store struct {
+	mu   sync.Mutex
+	undo []func()
+
+	lastID map[string]uint
+
+	logsCursors                 *table[chainAddressKey, entity.LogsCursor]
+	logs                        *table[uint, entity.Log]
+	logIDs                      map[logKey]uint
+	blockTimestamps             *table[chainBlockKey, entity.BlockTimestamp]
+	messages                    *table[bridgeHashKey, entity.Message]
+	ercToNativeMessages         *table[bridgeHashKey, entity.ErcToNativeMessage]
+	messageStatuses             *table[bridgeHashKey, entity.MessageStatus]
+	sentMessages                *table[uint, entity.SentMessage]
+	signedMessages              *table[uint, entity.SignedMessage]
+	collectedMessages           *table[uint, entity.CollectedMessage]
+	collectedSignatures         *table[collectedSignatureKey, entity.CollectedSignature]
+	ercToNativeTokenMovements   *table[tokenMovementKey, entity.ErcToNativeTokenMovement]
+	executedMessages            *table[uint, entity.ExecutedMessage]
+	informationRequests         *table[bridgeHashKey, entity.InformationRequest]
+	sentInformationRequests     *table[uint, entity.SentInformationRequest]
+	signedInformationRequests   *table[uint, entity.SignedInformationRequest]
+	executedInformationRequests *table[uint, entity.ExecutedInformationRequest]
+	bridgeValidators            *table[uint, entity.BridgeValidator]
+	apiKeys                     *table[string, entity.APIKey]
+}
+
+func NewStore() *Store {
+	s := &Store{
+		lastID: make(map[string]uint),
+		logIDs: make(map[logKey]uint),
+	}
+	s.logsCursors = newTable[chainAddressKey, entity.LogsCursor](s)
+	s.logs = newTable[uint, entity.Log](s)
+	s.blockTimestamps = newTable[chainBlockKey, entity.BlockTimestamp](s)
+	s.messages = newTable[bridgeHashKey, entity.Message](s)
+	s.ercToNativeMessages = newTable[bridgeHashKey, entity.ErcToNativeMessage](s)
+	s.messageStatuses = newTable[bridgeHashKey, entity.MessageStatus](s)
+	s.sentMessages = newTable[uint, entity.SentMessage](s)
+	s.signedMessages = newTable[uint, entity.SignedMessage](s)
+	s.collectedMessages = newTable[uint, entity.CollectedMessage](s)
+	s.collectedSignatures = newTable[collectedSignatureKey, entity.CollectedSignature](s)
+	s.ercToNativeTokenMovements = newTable[tokenMovementKey, entity.ErcToNativeTokenMovement](s)
+	s.executedMessages = newTable[uint, entity.ExecutedMessage](s)
+	s.informationRequests = newTable[bridgeHashKey, entity.InformationRequest](s)
+	s.sentInformationRequests = newTable[uint, entity.SentInformationRequest](s)
+	s.signedInformationRequests = newTable[uint, entity.SignedInformationRequest](s)
+	s.executedInformationRequests = newTable[uint, entity.ExecutedInformationRequest](s)
+	s.bridgeValidators = newTable[uint, entity.BridgeValidator](s)
+	s.apiKeys = newTable[string, entity.APIKey](s)
+	return s
+}
+
+// lock acquires the store lock, unless it is already held by the transaction of the given context.
+// Returned function releases the lock.
+func (s *Store) lock(ctx context.Context) func() {
+	if ctx.Value(txCtxKey{}) == s {
+		return func() {}
+	}
+	s.mu.Lock()
+	return func() {
+		s.undo = nil
+		s.mu.Unlock()
+	}
+}
+
+func (s *Store) addUndo(fn func()) {
+	s.undo = append(s.undo, fn)
+}
+
+// nextID returns the next value of the sequence, sequences are not rolled back, same as in Postgres.
+func (s *Store) nextID(sequence string) uint {
+	s.lastID[sequence]++
+	return s.lastID[sequence]
+}
+
+// InTransaction runs fn while holding the store lock, all writes made by fn are rolled back if it returns an error.
+// Nested calls reuse the outer transaction.
+func (s *Store) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txCtxKey{}) == s {
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.undo = nil
+	defer func() {
+		s.undo = nil
+	}()
+
+	if err := fn(context.WithValue(ctx, txCtxKey{}, s)); err != nil {
+		for i := len(s.undo) - 1; i >= 0; i-- {
+			s.undo[i]()
+		}
+		return err
+	}
+	return nil
+}
+
+// minProcessedBlock returns the smallest last processed block among logs cursors of the chain.
+// Second value is false if the chain has no cursors.
+func (s *Store) minProcessedBlock(chainID string) (uint, bool) {
+	var res uint
+	found := false
+	for _, cursor := range s.logsCursors.rows {
+		if cursor.ChainID == chainID && (!found || cursor.LastProcessedBlock < res) {
+			res = cursor.LastProcessedBlock
+			found = true
+		}
+	}
+	return res, found
+}
+
+// PingContext always succeeds, it allows using the store in place of the database in health checks.
+func (s *Store) PingContext(context.Context) error {
+	return nil
+}
+
+// InsertAPIKey adds API key to the store, as there is no write method in the API keys repository.
+func (s *Store) InsertAPIKey(key *entity.APIKey) {
+	defer s.lock(context.Background())()
+
+	row := *key
+	row.ID = s.nextID("api_keys")
+	row.CreatedAt, row.UpdatedAt = now(), now()
+	s.apiKeys.put(row.KeyHash, &row)
+}
+
+func now() *time.Time {
+	t := time.Now()
+	return &t
+}
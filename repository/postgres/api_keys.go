@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type apiKeysRepo basePostgresRepo
+
+func NewAPIKeysRepo(table string, db *db.DB) entity.APIKeysRepo {
+	return (*apiKeysRepo)(newBasePostgresRepo(table, db))
+}
+
+func (r *apiKeysRepo) GetByKeyHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	q, args, err := sq.Select("*").
+		From(r.table).
+		Where(sq.Eq{"key_hash": keyHash, "revoked": false}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	key := new(entity.APIKey)
+	err = r.db.GetContext(ctx, key, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't get api key: %w", err)
+	}
+	return key, nil
+}
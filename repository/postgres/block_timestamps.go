@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -52,3 +53,51 @@ func (r *blockTimestampsRepo) GetByBlockNumber(ctx context.Context, chainID stri
 	}
 	return bt, nil
 }
+
+func (r *blockTimestampsRepo) GetLastBefore(ctx context.Context, chainID string, ts time.Time) (*entity.BlockTimestamp, error) {
+	q, args, err := sq.Select("*").
+		From(r.table).
+		Where(sq.Eq{"chain_id": chainID}).
+		Where(sq.Lt{"timestamp": ts}).
+		OrderBy("block_number DESC").
+		Limit(1).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	bt := new(entity.BlockTimestamp)
+	err = r.db.GetContext(ctx, bt, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't get last block timestamp: %w", err)
+	}
+	return bt, nil
+}
+
+func (r *blockTimestampsRepo) PruneOrphaned(ctx context.Context, chainID string, toBlock uint, limit uint) (uint, error) {
+	sub, args, err := sq.Select("bt.block_number").
+		From(r.table + " bt").
+		Where(sq.Eq{"bt.chain_id": chainID}).
+		Where(sq.LtOrEq{"bt.block_number": toBlock}).
+		Where("bt.block_number <= (SELECT COALESCE(MIN(lc.last_processed_block), -1) FROM logs_cursors lc WHERE lc.chain_id = bt.chain_id)").
+		Where("NOT EXISTS (SELECT 1 FROM logs l WHERE l.chain_id = bt.chain_id AND l.block_number = bt.block_number)").
+		Where("NOT EXISTS (SELECT 1 FROM logs_cursors lc WHERE lc.chain_id = bt.chain_id AND bt.block_number IN (lc.last_processed_block, lc.last_fetched_block))").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("can't build query: %w", err)
+	}
+	q, err := sq.Dollar.ReplacePlaceholders(fmt.Sprintf("DELETE FROM %s WHERE chain_id = ? AND block_number IN (%s)", r.table, sub))
+	if err != nil {
+		return 0, fmt.Errorf("can't build query: %w", err)
+	}
+	res, err := r.db.ExecContext(ctx, q, append([]interface{}{chainID}, args...)...)
+	if err != nil {
+		return 0, fmt.Errorf("can't prune orphaned block timestamps: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get number of pruned block timestamps: %w", err)
+	}
+	return uint(n), nil
+}
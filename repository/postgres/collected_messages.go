@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/ethereum/go-ethereum/common"
@@ -50,3 +51,58 @@ func (r *collectedMessagesRepo) GetByMsgHash(ctx context.Context, bridgeID strin
 	}
 	return msg, nil
 }
+
+func (r *collectedMessagesRepo) FindUnverified(ctx context.Context, bridgeID string, limit uint) ([]*entity.CollectedMessage, error) {
+	q, args, err := sq.Select("*").
+		From(r.table).
+		Where(sq.Eq{"bridge_id": bridgeID, "message_valid": nil}).
+		Where(sq.Or{sq.Eq{"verify_retry_at": nil}, sq.Expr("verify_retry_at <= NOW()")}).
+		OrderBy("log_id").
+		Limit(uint64(limit)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	msgs := make([]*entity.CollectedMessage, 0, limit)
+	err = r.db.SelectContext(ctx, &msgs, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't find unverified collected messages: %w", err)
+	}
+	return msgs, nil
+}
+
+func (r *collectedMessagesRepo) SetMessageValid(ctx context.Context, logID uint, valid bool) error {
+	q, args, err := sq.Update(r.table).
+		Set("message_valid", valid).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"log_id": logID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("can't build query: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return fmt.Errorf("can't update collected message: %w", err)
+	}
+	return nil
+}
+
+func (r *collectedMessagesRepo) SetVerifyFailed(ctx context.Context, logID uint, retryAfter time.Duration) error {
+	q, args, err := sq.Update(r.table).
+		Set("verify_attempts", sq.Expr("verify_attempts + 1")).
+		Set("verify_retry_at", sq.Expr("NOW() + make_interval(secs => ?)", retryAfter.Seconds())).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"log_id": logID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("can't build query: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return fmt.Errorf("can't update collected message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type collectedSignaturesRepo basePostgresRepo
+
+func NewCollectedSignaturesRepo(table string, db *db.DB) entity.CollectedSignaturesRepo {
+	return (*collectedSignaturesRepo)(newBasePostgresRepo(table, db))
+}
+
+func (r *collectedSignaturesRepo) Ensure(ctx context.Context, sig *entity.CollectedSignature) error {
+	q, args, err := sq.Insert(r.table).
+		Columns("log_id", "bridge_id", "msg_hash", "signature_index", "signer", "signature").
+		Values(sig.LogID, sig.BridgeID, sig.MsgHash, sig.Index, sig.Signer, sig.Signature).
+		Suffix("ON CONFLICT (log_id, signature_index) DO UPDATE SET updated_at = NOW(), signer = EXCLUDED.signer, signature = EXCLUDED.signature").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("can't build query: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return fmt.Errorf("can't insert collected signature: %w", err)
+	}
+	return nil
+}
+
+func (r *collectedSignaturesRepo) FindByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) ([]*entity.CollectedSignature, error) {
+	q, args, err := sq.Select("*").
+		From(r.table).
+		Where(sq.Eq{"bridge_id": bridgeID, "msg_hash": msgHash}).
+		OrderBy("log_id", "signature_index").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	sigs := make([]*entity.CollectedSignature, 0, 4)
+	err = r.db.SelectContext(ctx, &sigs, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't find collected signatures: %w", err)
+	}
+	return sigs, nil
+}
@@ -54,8 +54,8 @@ func (r *ercToNativeMessagesRepo) GetByMsgHash(ctx context.Context, bridgeID str
 func (r *ercToNativeMessagesRepo) FindPendingMessages(ctx context.Context, bridgeID string) ([]*entity.ErcToNativeMessage, error) {
 	q, args, err := sq.Select("m.*").
 		From(r.table + " m").
-		LeftJoin("executed_messages em ON em.message_id = m.msg_hash AND em.bridge_id = m.bridge_id").
-		Where(sq.Eq{"m.bridge_id": bridgeID, "em.log_id": nil}).
+		Join("message_status ms ON ms.bridge_id = m.bridge_id AND ms.msg_hash = m.msg_hash").
+		Where(sq.Eq{"m.bridge_id": bridgeID, "ms.execution_status": nil}).
 		OrderBy("m.created_at").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
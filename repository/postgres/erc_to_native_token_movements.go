@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+type ercToNativeTokenMovementsRepo basePostgresRepo
+
+func NewErcToNativeTokenMovementsRepo(table string, db *db.DB) entity.ErcToNativeTokenMovementsRepo {
+	return (*ercToNativeTokenMovementsRepo)(newBasePostgresRepo(table, db))
+}
+
+func (r *ercToNativeTokenMovementsRepo) Ensure(ctx context.Context, movement *entity.ErcToNativeTokenMovement) error {
+	q, args, err := sq.Insert(r.table).
+		Columns("log_id", "bridge_id", "token", "kind", "counterparty", "amount").
+		Values(movement.LogID, movement.BridgeID, movement.Token, movement.Kind, movement.Counterparty, movement.Amount).
+		Suffix("ON CONFLICT (log_id, kind) DO UPDATE SET updated_at = NOW()").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("can't build query: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return fmt.Errorf("can't insert token movement: %w", err)
+	}
+	return nil
+}
+
+func (r *ercToNativeTokenMovementsRepo) FindBalances(ctx context.Context, bridgeID string) ([]*entity.ErcToNativeTokenBalance, error) {
+	q, args, err := sq.Select(
+		"token",
+		"SUM(CASE WHEN kind IN ('deposit', 'swap_in') THEN amount WHEN kind IN ('release', 'swap_out') THEN -amount ELSE 0 END) AS locked",
+		"SUM(CASE WHEN kind = 'invest' THEN amount WHEN kind = 'withdraw' THEN -amount ELSE 0 END) AS invested",
+		"SUM(CASE WHEN kind = 'interest' THEN amount ELSE 0 END) AS claimed",
+	).
+		From(r.table).
+		Where(sq.Eq{"bridge_id": bridgeID}).
+		GroupBy("token").
+		OrderBy("token").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	balances := make([]*entity.ErcToNativeTokenBalance, 0, 2)
+	err = r.db.SelectContext(ctx, &balances, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't find token balances: %w", err)
+	}
+	return balances, nil
+}
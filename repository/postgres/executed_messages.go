@@ -19,9 +19,12 @@ func NewExecutedMessagesRepo(table string, db *db.DB) entity.ExecutedMessagesRep
 
 func (r *executedMessagesRepo) Ensure(ctx context.Context, msg *entity.ExecutedMessage) error {
 	q, args, err := sq.Insert(r.table).
-		Columns("log_id", "bridge_id", "message_id", "status").
-		Values(msg.LogID, msg.BridgeID, msg.MessageID, msg.Status).
-		Suffix("ON CONFLICT (log_id) DO UPDATE SET updated_at = NOW()").
+		Columns("log_id", "bridge_id", "message_id", "status", "gas_used", "revert_reason", "failed_call_target").
+		Values(msg.LogID, msg.BridgeID, msg.MessageID, msg.Status, msg.GasUsed, msg.RevertReason, msg.FailedCallTarget).
+		Suffix(fmt.Sprintf("ON CONFLICT (log_id) DO UPDATE SET updated_at = NOW(), "+
+			"gas_used = COALESCE(EXCLUDED.gas_used, %[1]s.gas_used), "+
+			"revert_reason = COALESCE(EXCLUDED.revert_reason, %[1]s.revert_reason), "+
+			"failed_call_target = COALESCE(EXCLUDED.failed_call_target, %[1]s.failed_call_target)", r.table)).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
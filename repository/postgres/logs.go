@@ -65,7 +65,7 @@ func (r *logsRepo) GetByID(ctx context.Context, id uint) (*entity.Log, error) {
 }
 
 //nolint:cyclop
-func (r *logsRepo) Find(ctx context.Context, filter entity.LogsFilter) ([]*entity.Log, error) {
+func logsFilterCond(filter entity.LogsFilter) sq.And {
 	cond := sq.And{}
 	if filter.ChainID != nil {
 		cond = append(cond, sq.Eq{"chain_id": *filter.ChainID})
@@ -97,10 +97,13 @@ func (r *logsRepo) Find(ctx context.Context, filter entity.LogsFilter) ([]*entit
 	if filter.DataLength != nil {
 		cond = append(cond, sq.Eq{"length(data)": *filter.DataLength})
 	}
+	return cond
+}
 
+func (r *logsRepo) Find(ctx context.Context, filter entity.LogsFilter) ([]*entity.Log, error) {
 	q, args, err := sq.Select("*").
 		From(r.table).
-		Where(cond).
+		Where(logsFilterCond(filter)).
 		OrderBy("chain_id", "block_number", "log_index").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -115,6 +118,44 @@ func (r *logsRepo) Find(ctx context.Context, filter entity.LogsFilter) ([]*entit
 	return logs, nil
 }
 
+// iteratePageSize is the number of logs loaded by a single Iterate query.
+const iteratePageSize = 1000
+
+// Iterate loads matching logs page by page, using keyset pagination over the logs ordering.
+// Each page is fully read before fn is called, so fn can query the database without holding an open cursor.
+func (r *logsRepo) Iterate(ctx context.Context, filter entity.LogsFilter, fn func(*entity.Log) error) error {
+	var last *entity.Log
+	for {
+		cond := logsFilterCond(filter)
+		if last != nil {
+			cond = append(cond, sq.Expr("(chain_id, block_number, log_index) > (?, ?, ?)", last.ChainID, last.BlockNumber, last.LogIndex))
+		}
+		q, args, err := sq.Select("*").
+			From(r.table).
+			Where(cond).
+			OrderBy("chain_id", "block_number", "log_index").
+			Limit(iteratePageSize).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("can't build query: %w", err)
+		}
+		logs := make([]*entity.Log, 0, iteratePageSize)
+		if err = r.db.SelectContext(ctx, &logs, q, args...); err != nil {
+			return fmt.Errorf("can't iterate logs by filter query: %w", err)
+		}
+		for _, log := range logs {
+			if err = fn(log); err != nil {
+				return err
+			}
+		}
+		if len(logs) < iteratePageSize {
+			return nil
+		}
+		last = logs[len(logs)-1]
+	}
+}
+
 func (r *logsRepo) FindByIDs(ctx context.Context, ids []uint) ([]*entity.Log, error) {
 	q, args, err := sq.Select("*").
 		From(r.table).
@@ -132,3 +173,48 @@ func (r *logsRepo) FindByIDs(ctx context.Context, ids []uint) ([]*entity.Log, er
 	}
 	return logs, nil
 }
+
+// logReferences lists tables and columns referencing logs, referenced logs are never pruned.
+var logReferences = []struct {
+	table  string
+	column string
+}{
+	{"sent_messages", "log_id"},
+	{"signed_messages", "log_id"},
+	{"collected_messages", "log_id"},
+	{"executed_messages", "log_id"},
+	{"sent_information_requests", "log_id"},
+	{"signed_information_requests", "log_id"},
+	{"executed_information_requests", "log_id"},
+	{"erc_to_native_token_movements", "log_id"},
+	{"bridge_validators", "log_id"},
+	{"bridge_validators", "removed_log_id"},
+}
+
+func (r *logsRepo) PruneUnreferenced(ctx context.Context, chainID string, toBlock uint, limit uint) (uint, error) {
+	builder := sq.Select("l.id").
+		From(r.table + " l").
+		Where(sq.Eq{"l.chain_id": chainID}).
+		Where(sq.LtOrEq{"l.block_number": toBlock}).
+		Where("l.block_number <= (SELECT COALESCE(MIN(lc.last_processed_block), -1) FROM logs_cursors lc WHERE lc.chain_id = l.chain_id)")
+	for _, ref := range logReferences {
+		builder = builder.Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s t WHERE t.%s = l.id)", ref.table, ref.column))
+	}
+	sub, args, err := builder.Limit(uint64(limit)).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("can't build query: %w", err)
+	}
+	q, err := sq.Dollar.ReplacePlaceholders(fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", r.table, sub))
+	if err != nil {
+		return 0, fmt.Errorf("can't build query: %w", err)
+	}
+	res, err := r.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return 0, fmt.Errorf("can't prune unreferenced logs: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get number of pruned logs: %w", err)
+	}
+	return uint(n), nil
+}
@@ -18,10 +18,22 @@ func NewLogsCursorRepo(table string, db *db.DB) entity.LogsCursorsRepo {
 }
 
 func (r *logsCursorsRepo) Ensure(ctx context.Context, cursor *entity.LogsCursor) error {
+	return r.upsert(ctx, cursor, "last_fetched_block = EXCLUDED.last_fetched_block, last_processed_block = EXCLUDED.last_processed_block")
+}
+
+func (r *logsCursorsRepo) UpdateFetchedBlock(ctx context.Context, cursor *entity.LogsCursor) error {
+	return r.upsert(ctx, cursor, "last_fetched_block = EXCLUDED.last_fetched_block")
+}
+
+func (r *logsCursorsRepo) UpdateProcessedBlock(ctx context.Context, cursor *entity.LogsCursor) error {
+	return r.upsert(ctx, cursor, "last_processed_block = EXCLUDED.last_processed_block")
+}
+
+func (r *logsCursorsRepo) upsert(ctx context.Context, cursor *entity.LogsCursor, set string) error {
 	q, args, err := sq.Insert(r.table).
 		Columns("chain_id", "address", "last_fetched_block", "last_processed_block").
 		Values(cursor.ChainID, cursor.Address, cursor.LastFetchedBlock, cursor.LastProcessedBlock).
-		Suffix("ON CONFLICT (chain_id, address) DO UPDATE SET updated_at = NOW(), last_fetched_block = EXCLUDED.last_fetched_block, last_processed_block = EXCLUDED.last_processed_block").
+		Suffix("ON CONFLICT (chain_id, address) DO UPDATE SET updated_at = NOW(), " + set).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
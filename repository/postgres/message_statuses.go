@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+)
+
+// refreshMessageStatusQuery recomputes message status from the message events,
+// %[1]s is the status table name, %[2]s and %[3]s are conditions for messages and erc_to_native_messages tables.
+const refreshMessageStatusQuery = `
+	INSERT INTO %[1]s (bridge_id, msg_hash, message_id, direction, state, signatures, collected,
+	                   execution_status, first_event_at, last_event_at)
+	SELECT m.bridge_id,
+	       m.msg_hash,
+	       m.message_id,
+	       m.direction,
+	       CASE
+	           WHEN e.status THEN 'executed'
+	           WHEN NOT e.status THEN 'failed'
+	           WHEN c.collected THEN 'collected'
+	           WHEN s.signatures > 0 THEN 'signed'
+	           ELSE 'pending'
+	           END,
+	       s.signatures,
+	       c.collected,
+	       e.status,
+	       t.first_event_at,
+	       t.last_event_at
+	FROM (SELECT bridge_id, msg_hash, message_id, direction FROM messages WHERE bridge_id = $1 AND %[2]s
+	      UNION ALL
+	      SELECT bridge_id, msg_hash, msg_hash, direction FROM erc_to_native_messages WHERE bridge_id = $1 AND %[3]s) m
+	         CROSS JOIN LATERAL (SELECT count(*) AS signatures
+	                             FROM signed_messages
+	                             WHERE bridge_id = m.bridge_id AND msg_hash = m.msg_hash) s
+	         CROSS JOIN LATERAL (SELECT EXISTS(SELECT 1
+	                                           FROM collected_messages
+	                                           WHERE bridge_id = m.bridge_id AND msg_hash = m.msg_hash) AS collected) c
+	         CROSS JOIN LATERAL (SELECT bool_or(status) AS status
+	                             FROM executed_messages
+	                             WHERE bridge_id = m.bridge_id AND message_id = m.message_id) e
+	         CROSS JOIN LATERAL (SELECT min(bt.timestamp) AS first_event_at, max(bt.timestamp) AS last_event_at
+	                             FROM (SELECT log_id FROM sent_messages WHERE bridge_id = m.bridge_id AND msg_hash = m.msg_hash
+	                                   UNION ALL
+	                                   SELECT log_id FROM signed_messages WHERE bridge_id = m.bridge_id AND msg_hash = m.msg_hash
+	                                   UNION ALL
+	                                   SELECT log_id FROM collected_messages WHERE bridge_id = m.bridge_id AND msg_hash = m.msg_hash
+	                                   UNION ALL
+	                                   SELECT log_id FROM executed_messages WHERE bridge_id = m.bridge_id AND message_id = m.message_id) ev
+	                                      JOIN logs l ON l.id = ev.log_id
+	                                      JOIN block_timestamps bt ON bt.chain_id = l.chain_id AND bt.block_number = l.block_number) t
+	ON CONFLICT (bridge_id, msg_hash) DO UPDATE SET state            = EXCLUDED.state,
+	                                                signatures       = EXCLUDED.signatures,
+	                                                collected        = EXCLUDED.collected,
+	                                                execution_status = EXCLUDED.execution_status,
+	                                                first_event_at   = EXCLUDED.first_event_at,
+	                                                last_event_at    = EXCLUDED.last_event_at,
+	                                                updated_at       = NOW()`
+
+type messageStatusesRepo basePostgresRepo
+
+func NewMessageStatusesRepo(table string, db *db.DB) entity.MessageStatusesRepo {
+	return (*messageStatusesRepo)(newBasePostgresRepo(table, db))
+}
+
+func (r *messageStatusesRepo) Refresh(ctx context.Context, bridgeID string, msgHash common.Hash) error {
+	q := fmt.Sprintf(refreshMessageStatusQuery, r.table, "msg_hash = $2", "msg_hash = $2")
+	_, err := r.db.ExecContext(ctx, q, bridgeID, msgHash)
+	if err != nil {
+		return fmt.Errorf("can't refresh message status: %w", err)
+	}
+	return nil
+}
+
+func (r *messageStatusesRepo) RefreshByMessageID(ctx context.Context, bridgeID string, messageID common.Hash) error {
+	q := fmt.Sprintf(refreshMessageStatusQuery, r.table, "message_id = $2", "msg_hash = $2")
+	_, err := r.db.ExecContext(ctx, q, bridgeID, messageID)
+	if err != nil {
+		return fmt.Errorf("can't refresh message status: %w", err)
+	}
+	return nil
+}
+
+func (r *messageStatusesRepo) GetByMsgHash(ctx context.Context, bridgeID string, msgHash common.Hash) (*entity.MessageStatus, error) {
+	q, args, err := sq.Select("*").
+		From(r.table).
+		Where(sq.Eq{"bridge_id": bridgeID, "msg_hash": msgHash}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	status := new(entity.MessageStatus)
+	err = r.db.GetContext(ctx, status, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't get message status: %w", err)
+	}
+	return status, nil
+}
+
+func (r *messageStatusesRepo) CountByState(ctx context.Context, bridgeID string) (map[entity.MessageState]uint, error) {
+	q, args, err := sq.Select("state", "count(*) AS count").
+		From(r.table).
+		Where(sq.Eq{"bridge_id": bridgeID}).
+		GroupBy("state").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	rows := make([]struct {
+		State entity.MessageState `db:"state"`
+		Count uint                `db:"count"`
+	}, 0, 5)
+	err = r.db.SelectContext(ctx, &rows, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't count message statuses: %w", err)
+	}
+	res := make(map[entity.MessageState]uint, len(rows))
+	for _, row := range rows {
+		res[row.State] = row.Count
+	}
+	return res, nil
+}
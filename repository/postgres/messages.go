@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/ethereum/go-ethereum/common"
@@ -71,8 +72,8 @@ func (r *messagesRepo) GetByMessageID(ctx context.Context, bridgeID string, mess
 func (r *messagesRepo) FindPendingMessages(ctx context.Context, bridgeID string) ([]*entity.Message, error) {
 	q, args, err := sq.Select("m.*").
 		From(r.table + " m").
-		LeftJoin("executed_messages em ON em.message_id = m.message_id AND em.bridge_id = m.bridge_id").
-		Where(sq.Eq{"m.bridge_id": bridgeID, "em.log_id": nil}).
+		Join("message_status ms ON ms.bridge_id = m.bridge_id AND ms.msg_hash = m.msg_hash").
+		Where(sq.Eq{"m.bridge_id": bridgeID, "ms.execution_status": nil}).
 		OrderBy("m.created_at").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -86,3 +87,45 @@ func (r *messagesRepo) FindPendingMessages(ctx context.Context, bridgeID string)
 	}
 	return msgs, nil
 }
+
+func (r *messagesRepo) FindRecentPendingMessages(ctx context.Context, bridgeID string, since time.Time) ([]*entity.Message, error) {
+	q, args, err := sq.Select("m.*").
+		From(r.table + " m").
+		Join("message_status ms ON ms.bridge_id = m.bridge_id AND ms.msg_hash = m.msg_hash").
+		Where(sq.Eq{"m.bridge_id": bridgeID, "ms.execution_status": nil}).
+		Where(sq.Or{sq.Eq{"ms.first_event_at": nil}, sq.GtOrEq{"ms.first_event_at": since.UTC()}}).
+		OrderBy("m.created_at").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+	msgs := make([]*entity.Message, 0, 10)
+	err = r.db.SelectContext(ctx, &msgs, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't find recent messages: %w", err)
+	}
+	return msgs, nil
+}
+
+func (r *messagesRepo) SetSimulationResult(ctx context.Context, bridgeID string, msgHash common.Hash, success bool, errMsg string) error {
+	var simulationError *string
+	if !success {
+		simulationError = &errMsg
+	}
+	q, args, err := sq.Update(r.table).
+		Set("simulation_success", success).
+		Set("simulation_error", simulationError).
+		Set("simulated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"bridge_id": bridgeID, "msg_hash": msgHash}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("can't build query: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return fmt.Errorf("can't update message: %w", err)
+	}
+	return nil
+}
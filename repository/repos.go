@@ -7,24 +7,37 @@ import (
 	"github.com/omni/tokenbridge-monitor/config"
 	"github.com/omni/tokenbridge-monitor/db"
 	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
 	"github.com/omni/tokenbridge-monitor/repository/postgres"
 )
 
+// Transactor runs a function within a unit of work, all repository writes made with the context
+// passed to the function are applied atomically.
+type Transactor interface {
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type Repo struct {
 	LogsCursors                 entity.LogsCursorsRepo
 	Logs                        entity.LogsRepo
 	BlockTimestamps             entity.BlockTimestampsRepo
 	Messages                    entity.MessagesRepo
 	ErcToNativeMessages         entity.ErcToNativeMessagesRepo
+	ErcToNativeTokenMovements   entity.ErcToNativeTokenMovementsRepo
+	MessageStatuses             entity.MessageStatusesRepo
 	SentMessages                entity.SentMessagesRepo
 	SignedMessages              entity.SignedMessagesRepo
 	CollectedMessages           entity.CollectedMessagesRepo
+	CollectedSignatures         entity.CollectedSignaturesRepo
 	ExecutedMessages            entity.ExecutedMessagesRepo
 	InformationRequests         entity.InformationRequestsRepo
 	SentInformationRequests     entity.SentInformationRequestsRepo
 	SignedInformationRequests   entity.SignedInformationRequestsRepo
 	ExecutedInformationRequests entity.ExecutedInformationRequestsRepo
 	BridgeValidators            entity.BridgeValidatorsRepo
+	APIKeys                     entity.APIKeysRepo
+
+	transactor Transactor
 }
 
 func NewRepo(db *db.DB) *Repo {
@@ -34,18 +47,53 @@ func NewRepo(db *db.DB) *Repo {
 		BlockTimestamps:             postgres.NewBlockTimestampsRepo("block_timestamps", db),
 		Messages:                    postgres.NewMessagesRepo("messages", db),
 		ErcToNativeMessages:         postgres.NewErcToNativeMessagesRepo("erc_to_native_messages", db),
+		ErcToNativeTokenMovements:   postgres.NewErcToNativeTokenMovementsRepo("erc_to_native_token_movements", db),
+		MessageStatuses:             postgres.NewMessageStatusesRepo("message_status", db),
 		SentMessages:                postgres.NewSentMessagesRepo("sent_messages", db),
 		SignedMessages:              postgres.NewSignedMessagesRepo("signed_messages", db),
 		CollectedMessages:           postgres.NewCollectedMessagesRepo("collected_messages", db),
+		CollectedSignatures:         postgres.NewCollectedSignaturesRepo("collected_signatures", db),
 		ExecutedMessages:            postgres.NewExecutedMessagesRepo("executed_messages", db),
 		InformationRequests:         postgres.NewInformationRequestsRepo("information_requests", db),
 		SentInformationRequests:     postgres.NewSentInformationRequestsRepo("sent_information_requests", db),
 		SignedInformationRequests:   postgres.NewSignedInformationRequestsRepo("signed_information_requests", db),
 		ExecutedInformationRequests: postgres.NewExecutedInformationRequestsRepo("executed_information_requests", db),
 		BridgeValidators:            postgres.NewBridgeValidatorsRepo("bridge_validators", db),
+		APIKeys:                     postgres.NewAPIKeysRepo("api_keys", db),
+		transactor:                  db,
 	}
 }
 
+// NewMemoryRepo creates repositories backed by the given in-memory store, e.g. for tests and dry runs.
+func NewMemoryRepo(s *memory.Store) *Repo {
+	return &Repo{
+		LogsCursors:                 memory.NewLogsCursorRepo(s),
+		Logs:                        memory.NewLogsRepo(s),
+		BlockTimestamps:             memory.NewBlockTimestampsRepo(s),
+		Messages:                    memory.NewMessagesRepo(s),
+		ErcToNativeMessages:         memory.NewErcToNativeMessagesRepo(s),
+		ErcToNativeTokenMovements:   memory.NewErcToNativeTokenMovementsRepo(s),
+		MessageStatuses:             memory.NewMessageStatusesRepo(s),
+		SentMessages:                memory.NewSentMessagesRepo(s),
+		SignedMessages:              memory.NewSignedMessagesRepo(s),
+		CollectedMessages:           memory.NewCollectedMessagesRepo(s),
+		CollectedSignatures:         memory.NewCollectedSignaturesRepo(s),
+		ExecutedMessages:            memory.NewExecutedMessagesRepo(s),
+		InformationRequests:         memory.NewInformationRequestsRepo(s),
+		SentInformationRequests:     memory.NewSentInformationRequestsRepo(s),
+		SignedInformationRequests:   memory.NewSignedInformationRequestsRepo(s),
+		ExecutedInformationRequests: memory.NewExecutedInformationRequestsRepo(s),
+		BridgeValidators:            memory.NewBridgeValidatorsRepo(s),
+		APIKeys:                     memory.NewAPIKeysRepo(s),
+		transactor:                  s,
+	}
+}
+
+// InTransaction runs fn within a single unit of work, which is committed only if fn returns no error.
+func (r *Repo) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.transactor.InTransaction(ctx, fn)
+}
+
 func (r *Repo) FindPendingMessages(ctx context.Context, bridgeID string, bridgeMode config.BridgeMode) ([]entity.BridgeMessage, error) {
 	if bridgeMode == config.BridgeModeErcToNative {
 		msgs, err := r.ErcToNativeMessages.FindPendingMessages(ctx, bridgeID)
@@ -0,0 +1,428 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
+)
+
+var errRollback = errors.New("rollback")
+
+func TestMemoryRepo(t *testing.T) {
+	t.Parallel()
+
+	testRepo(t, repository.NewMemoryRepo(memory.NewStore()))
+}
+
+// TestPostgresRepo runs the same test suite against a real database,
+// it is skipped unless TEST_POSTGRES_HOST is set.
+func TestPostgresRepo(t *testing.T) {
+	t.Parallel()
+
+	host := os.Getenv("TEST_POSTGRES_HOST")
+	if host == "" {
+		t.Skip("TEST_POSTGRES_HOST is not set")
+	}
+	port, err := strconv.Atoi(os.Getenv("TEST_POSTGRES_PORT"))
+	if err != nil {
+		port = 5432
+	}
+	conn, err := db.NewDB(&config.DBConfig{
+		User:     os.Getenv("TEST_POSTGRES_USER"),
+		Password: config.Secret(os.Getenv("TEST_POSTGRES_PASSWORD")),
+		Host:     host,
+		Port:     port,
+		DB:       os.Getenv("TEST_POSTGRES_DB"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+	// migrations source is relative to the package directory, instead of the repository root
+	require.NoError(t, conn.MigrateFrom("file://../db/migrations"))
+
+	testRepo(t, repository.NewRepo(conn))
+}
+
+// testRepo checks behaviour shared by all repository implementations.
+// Random chain and bridge ids are used, so that it can be run against a non-empty database.
+func testRepo(t *testing.T, repo *repository.Repo) {
+	t.Helper()
+
+	//nolint:gosec
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	chainID := strconv.FormatInt(rnd.Int63n(1e15)+1e15, 10)
+	bridgeID := fmt.Sprintf("test-bridge-%d", rnd.Int63())
+	address := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	ctx := context.Background()
+
+	newLog := func(blockNumber, logIndex uint) *entity.Log {
+		topic := common.BigToHash(common.Big1)
+		return &entity.Log{
+			ChainID:         chainID,
+			Address:         address,
+			Topic0:          &topic,
+			Data:            []byte{1, 2, 3},
+			BlockNumber:     blockNumber,
+			LogIndex:        logIndex,
+			TransactionHash: common.BigToHash(common.Big2),
+		}
+	}
+
+	t.Run("logs", func(t *testing.T) {
+		logs := []*entity.Log{newLog(10, 0), newLog(10, 1), newLog(12, 0)}
+		require.NoError(t, repo.Logs.Ensure(ctx, logs...))
+		for _, log := range logs {
+			require.NotZero(t, log.ID)
+		}
+
+		dup := newLog(10, 1)
+		require.NoError(t, repo.Logs.Ensure(ctx, dup))
+		require.Equal(t, logs[1].ID, dup.ID)
+
+		log, err := repo.Logs.GetByID(ctx, logs[2].ID)
+		require.NoError(t, err)
+		require.Equal(t, uint(12), log.BlockNumber)
+		require.Equal(t, []byte{1, 2, 3}, log.Data)
+
+		from, to := uint(10), uint(11)
+		found, err := repo.Logs.Find(ctx, entity.LogsFilter{
+			ChainID:   &chainID,
+			Addresses: []common.Address{address},
+			FromBlock: &from,
+			ToBlock:   &to,
+		})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		require.Equal(t, logs[0].ID, found[0].ID)
+		require.Equal(t, logs[1].ID, found[1].ID)
+
+		found, err = repo.Logs.FindByIDs(ctx, []uint{logs[2].ID, logs[0].ID})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+	})
+
+	t.Run("logs cursors and block timestamps", func(t *testing.T) {
+		_, err := repo.LogsCursors.GetByChainIDAndAddress(ctx, chainID, address)
+		require.ErrorIs(t, err, db.ErrNotFound)
+
+		require.NoError(t, repo.LogsCursors.Ensure(ctx, &entity.LogsCursor{ChainID: chainID, Address: address, LastFetchedBlock: 20, LastProcessedBlock: 10}))
+		require.NoError(t, repo.LogsCursors.Ensure(ctx, &entity.LogsCursor{ChainID: chainID, Address: address, LastFetchedBlock: 30, LastProcessedBlock: 20}))
+		cursor, err := repo.LogsCursors.GetByChainIDAndAddress(ctx, chainID, address)
+		require.NoError(t, err)
+		require.Equal(t, uint(30), cursor.LastFetchedBlock)
+		require.Equal(t, uint(20), cursor.LastProcessedBlock)
+
+		require.NoError(t, repo.LogsCursors.UpdateFetchedBlock(ctx, &entity.LogsCursor{ChainID: chainID, Address: address, LastFetchedBlock: 40, LastProcessedBlock: 5}))
+		require.NoError(t, repo.LogsCursors.UpdateProcessedBlock(ctx, &entity.LogsCursor{ChainID: chainID, Address: address, LastFetchedBlock: 5, LastProcessedBlock: 30}))
+		cursor, err = repo.LogsCursors.GetByChainIDAndAddress(ctx, chainID, address)
+		require.NoError(t, err)
+		require.Equal(t, uint(40), cursor.LastFetchedBlock)
+		require.Equal(t, uint(30), cursor.LastProcessedBlock)
+
+		ts := time.Unix(1600000000, 0).UTC()
+		require.NoError(t, repo.BlockTimestamps.Ensure(ctx, &entity.BlockTimestamp{ChainID: chainID, BlockNumber: 10, Timestamp: ts}))
+		bt, err := repo.BlockTimestamps.GetByBlockNumber(ctx, chainID, 10)
+		require.NoError(t, err)
+		require.True(t, ts.Equal(bt.Timestamp))
+	})
+
+	t.Run("pending messages", func(t *testing.T) {
+		logs := []*entity.Log{newLog(20, 0), newLog(21, 0)}
+		require.NoError(t, repo.Logs.Ensure(ctx, logs...))
+
+		msgs := []*entity.Message{
+			{BridgeID: bridgeID, MsgHash: common.HexToHash("0xa1"), MessageID: common.HexToHash("0xb1"), RawMessage: []byte{1}},
+			{BridgeID: bridgeID, MsgHash: common.HexToHash("0xa2"), MessageID: common.HexToHash("0xb2"), RawMessage: []byte{2}},
+		}
+		for _, msg := range msgs {
+			require.NoError(t, repo.Messages.Ensure(ctx, msg))
+		}
+		require.NoError(t, repo.Messages.Ensure(ctx, msgs[0]))
+		ercMsg := &entity.ErcToNativeMessage{BridgeID: bridgeID, MsgHash: common.HexToHash("0xa3"), Value: "1", RawMessage: []byte{3}}
+		require.NoError(t, repo.ErcToNativeMessages.Ensure(ctx, ercMsg))
+		for _, msg := range msgs {
+			require.NoError(t, repo.MessageStatuses.Refresh(ctx, bridgeID, msg.MsgHash))
+		}
+		require.NoError(t, repo.MessageStatuses.Refresh(ctx, bridgeID, ercMsg.MsgHash))
+
+		pending, err := repo.FindPendingMessages(ctx, bridgeID, config.BridgeModeArbitraryMessage)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		require.Equal(t, msgs[0].MsgHash, pending[0].GetMsgHash())
+		require.Equal(t, msgs[1].MsgHash, pending[1].GetMsgHash())
+
+		require.NoError(t, repo.ExecutedMessages.Ensure(ctx, &entity.ExecutedMessage{LogID: logs[0].ID, BridgeID: bridgeID, MessageID: msgs[0].MessageID, Status: true}))
+		require.NoError(t, repo.ExecutedMessages.Ensure(ctx, &entity.ExecutedMessage{LogID: logs[1].ID, BridgeID: bridgeID, MessageID: ercMsg.MsgHash, Status: true}))
+		require.NoError(t, repo.MessageStatuses.RefreshByMessageID(ctx, bridgeID, msgs[0].MessageID))
+		require.NoError(t, repo.MessageStatuses.RefreshByMessageID(ctx, bridgeID, ercMsg.MsgHash))
+
+		pending, err = repo.FindPendingMessages(ctx, bridgeID, config.BridgeModeArbitraryMessage)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.Equal(t, msgs[1].MsgHash, pending[0].GetMsgHash())
+		// messages without indexed event timestamps are considered recent
+		recent, err := repo.Messages.FindRecentPendingMessages(ctx, bridgeID, time.Now())
+		require.NoError(t, err)
+		require.Len(t, recent, 1)
+		require.Equal(t, msgs[1].MsgHash, recent[0].MsgHash)
+
+		pending, err = repo.FindPendingMessages(ctx, bridgeID, config.BridgeModeErcToNative)
+		require.NoError(t, err)
+		require.Empty(t, pending)
+
+		executed, err := repo.ExecutedMessages.GetByMessageID(ctx, bridgeID, msgs[0].MessageID)
+		require.NoError(t, err)
+		require.Equal(t, logs[0].ID, executed.LogID)
+		_, err = repo.ExecutedMessages.GetByMessageID(ctx, bridgeID, msgs[1].MessageID)
+		require.ErrorIs(t, err, db.ErrNotFound)
+
+		require.NoError(t, repo.Messages.SetSimulationResult(ctx, bridgeID, msgs[1].MsgHash, false, "execution reverted"))
+		msg, err := repo.Messages.GetByMsgHash(ctx, bridgeID, msgs[1].MsgHash)
+		require.NoError(t, err)
+		require.False(t, *msg.SimulationSuccess)
+		require.Equal(t, "execution reverted", *msg.SimulationError)
+		require.NotNil(t, msg.SimulatedAt)
+		require.NoError(t, repo.Messages.SetSimulationResult(ctx, bridgeID, msgs[1].MsgHash, true, ""))
+		msg, err = repo.Messages.GetByMsgHash(ctx, bridgeID, msgs[1].MsgHash)
+		require.NoError(t, err)
+		require.True(t, *msg.SimulationSuccess)
+		require.Nil(t, msg.SimulationError)
+	})
+
+	t.Run("signatures and validators", func(t *testing.T) {
+		logs := []*entity.Log{newLog(30, 0), newLog(31, 0), newLog(32, 0)}
+		require.NoError(t, repo.Logs.Ensure(ctx, logs...))
+		signer1 := common.HexToAddress("0x02")
+		signer2 := common.HexToAddress("0x01")
+		msgHash := common.HexToHash("0xc1")
+
+		require.NoError(t, repo.SignedMessages.Ensure(ctx, &entity.SignedMessage{LogID: logs[0].ID, BridgeID: bridgeID, MsgHash: msgHash, Signer: signer1}))
+		require.NoError(t, repo.SignedMessages.Ensure(ctx, &entity.SignedMessage{LogID: logs[1].ID, BridgeID: bridgeID, MsgHash: msgHash, Signer: signer2}))
+		require.NoError(t, repo.SignedMessages.Ensure(ctx, &entity.SignedMessage{LogID: logs[2].ID, BridgeID: bridgeID, MsgHash: common.HexToHash("0xc2"), Signer: signer1}))
+
+		signed, err := repo.SignedMessages.FindByMsgHashes(ctx, bridgeID, []common.Hash{msgHash})
+		require.NoError(t, err)
+		require.Len(t, signed, 2)
+		require.Equal(t, signer2, signed[0].Signer)
+		require.Equal(t, signer1, signed[1].Signer)
+
+		latest, err := repo.SignedMessages.GetLatest(ctx, bridgeID, chainID, signer1)
+		require.NoError(t, err)
+		require.Equal(t, logs[2].ID, latest.LogID)
+
+		require.NoError(t, repo.BridgeValidators.Ensure(ctx, &entity.BridgeValidator{LogID: logs[0].ID, BridgeID: bridgeID, ChainID: chainID, Address: signer1}))
+		require.NoError(t, repo.BridgeValidators.Ensure(ctx, &entity.BridgeValidator{LogID: logs[1].ID, BridgeID: bridgeID, ChainID: chainID, Address: signer2}))
+		removedLogID := logs[2].ID
+		require.NoError(t, repo.BridgeValidators.Ensure(ctx, &entity.BridgeValidator{LogID: logs[1].ID, BridgeID: bridgeID, ChainID: chainID, Address: signer2, RemovedLogID: &removedLogID}))
+
+		validators, err := repo.BridgeValidators.FindActiveValidators(ctx, bridgeID, chainID)
+		require.NoError(t, err)
+		require.Len(t, validators, 1)
+		require.Equal(t, signer1, validators[0].Address)
+		_, err = repo.BridgeValidators.GetActiveValidator(ctx, bridgeID, chainID, signer2)
+		require.ErrorIs(t, err, db.ErrNotFound)
+	})
+
+	t.Run("transaction rollback", func(t *testing.T) {
+		addr := common.HexToAddress("0x2222222222222222222222222222222222222222")
+		err := repo.InTransaction(ctx, func(ctx context.Context) error {
+			if err := repo.LogsCursors.Ensure(ctx, &entity.LogsCursor{ChainID: chainID, Address: addr, LastFetchedBlock: 1}); err != nil {
+				return err
+			}
+			return repo.InTransaction(ctx, func(ctx context.Context) error {
+				if err := repo.Logs.Ensure(ctx, newLog(40, 0)); err != nil {
+					return err
+				}
+				return errRollback
+			})
+		})
+		require.ErrorIs(t, err, errRollback)
+
+		_, err = repo.LogsCursors.GetByChainIDAndAddress(ctx, chainID, addr)
+		require.ErrorIs(t, err, db.ErrNotFound)
+		from := uint(40)
+		found, err := repo.Logs.Find(ctx, entity.LogsFilter{ChainID: &chainID, FromBlock: &from})
+		require.NoError(t, err)
+		require.Empty(t, found)
+
+		require.NoError(t, repo.InTransaction(ctx, func(ctx context.Context) error {
+			return repo.LogsCursors.Ensure(ctx, &entity.LogsCursor{ChainID: chainID, Address: addr, LastFetchedBlock: 1})
+		}))
+		_, err = repo.LogsCursors.GetByChainIDAndAddress(ctx, chainID, addr)
+		require.NoError(t, err)
+	})
+
+	t.Run("pruning", func(t *testing.T) {
+		ts := time.Unix(1600000000, 0).UTC()
+		for i, block := range []uint{10, 11, 12, 20, 25} {
+			require.NoError(t, repo.BlockTimestamps.Ensure(ctx, &entity.BlockTimestamp{ChainID: chainID, BlockNumber: block, Timestamp: ts.Add(time.Duration(i) * time.Minute)}))
+		}
+		bt, err := repo.BlockTimestamps.GetLastBefore(ctx, chainID, ts.Add(90*time.Second))
+		require.NoError(t, err)
+		require.Equal(t, uint(11), bt.BlockNumber)
+		_, err = repo.BlockTimestamps.GetLastBefore(ctx, chainID, ts)
+		require.ErrorIs(t, err, db.ErrNotFound)
+
+		// cursor created in the previous test has not processed any blocks yet
+		n, err := repo.Logs.PruneUnreferenced(ctx, chainID, 100, 10)
+		require.NoError(t, err)
+		require.Zero(t, n)
+
+		addr := common.HexToAddress("0x2222222222222222222222222222222222222222")
+		require.NoError(t, repo.LogsCursors.Ensure(ctx, &entity.LogsCursor{ChainID: chainID, Address: addr, LastFetchedBlock: 25, LastProcessedBlock: 25}))
+		n, err = repo.Logs.PruneUnreferenced(ctx, chainID, 100, 2)
+		require.NoError(t, err)
+		require.Equal(t, uint(2), n)
+		n, err = repo.Logs.PruneUnreferenced(ctx, chainID, 100, 2)
+		require.NoError(t, err)
+		require.Equal(t, uint(1), n)
+
+		found, err := repo.Logs.Find(ctx, entity.LogsFilter{ChainID: &chainID})
+		require.NoError(t, err)
+		blocks := make([]uint, len(found))
+		for i, log := range found {
+			blocks[i] = log.BlockNumber
+		}
+		require.Equal(t, []uint{20, 21, 30, 31, 32}, blocks)
+
+		n, err = repo.BlockTimestamps.PruneOrphaned(ctx, chainID, 100, 10)
+		require.NoError(t, err)
+		require.Equal(t, uint(3), n)
+		_, err = repo.BlockTimestamps.GetByBlockNumber(ctx, chainID, 10)
+		require.ErrorIs(t, err, db.ErrNotFound)
+		for _, block := range []uint{20, 25} {
+			_, err = repo.BlockTimestamps.GetByBlockNumber(ctx, chainID, block)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("message statuses", func(t *testing.T) {
+		ts := time.Unix(1600000000, 0).UTC()
+		logs := []*entity.Log{newLog(50, 0), newLog(51, 0), newLog(52, 0), newLog(53, 0)}
+		require.NoError(t, repo.Logs.Ensure(ctx, logs...))
+		for i, log := range logs {
+			require.NoError(t, repo.BlockTimestamps.Ensure(ctx, &entity.BlockTimestamp{ChainID: chainID, BlockNumber: log.BlockNumber, Timestamp: ts.Add(time.Duration(i) * time.Minute)}))
+		}
+		msg := &entity.Message{BridgeID: bridgeID, MsgHash: common.HexToHash("0xd1"), MessageID: common.HexToHash("0xe1"), Direction: entity.DirectionHomeToForeign, RawMessage: []byte{1}}
+
+		// execution is handled before the message itself is known
+		require.NoError(t, repo.ExecutedMessages.Ensure(ctx, &entity.ExecutedMessage{LogID: logs[3].ID, BridgeID: bridgeID, MessageID: msg.MessageID, Status: false}))
+		require.NoError(t, repo.MessageStatuses.RefreshByMessageID(ctx, bridgeID, msg.MessageID))
+		_, err := repo.MessageStatuses.GetByMsgHash(ctx, bridgeID, msg.MsgHash)
+		require.ErrorIs(t, err, db.ErrNotFound)
+
+		// failure details are kept when the execution is handled again without them
+		gasUsed, reason, target := uint(50000), "not allowed", common.HexToAddress("0xe0")
+		require.NoError(t, repo.ExecutedMessages.Ensure(ctx, &entity.ExecutedMessage{LogID: logs[3].ID, BridgeID: bridgeID, MessageID: msg.MessageID, GasUsed: &gasUsed, RevertReason: &reason, FailedCallTarget: &target}))
+		require.NoError(t, repo.ExecutedMessages.Ensure(ctx, &entity.ExecutedMessage{LogID: logs[3].ID, BridgeID: bridgeID, MessageID: msg.MessageID}))
+		executed, err := repo.ExecutedMessages.GetByLogID(ctx, logs[3].ID)
+		require.NoError(t, err)
+		require.Equal(t, gasUsed, *executed.GasUsed)
+		require.Equal(t, reason, *executed.RevertReason)
+		require.Equal(t, target, *executed.FailedCallTarget)
+
+		require.NoError(t, repo.Messages.Ensure(ctx, msg))
+		require.NoError(t, repo.SentMessages.Ensure(ctx, &entity.SentMessage{LogID: logs[0].ID, BridgeID: bridgeID, MsgHash: msg.MsgHash}))
+		require.NoError(t, repo.SignedMessages.Ensure(ctx, &entity.SignedMessage{LogID: logs[1].ID, BridgeID: bridgeID, MsgHash: msg.MsgHash, Signer: common.HexToAddress("0x01")}))
+		require.NoError(t, repo.CollectedMessages.Ensure(ctx, &entity.CollectedMessage{LogID: logs[2].ID, BridgeID: bridgeID, MsgHash: msg.MsgHash, NumSignatures: 1}))
+		require.NoError(t, repo.MessageStatuses.Refresh(ctx, bridgeID, msg.MsgHash))
+		require.NoError(t, repo.MessageStatuses.Refresh(ctx, bridgeID, msg.MsgHash))
+
+		status, err := repo.MessageStatuses.GetByMsgHash(ctx, bridgeID, msg.MsgHash)
+		require.NoError(t, err)
+		require.Equal(t, entity.MessageStateFailed, status.State)
+		require.Equal(t, msg.MessageID, status.MessageID)
+		require.Equal(t, uint(1), status.Signatures)
+		require.True(t, status.Collected)
+		require.NotNil(t, status.ExecutionStatus)
+		require.False(t, *status.ExecutionStatus)
+		require.True(t, ts.Equal(*status.FirstEventAt))
+		require.True(t, ts.Add(3*time.Minute).Equal(*status.LastEventAt))
+
+		counts, err := repo.MessageStatuses.CountByState(ctx, bridgeID)
+		require.NoError(t, err)
+		require.Equal(t, map[entity.MessageState]uint{
+			entity.MessageStatePending:  1,
+			entity.MessageStateExecuted: 2,
+			entity.MessageStateFailed:   1,
+		}, counts)
+	})
+
+	t.Run("collected signatures", func(t *testing.T) {
+		collectedBridgeID := bridgeID + "-collected"
+		logs := []*entity.Log{newLog(60, 0), newLog(61, 0)}
+		require.NoError(t, repo.Logs.Ensure(ctx, logs...))
+		msgHash := common.HexToHash("0xd2")
+		for _, log := range logs {
+			require.NoError(t, repo.CollectedMessages.Ensure(ctx, &entity.CollectedMessage{LogID: log.ID, BridgeID: collectedBridgeID, MsgHash: msgHash, NumSignatures: 1}))
+		}
+
+		unverified, err := repo.CollectedMessages.FindUnverified(ctx, collectedBridgeID, 1)
+		require.NoError(t, err)
+		require.Len(t, unverified, 1)
+		require.Equal(t, logs[0].ID, unverified[0].LogID)
+
+		sig := &entity.CollectedSignature{LogID: logs[0].ID, BridgeID: collectedBridgeID, MsgHash: msgHash, Signer: common.HexToAddress("0x01"), Signature: []byte{1}}
+		require.NoError(t, repo.CollectedSignatures.Ensure(ctx, sig))
+		require.NoError(t, repo.CollectedSignatures.Ensure(ctx, sig))
+		require.NoError(t, repo.CollectedMessages.SetMessageValid(ctx, logs[0].ID, false))
+
+		sigs, err := repo.CollectedSignatures.FindByMsgHash(ctx, collectedBridgeID, msgHash)
+		require.NoError(t, err)
+		require.Len(t, sigs, 1)
+		require.Equal(t, sig.Signer, sigs[0].Signer)
+		unverified, err = repo.CollectedMessages.FindUnverified(ctx, collectedBridgeID, 10)
+		require.NoError(t, err)
+		require.Len(t, unverified, 1)
+		require.Equal(t, logs[1].ID, unverified[0].LogID)
+
+		require.NoError(t, repo.CollectedMessages.SetVerifyFailed(ctx, logs[1].ID, time.Hour))
+		unverified, err = repo.CollectedMessages.FindUnverified(ctx, collectedBridgeID, 10)
+		require.NoError(t, err)
+		require.Empty(t, unverified)
+	})
+
+	t.Run("erc to native token movements", func(t *testing.T) {
+		movementsBridgeID := bridgeID + "-movements"
+		logs := []*entity.Log{newLog(70, 0), newLog(70, 1), newLog(71, 0), newLog(72, 0)}
+		require.NoError(t, repo.Logs.Ensure(ctx, logs...))
+		sai, dai := common.HexToAddress("0x5a"), common.HexToAddress("0xda")
+		for _, movement := range []*entity.ErcToNativeTokenMovement{
+			{LogID: logs[0].ID, Token: sai, Kind: entity.TokenMovementDeposit, Amount: "100"},
+			{LogID: logs[1].ID, Token: sai, Kind: entity.TokenMovementSwapOut, Amount: "100"},
+			{LogID: logs[1].ID, Token: dai, Kind: entity.TokenMovementSwapIn, Amount: "100"},
+			{LogID: logs[2].ID, Token: dai, Kind: entity.TokenMovementInvest, Amount: "80"},
+			{LogID: logs[2].ID, Token: dai, Kind: entity.TokenMovementInvest, Amount: "80"},
+			{LogID: logs[3].ID, Token: dai, Kind: entity.TokenMovementRelease, Amount: "30"},
+			{LogID: logs[3].ID, Token: dai, Kind: entity.TokenMovementInterest, Amount: "5"},
+		} {
+			movement.BridgeID = movementsBridgeID
+			require.NoError(t, repo.ErcToNativeTokenMovements.Ensure(ctx, movement))
+		}
+
+		balances, err := repo.ErcToNativeTokenMovements.FindBalances(ctx, movementsBridgeID)
+		require.NoError(t, err)
+		require.Equal(t, []*entity.ErcToNativeTokenBalance{
+			{Token: sai, Locked: "0", Invested: "0", Claimed: "0"},
+			{Token: dai, Locked: "70", Invested: "80", Claimed: "5"},
+		}, balances)
+		balances, err = repo.ErcToNativeTokenMovements.FindBalances(ctx, bridgeID+"-unknown")
+		require.NoError(t, err)
+		require.Empty(t, balances)
+	})
+}
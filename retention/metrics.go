@@ -0,0 +1,21 @@
+package retention
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	PrunedRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "monitor",
+		Subsystem: "retention",
+		Name:      "pruned_rows_total",
+		Help:      "Shows the number of rows removed from the particular table by the retention pruner.",
+	}, []string{"chain_id", "table"})
+	PruneCutoffBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "monitor",
+		Subsystem: "retention",
+		Name:      "cutoff_block",
+		Help:      "Shows the latest block of the chain, up to which unreferenced rows were pruned during the last run.",
+	}, []string{"chain_id"})
+)
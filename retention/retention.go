@@ -0,0 +1,122 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/utils"
+)
+
+// Pruner periodically removes logs and block timestamps older than the retention horizon of their chain.
+// Only logs that are not referenced by any bridge records are removed, so that alerts and presenter
+// endpoints are not affected. Logs and timestamps above the last processed block of the chain are always kept.
+type Pruner struct {
+	logger logging.Logger
+	repo   *repository.Repo
+	cfg    *config.RetentionConfig
+	chains map[string]*config.ChainConfig
+}
+
+func NewPruner(logger logging.Logger, repo *repository.Repo, cfg *config.Config) *Pruner {
+	return &Pruner{
+		logger: logger,
+		repo:   repo,
+		cfg:    cfg.Retention,
+		chains: cfg.Chains,
+	}
+}
+
+// Start runs pruning with the configured interval, until the context is cancelled.
+func (p *Pruner) Start(ctx context.Context) {
+	p.logger.WithField("interval", p.cfg.Interval).Info("starting retention pruner")
+	for {
+		if err := p.Prune(ctx); err != nil {
+			p.logger.WithError(err).Error("failed to prune old data")
+		}
+		if utils.ContextSleep(ctx, p.cfg.Interval) == nil {
+			return
+		}
+	}
+}
+
+// Prune removes old data of all chains with non-zero retention horizon.
+func (p *Pruner) Prune(ctx context.Context) error {
+	names := make([]string, 0, len(p.chains))
+	for name := range p.chains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		horizon := p.cfg.ChainHorizon(name)
+		if horizon <= 0 {
+			continue
+		}
+		if err := p.pruneChain(ctx, p.chains[name].ChainID, horizon); err != nil {
+			return fmt.Errorf("can't prune chain %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (p *Pruner) pruneChain(ctx context.Context, chainID string, horizon time.Duration) error {
+	logger := p.logger.WithField("chain_id", chainID)
+	cutoff, err := p.repo.BlockTimestamps.GetLastBefore(ctx, chainID, time.Now().Add(-horizon))
+	if errors.Is(err, db.ErrNotFound) {
+		logger.Debug("no blocks older than retention horizon")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	PruneCutoffBlock.WithLabelValues(chainID).Set(float64(cutoff.BlockNumber))
+
+	// logs are pruned first, so that timestamps of their blocks become orphaned and can be pruned in the same run
+	logs, err := p.pruneBatches(ctx, chainID, "logs", func(ctx context.Context) (uint, error) {
+		return p.repo.Logs.PruneUnreferenced(ctx, chainID, cutoff.BlockNumber, p.cfg.BatchSize)
+	})
+	if err != nil {
+		return err
+	}
+	timestamps, err := p.pruneBatches(ctx, chainID, "block_timestamps", func(ctx context.Context) (uint, error) {
+		return p.repo.BlockTimestamps.PruneOrphaned(ctx, chainID, cutoff.BlockNumber, p.cfg.BatchSize)
+	})
+	if err != nil {
+		return err
+	}
+	logger.WithFields(logrus.Fields{
+		"cutoff_block":     cutoff.BlockNumber,
+		"cutoff_timestamp": cutoff.Timestamp,
+		"logs":             logs,
+		"block_timestamps": timestamps,
+	}).Info("pruned old data")
+	return nil
+}
+
+// pruneBatches calls prune until it removes less than a full batch, each batch is removed in a separate statement,
+// so that table locks are not held for a long time.
+func (p *Pruner) pruneBatches(ctx context.Context, chainID, table string, prune func(ctx context.Context) (uint, error)) (uint, error) {
+	var total uint
+	for {
+		n, err := prune(ctx)
+		if err != nil {
+			return total, fmt.Errorf("can't prune %s: %w", table, err)
+		}
+		total += n
+		PrunedRows.WithLabelValues(chainID, table).Add(float64(n))
+		if n < p.cfg.BatchSize {
+			return total, nil
+		}
+		if err = ctx.Err(); err != nil {
+			return total, fmt.Errorf("can't prune %s: %w", table, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package retention_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/config"
+	"github.com/omni/tokenbridge-monitor/db"
+	"github.com/omni/tokenbridge-monitor/entity"
+	"github.com/omni/tokenbridge-monitor/logging"
+	"github.com/omni/tokenbridge-monitor/repository"
+	"github.com/omni/tokenbridge-monitor/repository/memory"
+	"github.com/omni/tokenbridge-monitor/retention"
+)
+
+func TestPruner_Prune(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo(memory.NewStore())
+	cfg := &config.Config{
+		Chains: map[string]*config.ChainConfig{
+			"mainnet": {ChainID: "1"},
+			"xdai":    {ChainID: "100"},
+		},
+		Retention: &config.RetentionConfig{
+			BatchSize: 1,
+			Horizon:   24 * time.Hour,
+			Chains: map[string]*config.RetentionPolicyConfig{
+				"xdai": {Horizon: 0},
+			},
+		},
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	address := common.HexToAddress("0x01")
+	var sent *entity.Log
+	for _, chainID := range []string{"1", "100"} {
+		require.NoError(t, repo.LogsCursors.Ensure(ctx, &entity.LogsCursor{ChainID: chainID, Address: address, LastFetchedBlock: 30, LastProcessedBlock: 30}))
+		for _, block := range []uint{10, 20, 30} {
+			require.NoError(t, repo.BlockTimestamps.Ensure(ctx, &entity.BlockTimestamp{ChainID: chainID, BlockNumber: block, Timestamp: old}))
+			logs := []*entity.Log{
+				{ChainID: chainID, Address: address, BlockNumber: block, LogIndex: 0},
+				{ChainID: chainID, Address: address, BlockNumber: block, LogIndex: 1},
+			}
+			require.NoError(t, repo.Logs.Ensure(ctx, logs...))
+			if chainID == "1" && block == 20 {
+				sent = logs[1]
+			}
+		}
+	}
+	require.NoError(t, repo.SentMessages.Ensure(ctx, &entity.SentMessage{LogID: sent.ID, BridgeID: "test", MsgHash: common.HexToHash("0x01")}))
+
+	p := retention.NewPruner(logging.NullLogger(), repo, cfg)
+	require.NoError(t, p.Prune(ctx))
+
+	mainnet := "1"
+	logs, err := repo.Logs.Find(ctx, entity.LogsFilter{ChainID: &mainnet})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, sent.ID, logs[0].ID)
+	_, err = repo.BlockTimestamps.GetByBlockNumber(ctx, mainnet, 10)
+	require.ErrorIs(t, err, db.ErrNotFound)
+	// timestamps of blocks with referenced logs and of the last processed block are kept
+	for _, block := range []uint{20, 30} {
+		_, err = repo.BlockTimestamps.GetByBlockNumber(ctx, mainnet, block)
+		require.NoError(t, err)
+	}
+
+	xdai := "100"
+	logs, err = repo.Logs.Find(ctx, entity.LogsFilter{ChainID: &xdai})
+	require.NoError(t, err)
+	require.Len(t, logs, 6)
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"math/big"
+	"strings"
+)
+
+const etherDecimals = 18
+
+var etherUnit = new(big.Int).Exp(big.NewInt(10), big.NewInt(etherDecimals), nil)
+
+// WeiToEther converts the given amount of wei into the approximate amount of native coins.
+func WeiToEther(wei *big.Int) float64 {
+	res, _ := new(big.Rat).SetFrac(wei, etherUnit).Float64()
+	return res
+}
+
+// FormatEther formats the given amount of wei as the exact decimal amount of native coins, e.g. "1.5".
+func FormatEther(wei *big.Int) string {
+	res := new(big.Rat).SetFrac(wei, etherUnit).FloatString(etherDecimals)
+	res = strings.TrimRight(res, "0")
+	return strings.TrimSuffix(res, ".")
+}
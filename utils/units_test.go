@@ -0,0 +1,25 @@
+package utils_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/omni/tokenbridge-monitor/utils"
+)
+
+func TestFormatEther(t *testing.T) {
+	t.Parallel()
+
+	for wei, expected := range map[string]string{
+		"0":                    "0",
+		"1":                    "0.000000000000000001",
+		"1500000000000000000":  "1.5",
+		"20000000000000000000": "20",
+	} {
+		value, _ := new(big.Int).SetString(wei, 10)
+		require.Equal(t, expected, utils.FormatEther(value))
+	}
+	require.InDelta(t, 1.5, utils.WeiToEther(big.NewInt(1.5e18)), 1e-9)
+}